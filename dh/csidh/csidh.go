@@ -0,0 +1,352 @@
+package csidh
+
+import (
+	cryptoRand "crypto/rand"
+	"io"
+)
+
+// 511-bit number representing prime field element GF(p)
+type fp [numWords]uint64
+
+// Represents projective point on elliptic curve E over GF(p)
+type point struct {
+	x fp
+	z fp
+}
+
+// Curve coefficients
+type coeff struct {
+	a fp
+	c fp
+}
+
+// PrivateKey is a cSIDH/512 private key: a vector of primeCount exponents,
+// each in the range [-5, 5], encoded as signed 4-bit values, two per byte.
+type PrivateKey [PrivateKeySize]byte
+
+// PublicKey is a cSIDH/512 public key: the Montgomery coefficient A of the
+// supersingular elliptic curve y^2 = x^3 + Ax^2 + x, encoded in little-endian
+// order in the Montgomery domain.
+type PublicKey [PublicKeySize]byte
+
+// randFp generates random element from Fp.
+func randFp(v *fp, rng io.Reader) {
+	var buf [numWords * limbByteSize]byte
+	mask := uint64(1<<(pbits%limbBitSize)) - 1
+	for {
+		if _, err := io.ReadFull(rng, buf[:]); err != nil {
+			panic("csidh: can't read random number")
+		}
+		v.fromBytes(buf[:])
+		v[len(v)-1] &= mask
+		if isLess(v, &p) {
+			return
+		}
+	}
+}
+
+func (v *fp) fromBytes(in []byte) {
+	*v = fp{}
+	for i := range in {
+		v[i/limbByteSize] |= uint64(in[i]) << (8 * uint(i%limbByteSize))
+	}
+}
+
+func (v *fp) toBytes(out []byte) {
+	for i := range out {
+		out[i] = byte(v[i/limbByteSize] >> (8 * uint(i%limbByteSize)))
+	}
+}
+
+// exponent returns the i-th exponent of the private key in constant time.
+// The exponent of index 2j is stored in the high nibble of byte j, the one
+// of index 2j+1 in the low nibble.
+func (k *PrivateKey) exponent(i int) int8 {
+	return int8(k[i>>1]<<(uint(i%2)*4)) >> 4
+}
+
+// cofactorMul helper implements batch cofactor multiplication as described
+// in the ia.cr/2018/383 (algo. 3). Returns tuple of two booleans, first indicates
+// if function has finished successfully. In case first return value is true,
+// second return value indicates if curve represented by coffactor 'a' is
+// supersingular.
+// Implementation uses divide-and-conquer strategy and recursion in order to
+// speed up calculation of Q_i = [(p+1)/l_i] * P.
+// Implementation is not constant time, but it operates on public data only.
+func cofactorMul(p *point, a *coeff, halfL, halfR int, order *fp) (bool, bool) {
+	var Q point
+	var r1, d1, r2, d2 bool
+	if (halfR - halfL) == 1 {
+		// base case
+		if !p.z.isZero() {
+			var tmp = fp{primes[halfL]}
+			xMul(p, p, a, &tmp)
+
+			if !p.z.isZero() {
+				// order does not divide p+1 -> ordinary curve
+				return true, false
+			}
+
+			mul512(order, order, primes[halfL])
+			if isLess(&fourSqrtP, order) {
+				// order > 4*sqrt(p) -> supersingular curve
+				return true, true
+			}
+		}
+		return false, false
+	}
+
+	// perform another recursive step
+	mid := halfL + ((halfR - halfL + 1) / 2)
+	var mulL, mulR = fp{1}, fp{1}
+	// compute u = primes_1 * ... * primes_m
+	for i := halfL; i < mid; i++ {
+		mul512(&mulR, &mulR, primes[i])
+	}
+	// compute v = primes_m+1 * ... * primes_n
+	for i := mid; i < halfR; i++ {
+		mul512(&mulL, &mulL, primes[i])
+	}
+
+	// calculate Q_i
+	xMul(&Q, p, a, &mulR)
+	xMul(p, p, a, &mulL)
+
+	d1, r1 = cofactorMul(&Q, a, mid, halfR, order)
+	d2, r2 = cofactorMul(p, a, halfL, mid, order)
+	return d1 || d2, r1 || r2
+}
+
+// samplePoints sets P[0] to a random point on the curve y^2 = x^3 + Ax^2 + x
+// and P[1] to a random point on its quadratic twist. A is in affine form.
+func samplePoints(P *[2]point, A *fp, rng io.Reader) {
+	var got [2]bool
+	for !got[0] || !got[1] {
+		var x, rhs fp
+		randFp(&x, rng)
+		montEval(&rhs, A, &x)
+		twist := rhs.isNonQuadRes()
+		if !got[twist] {
+			P[twist] = point{x: x, z: one}
+			got[twist] = true
+		}
+	}
+}
+
+// cswapcoeff swaps c1 with c2 in constant time. The 'choice' parameter
+// must have a value of either 1 (results in swap) or 0 (results in
+// no-swap).
+func cswapcoeff(c1, c2 *coeff, choice uint8) {
+	cswap512(&c1.a, &c2.a, choice)
+	cswap512(&c1.c, &c2.c, choice)
+}
+
+// groupAction evaluates the action of the ideal class given by the
+// exponents of prv on the Montgomery curve with coefficient A.
+//
+// For each prime l_i, expMax isogenies of degree l_i are computed: the
+// first |e_i| of them are real, the others are dummy ones, in which the
+// points are multiplied by l_i instead. Kernel points are taken from the
+// curve if e_i ≥ 0, and from the twist otherwise, selecting between the
+// two points in constant time. The only branches depend on the random
+// points, in case a kernel point is the point at infinity.
+func groupAction(A *fp, prv *PrivateKey, rng io.Reader) {
+	var steps [primeCount]uint8 // steps left, public
+	var real [primeCount]uint8  // real isogenies left, secret
+	var twist [primeCount]uint8 // sign of the exponents, secret
+	var a = coeff{a: *A, c: one}
+
+	for i := range primes {
+		e := prv.exponent(i)
+		s := e >> 7
+		abs := (e ^ s) - s
+		// Saturate to expMax exponents out of range.
+		over := (expMax - abs) >> 7
+		abs = (abs &^ over) | (expMax & over)
+
+		steps[i] = uint8(expMax)
+		real[i] = uint8(abs)
+		twist[i] = uint8(s & 1)
+	}
+
+	for done := false; !done; {
+		var P [2]point
+		samplePoints(&P, &a.a, rng)
+
+		// Clear the cofactor 4 and the primes which are done.
+		var k = fp{4}
+		for i := range primes {
+			if steps[i] == 0 {
+				mul512(&k, &k, primes[i])
+			}
+		}
+		xMul(&P[0], &P[0], &a, &k)
+		xMul(&P[1], &P[1], &a, &k)
+
+		for i := range primes {
+			if steps[i] == 0 {
+				continue
+			}
+
+			var K point
+			var cof = fp{1}
+			var l = fp{primes[i]}
+			for j := i + 1; j < primeCount; j++ {
+				if steps[j] != 0 {
+					mul512(&cof, &cof, primes[j])
+				}
+			}
+
+			// P[0] is the point of the sign of e_i.
+			cswappoint(&P[0], &P[1], twist[i])
+			xMul(&K, &P[0], &a, &cof)
+			if K.z.isZero() {
+				// P[0] has no point of order l_i, remove it from P[1].
+				xMul(&P[1], &P[1], &a, &l)
+			} else {
+				var Pl [2]point
+				var img [2]point
+				var b = a
+
+				// A dummy isogeny maps P to [l_i]P.
+				xMul(&Pl[0], &P[0], &a, &l)
+				xMul(&Pl[1], &P[1], &a, &l)
+
+				// A real isogeny maps P[0] to its image, and P[1] to
+				// the image of [l_i]P[1].
+				img[0], img[1] = P[0], Pl[1]
+				xIsoN([]*point{&img[0], &img[1]}, &b, &K, primes[i])
+
+				isReal := uint8(ctIsNonZero64(uint64(real[i])))
+				cswappoint(&Pl[0], &img[0], isReal)
+				cswappoint(&Pl[1], &img[1], isReal)
+				cswapcoeff(&a, &b, isReal)
+				P = Pl
+
+				real[i] -= isReal
+				steps[i]--
+			}
+			cswappoint(&P[0], &P[1], twist[i])
+		}
+
+		// Normalize the curve coefficient, A = A/C.
+		modExpRdc512(&a.c, &a.c, &pMin1)
+		mulRdc(&a.a, &a.a, &a.c)
+		a.c = one
+
+		done = true
+		for i := range steps {
+			done = done && steps[i] == 0
+		}
+	}
+	*A = a.a
+}
+
+// GeneratePrivateKey generates a private key using entropy from rng. If
+// rng is nil, crypto/rand.Reader will be used.
+func GeneratePrivateKey(secret *PrivateKey, rng io.Reader) error {
+	var buf [64]byte
+	if rng == nil {
+		rng = cryptoRand.Reader
+	}
+	*secret = PrivateKey{}
+
+	for i := 0; i < primeCount; {
+		if _, err := io.ReadFull(rng, buf[:]); err != nil {
+			return err
+		}
+
+		for j := 0; j < len(buf) && i < primeCount; j++ {
+			if e := int8(buf[j]); e <= expMax && e >= -expMax {
+				secret[i>>1] |= (buf[j] & 0xF) << (4 - uint(i%2)*4)
+				i++
+			}
+		}
+	}
+	return nil
+}
+
+// KeyGen computes the public key corresponding to the given private key,
+// using randomness from rng. If rng is nil, crypto/rand.Reader will be used.
+//
+// Exponents of the private key out of the range [-5, 5] are treated as -5
+// or 5. Panics if rng fails.
+func KeyGen(public *PublicKey, secret *PrivateKey, rng io.Reader) {
+	var a fp
+	if rng == nil {
+		rng = cryptoRand.Reader
+	}
+	groupAction(&a, secret, rng)
+	a.toBytes(public[:])
+}
+
+// Shared calculates the shared secret from a private key and the public key
+// of the peer, using randomness from rng, and returns true on success. If
+// rng is nil, crypto/rand.Reader will be used.
+//
+// The shared secret is the Montgomery coefficient of the curve obtained by
+// the action of the private key on the curve of the public key, encoded as
+// a public key. The public key is validated first; if it is invalid, the
+// function returns false and the shared secret is set to zero. Panics if
+// rng fails.
+func Shared(shared *[SharedSecretSize]byte, secret *PrivateKey,
+	public *PublicKey, rng io.Reader) bool {
+	var a fp
+	if rng == nil {
+		rng = cryptoRand.Reader
+	}
+	if !Validate(public, rng) {
+		*shared = [SharedSecretSize]byte{}
+		return false
+	}
+	a.fromBytes(public[:])
+	groupAction(&a, secret, rng)
+	a.toBytes(shared[:])
+	return true
+}
+
+// Validate returns true if public is a valid cSIDH public key, and false
+// otherwise, using randomness from rng. If rng is nil, crypto/rand.Reader
+// will be used.
+//
+// More precisely, the function verifies that the curve
+//            y^2 = x^3 + A * x^2 + x
+// is supersingular. It is not constant time, as public keys are public.
+func Validate(public *PublicKey, rng io.Reader) bool {
+	var a fp
+	if rng == nil {
+		rng = cryptoRand.Reader
+	}
+	a.fromBytes(public[:])
+
+	// Check if in range
+	if !isLess(&a, &p) {
+		return false
+	}
+
+	// Check if pub represents a smooth Montgomery curve.
+	if a.equal(&two) || a.equal(&twoNeg) {
+		return false
+	}
+
+	// Check if pub represents a supersingular curve.
+	for {
+		var P point
+		var A = point{a, one}
+
+		// Randomly chosen P must have big enough order to check
+		// supersingularity. Probability of random P having big
+		// enough order is very high, as proven by W.Castryck et
+		// al. (ia.cr/2018/383, ch 5)
+		randFp(&P.x, rng)
+		P.z = one
+
+		xDbl(&P, &P, &A)
+		xDbl(&P, &P, &A)
+
+		done, res := cofactorMul(&P, &coeff{A.x, A.z}, 0, len(primes), &fp{1})
+		if done {
+			return res
+		}
+	}
+}
@@ -0,0 +1,283 @@
+package csidh
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+)
+
+// Possible values for "Status"
+const (
+	Valid               = iota // Indicates that shared secret must be agreed correctly
+	ValidPublicKey2            // Public key 2 must succeed validation
+	InvalidSharedSecret        // Calculated shared secret must be different than test vector
+	InvalidPublicKey1          // Public key 1 generated from private key must be different than test vector
+	InvalidPublicKey2          // Public key 2 must fail validation
+)
+
+var StatusValues = map[int]string{
+	Valid:               "valid",
+	ValidPublicKey2:     "valid_public_key2",
+	InvalidSharedSecret: "invalid_shared_secret",
+	InvalidPublicKey1:   "invalid_public_key1",
+	InvalidPublicKey2:   "invalid_public_key2",
+}
+
+var rng = rand.Reader
+
+type TestVector struct {
+	ID     int    `json:"Id"`
+	Pk1    string `json:"Pk1"`
+	Pr1    string `json:"Pr1"`
+	Pk2    string `json:"Pk2"`
+	Ss     string `json:"Ss"`
+	Status string `json:"status"`
+}
+
+type TestVectors struct {
+	Vectors []TestVector `json:"Vectors"`
+}
+
+func TestCompare64(t *testing.T) {
+	const s uint64 = 0xFFFFFFFFFFFFFFFF
+	var val1 = fp{0, 2, 3, 4, 5, 6, 7, 8}
+	var val2 = fp{s, s, s, s, s, s, s, s}
+	var fp fp
+
+	if !fp.isZero() {
+		t.Errorf("isZero returned true, where it should be false")
+	}
+	if val1.isZero() {
+		t.Errorf("isZero returned false, where it should be true")
+	}
+	if val2.isZero() {
+		t.Errorf("isZero returned false, where it should be true")
+	}
+}
+
+func TestEphemeralKeyExchange(t *testing.T) {
+	var ss1, ss2 [SharedSecretSize]byte
+	var prv1, prv2 PrivateKey
+	var pub1, pub2 PublicKey
+
+	prvBytes1 := []byte{0xaa, 0x54, 0xe4, 0xd4, 0xd0, 0xbd, 0xee, 0xcb, 0xf4, 0xd0, 0xc2, 0xbc, 0x52, 0x44, 0x11, 0xee, 0xe1, 0x14, 0xd2, 0x24, 0xe5, 0x0, 0xcc, 0xf5, 0xc0, 0xe1, 0x1e, 0xb3, 0x43, 0x52, 0x45, 0xbe, 0xfb, 0x54, 0xc0, 0x55, 0xb2}
+	copy(prv1[:], prvBytes1)
+	KeyGen(&pub1, &prv1, rng)
+
+	test.CheckNoErr(t, GeneratePrivateKey(&prv2, rng), "PrivateKey generation failed")
+	KeyGen(&pub2, &prv2, rng)
+
+	if !Shared(&ss1, &prv2, &pub1, rng) {
+		t.Fatal("Derivation failed")
+	}
+	if !Shared(&ss2, &prv1, &pub2, rng) {
+		t.Fatal("Derivation failed")
+	}
+	if !bytes.Equal(ss1[:], ss2[:]) {
+		t.Error("ss1 != ss2")
+	}
+}
+
+func TestGeneratePrivateKey(t *testing.T) {
+	var prv PrivateKey
+	for i := 0; i < numIter; i++ {
+		test.CheckNoErr(t, GeneratePrivateKey(&prv, rng), "PrivateKey generation failed")
+		for j := 0; j < primeCount; j++ {
+			if e := prv.exponent(j); e > expMax || e < -expMax {
+				test.ReportError(t, e, expMax, j)
+			}
+		}
+	}
+}
+
+func TestKeyGenZero(t *testing.T) {
+	// The action of the neutral element leaves the starting curve
+	// y^2 = x^3 + x unchanged.
+	var prv PrivateKey
+	var pub PublicKey
+	KeyGen(&pub, &prv, rng)
+	if pub != (PublicKey{}) {
+		t.Errorf("public key of the zero private key must be zero")
+	}
+}
+
+func TestValidateNegative(t *testing.T) {
+	var pk PublicKey
+	var ss [SharedSecretSize]byte
+	var prv PrivateKey
+	var q = p
+
+	q.toBytes(pk[:])
+	if Validate(&pk, rng) {
+		t.Error("Public key == p has been validated")
+	}
+
+	q[0]++
+	q.toBytes(pk[:])
+	if Validate(&pk, rng) {
+		t.Error("Public key > p has been validated")
+	}
+
+	two.toBytes(pk[:])
+	if Validate(&pk, rng) {
+		t.Error("Public key == 2 has been validated")
+	}
+
+	twoNeg.toBytes(pk[:])
+	if Validate(&pk, rng) {
+		t.Error("Public key == -2 has been validated")
+	}
+
+	for i := range ss {
+		ss[i] = 0xFF
+	}
+	if Shared(&ss, &prv, &pk, rng) || ss != ([SharedSecretSize]byte{}) {
+		t.Error("Shared secret derived from invalid public key")
+	}
+}
+
+// Test vectors generated by reference implementation.
+func TestKAT(t *testing.T) {
+	var tests TestVectors
+	// Helper checks if e==true and reports an error if not.
+	checkExpr := func(e bool, vec *TestVector, t *testing.T, msg string) {
+		t.Helper()
+		if !e {
+			t.Errorf("[Test ID=%d] "+msg, vec.ID)
+		}
+	}
+	decode := func(out []byte, in string) {
+		t.Helper()
+		buf, err := hex.DecodeString(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(buf) != len(out) {
+			t.Fatalf("wrong length %d", len(buf))
+		}
+		copy(out, buf)
+	}
+	// checkSharedSecret implements nominal case - imports asymmetric keys for
+	// both parties, derives secret key and compares it to value in test vector.
+	// Comparison must succeed in case status is "Valid" in any other case
+	// it must fail.
+	checkSharedSecret := func(vec *TestVector, t *testing.T, status int) {
+		var prv1 PrivateKey
+		var pub2 PublicKey
+		var ss, ssExp [SharedSecretSize]byte
+
+		decode(prv1[:], vec.Pr1)
+		decode(pub2[:], vec.Pk2)
+		decode(ssExp[:], vec.Ss)
+		checkExpr(Shared(&ss, &prv1, &pub2, rng), vec, t, "Error when deriving key")
+		checkExpr((ss == ssExp) == (status == Valid), vec, t, "Unexpected value of shared secret")
+	}
+	// checkPublicKey1 generates the public key for the private key of
+	// party A, and compares it to the public key of the test vector.
+	// Comparison must succeed in case status is "Valid" and fail otherwise.
+	checkPublicKey1 := func(vec *TestVector, t *testing.T, status int) {
+		var prv PrivateKey
+		var pub, pubExp PublicKey
+
+		decode(prv[:], vec.Pr1)
+		decode(pubExp[:], vec.Pk1)
+		KeyGen(&pub, &prv, rng)
+		checkExpr((pub == pubExp) == (status == Valid), vec, t, "Unexpected value of public key")
+	}
+	// checkPublicKey2 tests key validation of the public key of party B,
+	// which must succeed in case status is "Valid" and fail otherwise.
+	checkPublicKey2 := func(vec *TestVector, t *testing.T, status int) {
+		var pub PublicKey
+		decode(pub[:], vec.Pk2)
+		checkExpr(
+			Validate(&pub, rng) == (status == Valid || status == ValidPublicKey2),
+			vec, t, "PublicKey has been validated incorrectly")
+	}
+	// Load test data
+	file, err := os.Open(katFile)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer file.Close()
+	err = json.NewDecoder(file).Decode(&tests)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// The algorithm is relatively slow, so in short mode only the first
+	// vectors of each kind are checked.
+	count := map[string]int{}
+	for i := range tests.Vectors {
+		vec := &tests.Vectors[i]
+		if count[vec.Status]++; testing.Short() && count[vec.Status] > numIter {
+			continue
+		}
+		switch vec.Status {
+		case StatusValues[Valid]:
+			checkSharedSecret(vec, t, Valid)
+			checkPublicKey1(vec, t, Valid)
+			checkPublicKey2(vec, t, Valid)
+		case StatusValues[InvalidSharedSecret]:
+			checkSharedSecret(vec, t, InvalidSharedSecret)
+		case StatusValues[InvalidPublicKey1]:
+			checkPublicKey1(vec, t, InvalidPublicKey1)
+		case StatusValues[InvalidPublicKey2]:
+			checkPublicKey2(vec, t, InvalidPublicKey2)
+		case StatusValues[ValidPublicKey2]:
+			checkPublicKey2(vec, t, ValidPublicKey2)
+		default:
+			t.Fatalf("unknown status %s", vec.Status)
+		}
+	}
+}
+
+// Private key generation.
+func BenchmarkGeneratePrivate(b *testing.B) {
+	var prv PrivateKey
+	for n := 0; n < b.N; n++ {
+		_ = GeneratePrivateKey(&prv, rng)
+	}
+}
+
+// Public key generation from private (group action on the base curve).
+func BenchmarkKeyGen(b *testing.B) {
+	var prv PrivateKey
+	var pub PublicKey
+	_ = GeneratePrivateKey(&prv, rng)
+	for n := 0; n < b.N; n++ {
+		KeyGen(&pub, &prv, rng)
+	}
+}
+
+// Benchmark validation on same key multiple times.
+func BenchmarkValidate(b *testing.B) {
+	var prv PrivateKey
+	var pub PublicKey
+	_ = GeneratePrivateKey(&prv, rng)
+	KeyGen(&pub, &prv, rng)
+
+	for n := 0; n < b.N; n++ {
+		Validate(&pub, rng)
+	}
+}
+
+// Generate some keys and benchmark the derivation of the shared secret.
+func BenchmarkShared(b *testing.B) {
+	var ss [SharedSecretSize]byte
+	var prv1, prv2 PrivateKey
+	var pub2 PublicKey
+
+	_ = GeneratePrivateKey(&prv1, rng)
+	_ = GeneratePrivateKey(&prv2, rng)
+	KeyGen(&pub2, &prv2, rng)
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		Shared(&ss, &prv1, &pub2, rng)
+	}
+}
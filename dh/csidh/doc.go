@@ -0,0 +1,25 @@
+// Package csidh implements commutative supersingular isogeny-based Diffie-Hellman
+// key exchange algorithm (CSIDH) resulting from the group action. Implementation
+// uses prime field of a size 512-bits.
+//
+// Unlike SIDH, the keys of CSIDH may be reused, which allows non-interactive
+// key exchange with static keys. The public keys received from a peer must
+// be validated, which the Shared function does by checking that they
+// represent supersingular curves.
+//
+// The group action is evaluated in constant time following the approach of
+// Meyer, Campos and Reith, and the two-point variant of Onuki, Aikawa,
+// Yamazaki and Takagi: the same number of isogenies is computed for every
+// exponent using dummy isogenies, keeping a point on the curve and one on
+// its twist. The running time depends on the random points sampled, but
+// not on the private key.
+//
+// This implementation is highly experimental work and currently it is not
+// suitable for securing systems.
+//
+// References:
+//  - cSIDH:                  ia.cr/2018/383
+//  - Faster cSIDH:           ia.cr/2018/782
+//  - Constant-time cSIDH:    ia.cr/2018/1198
+//
+package csidh
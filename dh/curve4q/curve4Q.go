@@ -2,7 +2,13 @@
 
 package curve4q
 
-import "github.com/cloudflare/circl/ecc/fourq"
+import (
+	"crypto/subtle"
+	"errors"
+
+	"github.com/cloudflare/circl/ecc/fourq"
+	"github.com/cloudflare/circl/internal/conv"
+)
 
 // Size is the size in bytes of keys.
 const Size = 32
@@ -10,6 +16,26 @@ const Size = 32
 // Key represents a public or private key of FourQ.
 type Key [Size]byte
 
+// PublicKey represents a public key of FourQ received from a peer. It must be
+// validated before being used, see Validate and SharedSecret.
+type PublicKey Key
+
+var (
+	// ErrEncoding is returned when a public key is not the canonical
+	// encoding of a point, for example, when a coordinate is not reduced
+	// modulo 2^127-1.
+	ErrEncoding = errors.New("curve4q: invalid encoding of public key")
+	// ErrNotOnCurve is returned when a public key does not decode to a point
+	// on the curve.
+	ErrNotOnCurve = errors.New("curve4q: public key is not on the curve")
+	// ErrSubgroup is returned when a public key is not in the subgroup of
+	// prime order N.
+	ErrSubgroup = errors.New("curve4q: public key is not in the prime-order subgroup")
+	// ErrIdentity is returned when either the public key or the shared
+	// secret is the identity point.
+	ErrIdentity = errors.New("curve4q: identity point")
+)
+
 // KeyGen calculates a public key k from a secret key.
 func KeyGen(public, secret *Key) {
 	var P fourq.Point
@@ -27,3 +53,60 @@ func Shared(shared, secret, public *Key) bool {
 	ok = ok && Q.IsOnCurve()
 	return ok
 }
+
+// Validate checks that the public key is the canonical encoding of a point
+// of the curve, which is neither the identity nor outside the subgroup of
+// prime order N. Returns nil if the public key is valid, otherwise returns
+// one of ErrEncoding, ErrNotOnCurve, ErrSubgroup or ErrIdentity.
+func (pk *PublicKey) Validate() error {
+	var P fourq.Point
+	return pk.decode(&P)
+}
+
+// decode stores in P the point encoded by the public key after validating
+// it.
+func (pk *PublicKey) decode(P *fourq.Point) error {
+	// Unmarshal takes the buffer as input and output, so a copy is used.
+	enc := [Size]byte(*pk)
+	if ok := P.Unmarshal(&enc); !ok {
+		return ErrEncoding
+	}
+	if !P.IsOnCurve() {
+		return ErrNotOnCurve
+	}
+	// Only canonical encodings are accepted, so the point must encode back
+	// to the same bytes.
+	P.Marshal(&enc)
+	if subtle.ConstantTimeCompare(enc[:], pk[:]) != 1 {
+		return ErrEncoding
+	}
+	if P.IsIdentity() {
+		return ErrIdentity
+	}
+	// Q = N*P must be the identity point.
+	var zero, order [Size]byte
+	var Q fourq.Point
+	conv.BigInt2BytesLe(order[:], fourq.Params().N)
+	Q.DoubleScalarMult(&zero, &order, P)
+	if !Q.IsIdentity() {
+		return ErrSubgroup
+	}
+	return nil
+}
+
+// SharedSecret calculates a shared key from Alice's secret and Bob's public
+// key. Unlike Shared, the public key is validated first, and an error
+// describing the reason of failure is returned (see Validate). Returns
+// ErrIdentity if the shared key is the identity point.
+func SharedSecret(shared, secret *Key, public *PublicKey) error {
+	var P, Q fourq.Point
+	if err := public.decode(&P); err != nil {
+		return err
+	}
+	Q.ScalarMult((*[Size]byte)(secret), &P)
+	if Q.IsIdentity() {
+		return ErrIdentity
+	}
+	Q.Marshal((*[Size]byte)(shared))
+	return nil
+}
@@ -7,8 +7,11 @@ import (
 	"crypto/rand"
 	"fmt"
 	"io"
+	"math/big"
 	"testing"
 
+	"github.com/cloudflare/circl/ecc/fourq"
+	"github.com/cloudflare/circl/internal/conv"
 	"github.com/cloudflare/circl/internal/test"
 )
 
@@ -39,6 +42,191 @@ func TestDH(t *testing.T) {
 	}
 }
 
+// randomPoint returns the encoding of a point of the curve that is likely not
+// in the prime-order subgroup.
+func randomPoint() (pk PublicKey) {
+	for {
+		var P fourq.Point
+		_, _ = rand.Read(pk[:])
+		pk[15] &= 0x7F
+		pk[31] &= 0x7F
+		enc := [Size]byte(pk)
+		if P.Unmarshal(&enc) && P.IsOnCurve() {
+			P.Marshal((*[Size]byte)(&pk))
+			return pk
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	testTimes := 1 << 8
+
+	t.Run("valid", func(t *testing.T) {
+		var secretAlice, secretBob, sharedAlice, sharedBob Key
+		var publicAlice, publicBob PublicKey
+		for i := 0; i < testTimes; i++ {
+			_, _ = rand.Read(secretAlice[:])
+			_, _ = rand.Read(secretBob[:])
+			KeyGen((*Key)(&publicAlice), &secretAlice)
+			KeyGen((*Key)(&publicBob), &secretBob)
+
+			test.CheckNoErr(t, publicAlice.Validate(), "valid public key was rejected")
+			err := SharedSecret(&sharedAlice, &secretAlice, &publicBob)
+			test.CheckNoErr(t, err, "shared secret failed")
+			err = SharedSecret(&sharedBob, &secretBob, &publicAlice)
+			test.CheckNoErr(t, err, "shared secret failed")
+
+			got := sharedAlice
+			want := sharedBob
+			if got != want {
+				test.ReportError(t, got, want, secretAlice, secretBob)
+			}
+			Shared(&want, &secretAlice, (*Key)(&publicBob))
+			if got != want {
+				test.ReportError(t, got, want, secretAlice, publicBob)
+			}
+		}
+	})
+
+	t.Run("off-curve", func(t *testing.T) {
+		var pk PublicKey
+		notOnCurve := 0
+		for i := 0; i < testTimes; i++ {
+			_, _ = rand.Read(pk[:])
+			pk[15] &= 0x7F
+			var P fourq.Point
+			enc := [Size]byte(pk)
+			if P.Unmarshal(&enc) && !P.IsOnCurve() {
+				notOnCurve++
+				got := pk.Validate()
+				want := ErrNotOnCurve
+				if got != want {
+					test.ReportError(t, got, want, pk)
+				}
+			}
+		}
+		if notOnCurve == 0 {
+			t.Fatal("no point off the curve was tested")
+		}
+	})
+
+	t.Run("small-order", func(t *testing.T) {
+		var zero, k [Size]byte
+		var P, Q fourq.Point
+		var pk PublicKey
+		order := fourq.Params().N
+
+		// (0,-1) has order 2.
+		pk = PublicKey{
+			0xfe, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+			0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f,
+		}
+		got := pk.Validate()
+		want := ErrSubgroup
+		if got != want {
+			test.ReportError(t, got, want, pk)
+		}
+
+		// (±i,0) has order 4.
+		for _, sign := range []byte{0x00, 0x80} {
+			pk = PublicKey{}
+			pk[Size-1] = sign
+			got = pk.Validate()
+			if got != want {
+				test.ReportError(t, got, want, pk)
+			}
+		}
+
+		// Points of order h, for each h|392, are obtained as (392N/h)*P.
+		for _, h := range []int64{2, 4, 7, 8, 14, 28, 49, 56, 98, 196, 392} {
+			bigK := new(big.Int).SetInt64(392 / h)
+			conv.BigInt2BytesLe(k[:], bigK.Mul(bigK, order))
+			for i := 0; i < testTimes; i++ {
+				pk = randomPoint()
+				enc := [Size]byte(pk)
+				P.Unmarshal(&enc)
+				Q.DoubleScalarMult(&zero, &k, &P)
+				if Q.IsIdentity() {
+					continue
+				}
+				Q.Marshal((*[Size]byte)(&pk))
+				got = pk.Validate()
+				if got != want {
+					test.ReportError(t, got, want, h, pk)
+				}
+			}
+		}
+	})
+
+	t.Run("mixed-order", func(t *testing.T) {
+		var zero, order [Size]byte
+		var P, Q fourq.Point
+		conv.BigInt2BytesLe(order[:], fourq.Params().N)
+		for i := 0; i < testTimes; i++ {
+			pk := randomPoint()
+			enc := [Size]byte(pk)
+			P.Unmarshal(&enc)
+			// Skips points that lie in the prime-order subgroup.
+			if Q.DoubleScalarMult(&zero, &order, &P); Q.IsIdentity() {
+				continue
+			}
+			got := pk.Validate()
+			want := ErrSubgroup
+			if got != want {
+				test.ReportError(t, got, want, pk)
+			}
+		}
+	})
+
+	t.Run("non-canonical", func(t *testing.T) {
+		var secret Key
+		var pk PublicKey
+		for i := 0; i < testTimes; i++ {
+			_, _ = rand.Read(secret[:])
+			KeyGen((*Key)(&pk), &secret)
+			// Sets the bit 127 of the first coordinate of y.
+			pk[15] |= 0x80
+			got := pk.Validate()
+			want := ErrEncoding
+			if got != want {
+				test.ReportError(t, got, want, pk)
+			}
+		}
+
+		// The identity point (0,1) encoded with y = 1 + p*i.
+		pk = PublicKey{
+			0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+			0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f,
+		}
+		got := pk.Validate()
+		want := ErrEncoding
+		if got != want {
+			test.ReportError(t, got, want, pk)
+		}
+	})
+
+	t.Run("identity", func(t *testing.T) {
+		var shared, secret Key
+		pk := PublicKey{0x01}
+		got := pk.Validate()
+		want := ErrIdentity
+		if got != want {
+			test.ReportError(t, got, want, pk)
+		}
+
+		// A zero secret key produces the identity as shared key.
+		_, _ = rand.Read(secret[:])
+		KeyGen((*Key)(&pk), &secret)
+		secret = Key{}
+		got = SharedSecret(&shared, &secret, &pk)
+		if got != want {
+			test.ReportError(t, got, want, pk)
+		}
+	})
+}
+
 func BenchmarkDH(b *testing.B) {
 	var secret, public, shared Key
 	_, _ = rand.Read(secret[:])
@@ -54,6 +242,12 @@ func BenchmarkDH(b *testing.B) {
 			Shared(&shared, &secret, &public)
 		}
 	})
+	b.Run("validate", func(b *testing.B) {
+		pk := PublicKey(public)
+		for i := 0; i < b.N; i++ {
+			_ = pk.Validate()
+		}
+	})
 }
 
 func ExampleKey() {
@@ -2,13 +2,15 @@
 // Supersingular Isogeny Diffie-Hellman (SIDH) as well as Supersingular
 // Isogeny Key Encapsulation (SIKE).
 //
-// It comes with implementations of 2 different field arithmetic
-// implementations sidh.Fp503 and sidh.Fp751.
+// It comes with implementations of 3 different field arithmetic
+// implementations sidh.Fp434, sidh.Fp503 and sidh.Fp751.
 //
 //	| Algoirthm | Public Key Size | Shared Secret Size | Ciphertext Size |
 //	|-----------|-----------------|--------------------|-----------------|
+//	| SIDH/p434 |          330    |        110         | N/A             |
 //	| SIDH/p503 |          376    |        126         | N/A             |
 //	| SIDH/p751 |          564    |        188         | N/A             |
+//	| SIKE/p434 |          330    |         16         | 346             |
 //	| SIKE/p503 |          376    |         16         | 402             |
 //	| SIKE/p751 |          564    |         24         | 596             |
 //
@@ -20,8 +22,24 @@
 //	kem.Encapsulate(ciphertext, sharedSecret, publicBob)
 //	kem.Decapsulate(sharedSecret, privateBob, PublicBob, ciphertext)
 //
-// Code is optimized for AMD64 and aarch64. Generic implementation
-// is provided for other architectures.
+// Alternatively, the package-level functions Encapsulate and Decapsulate
+// don't need a KEM object, and return freshly allocated outputs. Both are safe
+// for concurrent use.
+//
+//	ciphertext, sharedSecret, err := sidh.Encapsulate(rand.Reader, publicBob)
+//	sharedSecret, err = sidh.Decapsulate(privateBob, publicBob, ciphertext)
+//
+// Key pairs can also be derived deterministically from a seed of SeedSize
+// bytes with DeriveKeyPair, which allows storing just the seed.
+//
+// Functions of the package don't panic on malformed input. Instead, they
+// return one of the errors defined in this package, for example ErrUnallocated
+// when a KEM wasn't allocated, ErrKeyVariant when a key has a wrong variant or
+// ErrCiphertextSize when a ciphertext has a wrong size.
+//
+// Code is optimized for AMD64 and aarch64 (except p434, which is only
+// optimized for AMD64). Generic implementation is provided for other
+// architectures.
 //
 // References:
 // - [SIDH] https://eprint.iacr.org/2011/506
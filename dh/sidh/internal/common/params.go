@@ -6,12 +6,13 @@ import "fmt"
 var sidhParams = make(map[uint8]SidhParams)
 
 // Params returns domain parameters corresponding to finite field and identified by
-// `id` provieded by the caller. Function panics in case `id` wasn't registered earlier.
+// `id` provieded by the caller. Function returns nil in case `id` wasn't registered
+// earlier.
 func Params(id uint8) *SidhParams {
 	if val, ok := sidhParams[id]; ok {
 		return &val
 	}
-	panic("sidh: SIDH Params ID unregistered")
+	return nil
 }
 
 // Registers SIDH parameters for particular field.
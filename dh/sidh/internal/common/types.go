@@ -18,10 +18,10 @@ const (
 )
 
 // Id's correspond to bitlength of the prime field characteristic
-// Currently Fp751 is the only one supported by this implementation
 const (
 	Fp503 uint8 = iota
 	Fp751
+	Fp434
 )
 
 // Representation of an element of the base field F_p.
@@ -0,0 +1,2 @@
+// Package p434 provides implementation of field arithmetic used in SIDH and SIKE.
+package p434
@@ -18,6 +18,11 @@ var p34 = map[string]struct {
 	mul_strategy string
 	mul_initial  int
 }{
+	"P434": {
+		pow_strategy: "[]uint8{3, 10, 7, 5, 6, 5, 3, 8, 4, 7, 5, 6, 4, 5, 9, 6, 3, 11, 5, 5, 2, 8, 4, 7, 7, 8, 5, 6, 4, 8, 5, 2, 10, 6, 5, 4, 8, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 1}",
+		mul_strategy: "[]uint8{2, 15, 9, 8, 14, 12, 2, 8, 5, 15, 8, 15, 6, 6, 3, 2, 0, 10, 9, 13, 1, 12, 3, 7, 1, 10, 8, 11, 2, 15, 14, 1, 11, 12, 14, 3, 11, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 0}",
+		mul_initial:  8,
+	},
 	"P503": {
 		pow_strategy: "[]uint8{12, 5, 5, 2, 7, 11, 3, 8, 4, 11, 4, 7, 5, 6, 3, 7, 5, 7, 2, 12, 5, 6, 4, 6, 8, 6, 4, 7, 5, 5, 8, 5, 8, 5, 5, 8, 9, 3, 6, 2, 10, 6, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 3}",
 		mul_strategy: "[]uint8{12, 11, 10, 0, 1, 8, 3, 7, 1, 8, 3, 6, 7, 14, 2, 14, 14, 9, 0, 13, 9, 15, 5, 12, 7, 13, 7, 15, 6, 7, 9, 0, 5, 7, 6, 8, 8, 3, 7, 0, 10, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 3}",
@@ -30,6 +35,14 @@ var p34 = map[string]struct {
 	},
 }
 
+// Fields having an optimized arm64 implementation. Otherwise the generic
+// implementation is used on arm64.
+var optArm = map[string]bool{
+	"P434": false,
+	"P503": true,
+	"P751": true,
+}
+
 // Generates an 'fileNameBase.go' from 'fileNameBase.gotemp' file
 // for a given finite 'field'. Maps placeholders to 'values'.
 func gen(field, fileNameBase string, values interface{}) {
@@ -64,12 +77,14 @@ func main() {
 		P34_POW_STRATEGY string
 		P34_MUL_STRATEGY string
 		P34_INITIAL_MUL  int
+		OPT_ARM          bool
 	}{
 		FIELD:            field,
 		PACKAGE:          strings.ToLower(field),
 		P34_POW_STRATEGY: p34[field].pow_strategy,
 		P34_MUL_STRATEGY: p34[field].mul_strategy,
 		P34_INITIAL_MUL:  p34[field].mul_initial,
+		OPT_ARM:          optArm[field],
 	}
 
 	targets := map[string]interface{}{
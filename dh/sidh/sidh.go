@@ -5,6 +5,7 @@ import (
 	"io"
 
 	"github.com/cloudflare/circl/dh/sidh/internal/common"
+	"github.com/cloudflare/circl/dh/sidh/internal/p434"
 	"github.com/cloudflare/circl/dh/sidh/internal/p503"
 	"github.com/cloudflare/circl/dh/sidh/internal/p751"
 )
@@ -38,8 +39,8 @@ type PrivateKey struct {
 }
 
 // Id's correspond to bitlength of the prime field characteristic
-// Currently Fp751 is the only one supported by this implementation
 const (
+	Fp434 = common.Fp434
 	Fp503 = common.Fp503
 	Fp751 = common.Fp751
 )
@@ -56,13 +57,58 @@ const (
 	KeyVariantSike = 1<<2 | KeyVariantSidhB
 )
 
+var (
+	// ErrUnsupportedField is returned when a key is nil or not initialized
+	// with a supported prime field.
+	ErrUnsupportedField = errors.New("sidh: unsupported field")
+	// ErrKeyVariant is returned when a key has a wrong or unsupported variant.
+	ErrKeyVariant = errors.New("sidh: wrong key variant")
+	// ErrKeyMismatch is returned when a public and a private key are not
+	// compatible, i.e., they use different fields or variants.
+	ErrKeyMismatch = errors.New("sidh: incompatible keys")
+	// ErrKeySize is returned when importing a key of wrong size.
+	ErrKeySize = errors.New("sidh: wrong key size")
+	// ErrBufferSize is returned when an output buffer is too small.
+	ErrBufferSize = errors.New("sidh: buffer too small")
+	// ErrUnallocated is returned when using a KEM that was not allocated.
+	ErrUnallocated = errors.New("sidh: KEM unallocated")
+	// ErrCiphertextSize is returned when a ciphertext has a wrong size.
+	ErrCiphertextSize = errors.New("sidh: wrong ciphertext size")
+	// ErrSeedSize is returned when a seed has a wrong size.
+	ErrSeedSize = errors.New("sidh: wrong seed size")
+)
+
 // Accessor to key variant
 func (key *key) Variant() KeyVariant {
 	return key.keyVariant
 }
 
+// check returns an error if the key is not initialized with supported domain
+// parameters and variant.
+func (key *key) check() error {
+	if key.params == nil {
+		return ErrUnsupportedField
+	}
+	switch key.keyVariant {
+	case KeyVariantSidhA, KeyVariantSidhB, KeyVariantSike:
+		return nil
+	default:
+		return ErrKeyVariant
+	}
+}
+
+// check returns an error if pub is nil or not correctly initialized.
+func (pub *PublicKey) check() error {
+	if pub == nil {
+		return ErrUnsupportedField
+	}
+	return pub.key.check()
+}
+
 // NewPublicKey initializes public key.
 // Usage of this function guarantees that the object is correctly initialized.
+// If id or v are not supported, the methods of the returned key fail with
+// ErrUnsupportedField or ErrKeyVariant respectively.
 func NewPublicKey(id uint8, v KeyVariant) *PublicKey {
 	return &PublicKey{key: key{params: common.Params(id), keyVariant: v}}
 }
@@ -71,14 +117,21 @@ func NewPublicKey(id uint8, v KeyVariant) *PublicKey {
 // and imports key stored in the byte string. Returns error in case byte string
 // size is wrong. Doesn't perform any validation.
 func (pub *PublicKey) Import(input []byte) error {
+	if err := pub.check(); err != nil {
+		return err
+	}
 	if len(input) != pub.Size() {
-		return errors.New("sidh: input to short")
+		return ErrKeySize
 	}
 	ssSz := pub.params.SharedSecretSize
 	common.BytesToFp2(&pub.affine3Pt[0], input[0:ssSz], pub.params.Bytelen)
 	common.BytesToFp2(&pub.affine3Pt[1], input[ssSz:2*ssSz], pub.params.Bytelen)
 	common.BytesToFp2(&pub.affine3Pt[2], input[2*ssSz:3*ssSz], pub.params.Bytelen)
 	switch pub.params.ID {
+	case Fp434:
+		p434.ToMontgomery(&pub.affine3Pt[0], &pub.affine3Pt[0])
+		p434.ToMontgomery(&pub.affine3Pt[1], &pub.affine3Pt[1])
+		p434.ToMontgomery(&pub.affine3Pt[2], &pub.affine3Pt[2])
 	case Fp503:
 		p503.ToMontgomery(&pub.affine3Pt[0], &pub.affine3Pt[0])
 		p503.ToMontgomery(&pub.affine3Pt[1], &pub.affine3Pt[1])
@@ -88,17 +141,28 @@ func (pub *PublicKey) Import(input []byte) error {
 		p751.ToMontgomery(&pub.affine3Pt[1], &pub.affine3Pt[1])
 		p751.ToMontgomery(&pub.affine3Pt[2], &pub.affine3Pt[2])
 	default:
-		panic("Unsupported key")
+		return ErrUnsupportedField
 	}
 	return nil
 }
 
 // Exports currently stored key. In case structure hasn't been filled with key data
-// returned byte string is filled with zeros.
-func (pub *PublicKey) Export(out []byte) {
+// returned byte string is filled with zeros. Returns error in case the output
+// buffer is too small.
+func (pub *PublicKey) Export(out []byte) error {
+	if err := pub.check(); err != nil {
+		return err
+	}
+	if len(out) < pub.Size() {
+		return ErrBufferSize
+	}
 	var feTmp [3]common.Fp2
 	ssSz := pub.params.SharedSecretSize
 	switch pub.params.ID {
+	case Fp434:
+		p434.FromMontgomery(&feTmp[0], &pub.affine3Pt[0])
+		p434.FromMontgomery(&feTmp[1], &pub.affine3Pt[1])
+		p434.FromMontgomery(&feTmp[2], &pub.affine3Pt[2])
 	case Fp503:
 		p503.FromMontgomery(&feTmp[0], &pub.affine3Pt[0])
 		p503.FromMontgomery(&feTmp[1], &pub.affine3Pt[1])
@@ -108,22 +172,40 @@ func (pub *PublicKey) Export(out []byte) {
 		p751.FromMontgomery(&feTmp[1], &pub.affine3Pt[1])
 		p751.FromMontgomery(&feTmp[2], &pub.affine3Pt[2])
 	default:
-		panic("Unsupported key")
+		return ErrUnsupportedField
 	}
 	common.Fp2ToBytes(out[0:ssSz], &feTmp[0], pub.params.Bytelen)
 	common.Fp2ToBytes(out[ssSz:2*ssSz], &feTmp[1], pub.params.Bytelen)
 	common.Fp2ToBytes(out[2*ssSz:3*ssSz], &feTmp[2], pub.params.Bytelen)
+	return nil
 }
 
-// Size returns size of the public key in bytes
+// Size returns size of the public key in bytes, or zero if the key is not
+// initialized with a supported field.
 func (pub *PublicKey) Size() int {
+	if pub == nil || pub.params == nil {
+		return 0
+	}
 	return pub.params.PublicKeySize
 }
 
+// check returns an error if prv is nil or not correctly initialized.
+func (prv *PrivateKey) check() error {
+	if prv == nil {
+		return ErrUnsupportedField
+	}
+	return prv.key.check()
+}
+
 // NewPrivateKey initializes private key.
 // Usage of this function guarantees that the object is correctly initialized.
+// If id or v are not supported, the methods of the returned key fail with
+// ErrUnsupportedField or ErrKeyVariant respectively.
 func NewPrivateKey(id uint8, v KeyVariant) *PrivateKey {
 	prv := &PrivateKey{key: key{params: common.Params(id), keyVariant: v}}
+	if prv.check() != nil {
+		return prv
+	}
 	if (v & KeyVariantSidhA) == KeyVariantSidhA {
 		prv.Scalar = make([]byte, prv.params.A.SecretByteLen)
 	} else {
@@ -136,14 +218,26 @@ func NewPrivateKey(id uint8, v KeyVariant) *PrivateKey {
 }
 
 // Exports currently stored key. In case structure hasn't been filled with key data
-// returned byte string is filled with zeros.
-func (prv *PrivateKey) Export(out []byte) {
+// returned byte string is filled with zeros. Returns error in case the output
+// buffer is too small.
+func (prv *PrivateKey) Export(out []byte) error {
+	if err := prv.check(); err != nil {
+		return err
+	}
+	if len(out) < prv.Size() {
+		return ErrBufferSize
+	}
 	copy(out, prv.S)
 	copy(out[len(prv.S):], prv.Scalar)
+	return nil
 }
 
-// Size returns size of the private key in bytes
+// Size returns size of the private key in bytes, or zero if the key is not
+// initialized with a supported field and variant.
 func (prv *PrivateKey) Size() int {
+	if prv.check() != nil {
+		return 0
+	}
 	tmp := len(prv.Scalar)
 	if prv.Variant() == KeyVariantSike {
 		tmp += prv.params.MsgLen
@@ -151,8 +245,12 @@ func (prv *PrivateKey) Size() int {
 	return tmp
 }
 
-// Size returns size of the shared secret
+// Size returns size of the shared secret, or zero if the key is not
+// initialized with a supported field.
 func (prv *PrivateKey) SharedSecretSize() int {
+	if prv == nil || prv.params == nil {
+		return 0
+	}
 	return prv.params.SharedSecretSize
 }
 
@@ -161,14 +259,33 @@ func (prv *PrivateKey) SharedSecretSize() int {
 // must be prepended to the value of actual private key (see SIKE spec for details).
 // Function doesn't import public key value to PrivateKey object.
 func (prv *PrivateKey) Import(input []byte) error {
+	if err := prv.check(); err != nil {
+		return err
+	}
 	if len(input) != prv.Size() {
-		return errors.New("sidh: input to short")
+		return ErrKeySize
 	}
 	copy(prv.S, input[:len(prv.S)])
 	copy(prv.Scalar, input[len(prv.S):])
 	return nil
 }
 
+// checkSize returns an error if the secret values of the key, which are
+// exported, have been set to slices of wrong size.
+func (prv *PrivateKey) checkSize() error {
+	dp := &prv.params.B
+	if (prv.keyVariant & KeyVariantSidhA) == KeyVariantSidhA {
+		dp = &prv.params.A
+	}
+	if uint(len(prv.Scalar)) != dp.SecretByteLen {
+		return ErrKeySize
+	}
+	if prv.keyVariant == KeyVariantSike && len(prv.S) != prv.params.MsgLen {
+		return ErrKeySize
+	}
+	return nil
+}
+
 // Generates random private key for SIDH or SIKE. Generated value is
 // formed as little-endian integer from key-space <2^(e2-1)..2^e2 - 1>
 // for KeyVariant_A or <2^(s-1)..2^s - 1>, where s = floor(log_2(3^e3)),
@@ -176,8 +293,22 @@ func (prv *PrivateKey) Import(input []byte) error {
 //
 // Returns error in case user provided RNG fails.
 func (prv *PrivateKey) Generate(rand io.Reader) error {
+	return prv.generate(rand, true)
+}
+
+// generate samples the private key as Generate does. If fullLen is false, the
+// value is taken from <0..2^s - 1> instead, as in the reference
+// implementation. This is only used to reproduce NIST's KATs.
+func (prv *PrivateKey) generate(rand io.Reader, fullLen bool) error {
 	var dp *common.DomainParams
 
+	if err := prv.check(); err != nil {
+		return err
+	}
+	if err := prv.checkSize(); err != nil {
+		return err
+	}
+
 	if (prv.keyVariant & KeyVariantSidhA) == KeyVariantSidhA {
 		dp = &prv.params.A
 	} else {
@@ -201,6 +332,9 @@ func (prv *PrivateKey) Generate(rand io.Reader) error {
 	}
 
 	prv.Scalar[len(prv.Scalar)-1] &= (1 << (dp.SecretBitLen % 8)) - 1
+	if !fullLen {
+		return nil
+	}
 	// Make sure scalar is SecretBitLen long. SIKE spec says that key
 	// space starts from 0, but I'm not comfortable with having low
 	// value scalars used for private keys. It is still secrure as per
@@ -210,15 +344,30 @@ func (prv *PrivateKey) Generate(rand io.Reader) error {
 	return nil
 }
 
-// Generates public key.
-func (prv *PrivateKey) GeneratePublicKey(pub *PublicKey) {
+// Generates public key. Returns error in case the public key is not compatible
+// with the private key.
+func (prv *PrivateKey) GeneratePublicKey(pub *PublicKey) error {
+	if err := prv.check(); err != nil {
+		return err
+	}
 	var isA = (prv.keyVariant & KeyVariantSidhA) == KeyVariantSidhA
-
+	if err := pub.check(); err != nil {
+		return err
+	}
 	if (pub.keyVariant != prv.keyVariant) || (pub.params.ID != prv.params.ID) {
-		panic("sidh: incompatbile public key")
+		return ErrKeyMismatch
+	}
+	if err := prv.checkSize(); err != nil {
+		return err
 	}
 
 	switch prv.params.ID {
+	case Fp434:
+		if isA {
+			p434.PublicKeyGenA(&pub.affine3Pt, prv.Scalar)
+		} else {
+			p434.PublicKeyGenB(&pub.affine3Pt, prv.Scalar)
+		}
 	case Fp503:
 		if isA {
 			p503.PublicKeyGenA(&pub.affine3Pt, prv.Scalar)
@@ -232,23 +381,43 @@ func (prv *PrivateKey) GeneratePublicKey(pub *PublicKey) {
 			p751.PublicKeyGenB(&pub.affine3Pt, prv.Scalar)
 		}
 	default:
-		panic("Field not supported")
+		return ErrUnsupportedField
 	}
+	return nil
 }
 
 // Computes a SIDH shared secret. Function requires that pub has different
 // KeyVariant than prv. Length of returned output is 2*ceil(log_2 P)/8),
 // where P is a prime defining finite field.
 //
-// Caller must make sure key SIDH key pair is not used more than once.
-func (prv *PrivateKey) DeriveSecret(ss []byte, pub *PublicKey) {
+// Caller must make sure key SIDH key pair is not used more than once. Returns
+// error in case the keys are not compatible or the buffer ss is too small.
+func (prv *PrivateKey) DeriveSecret(ss []byte, pub *PublicKey) error {
+	if err := prv.check(); err != nil {
+		return err
+	}
 	var isA = (prv.keyVariant & KeyVariantSidhA) == KeyVariantSidhA
-
-	if (pub.keyVariant == prv.keyVariant) || (pub.params.ID != prv.params.ID) {
-		panic("sidh: public and private are incompatbile")
+	if err := pub.check(); err != nil {
+		return err
+	}
+	var isPubA = (pub.keyVariant & KeyVariantSidhA) == KeyVariantSidhA
+	if (isA == isPubA) || (pub.params.ID != prv.params.ID) {
+		return ErrKeyMismatch
+	}
+	if err := prv.checkSize(); err != nil {
+		return err
+	}
+	if len(ss) < prv.SharedSecretSize() {
+		return ErrBufferSize
 	}
 
 	switch prv.params.ID {
+	case Fp434:
+		if isA {
+			p434.DeriveSecretA(ss, prv.Scalar, &pub.affine3Pt)
+		} else {
+			p434.DeriveSecretB(ss, prv.Scalar, &pub.affine3Pt)
+		}
 	case Fp503:
 		if isA {
 			p503.DeriveSecretA(ss, prv.Scalar, &pub.affine3Pt)
@@ -262,6 +431,7 @@ func (prv *PrivateKey) DeriveSecret(ss []byte, pub *PublicKey) {
 			p751.DeriveSecretB(ss, prv.Scalar, &pub.affine3Pt)
 		}
 	default:
-		panic("Field not supported")
+		return ErrUnsupportedField
 	}
+	return nil
 }
@@ -25,6 +25,36 @@ type sidhVec struct {
 }
 
 var tdataSidh = map[uint8]sidhVec{
+	Fp434: {
+		id:   Fp434,
+		name: "P-434",
+		PrA:  "3A727E04EA9B7E2A766A6F846489E7E7B915263BCEED308BB10FC900",
+		PrB:  "E37BFE55B43B32448F375903D8D226EC94ADBFEA1D2B3536EB987001",
+		PkA: "9E668D1E6750ED4B91EE052C32839CA9DD2E56D52BC24DECC950AAAD" +
+			"24CEED3F9049C77FE80F0B9B01E7F8DAD7833EEC2286544D6380009C" +
+			"379CDD3E7517CEF5E20EB01F8231D52FC30DC61D2F63FB357F85DC63" +
+			"96E8A95DB9740BD3A972C8DB7901B31F074CD3E45345CA78F9008171" +
+			"30E688A29A7CF0073B5C00FF2C65FBE776918EF9BD8E75B29EF7FAB7" +
+			"91969B60B0C5B37A8992EDEF95FA7BAC40A95DAFE02E237301FEE9A7" +
+			"A43FD0B73477E8035DD12B73FAFEF18D39904DDE3653A754F36BE188" +
+			"8F6607C6A7951349A414352CF31A29F2C40302DB406C48018C905EB9" +
+			"DC46AFBF42A9187A9BB9E51B587622A2862DC7D5CC598BF38ED6320F" +
+			"B51D8697AD3D7A72ABCC32A393F0133DA8DF5E253D9E00B760B2DF34" +
+			"2FCE974DCFE946CFE4727783531882800F9E5DD594D6D5A6275EEFEF" +
+			"9713ED838F4A06BB34D7B8D46E0B385AAEA1C7963601",
+		PkB: "C9F73E4497AAA3FDF9EB688135866A8A83934BA10E273B8CC3808CF0" +
+			"C1F5FAB3E9BB295885881B73DEBC875670C0F51C4BB40DF5FEDE01B8" +
+			"AF32D1BF10508B8C17B2734EB93B2B7F5D84A4A0F2F816E9E2C32AC2" +
+			"53C0B6025B124D05A87A9E2A8567930F44BAA14219B941B6B400B4AE" +
+			"D1D796DA12A5A9F0B8F3F5EE9DD43F64CB24A3B1719DF278ADF56B5F" +
+			"3395187829DA2319DEABF6BBD6EDA244DE2B62CC5AC250C1009DD1CD" +
+			"4712B0B37406612AD002B5E51A62B51AC9C0374D143ABBBD58275FAF" +
+			"C4A5E959C54838C2D6D9FB43B7B2609061267B6A2E6C6D01D295C422" +
+			"3E0D3D7A4CDCFB28A7818A737935279751A6DD8290FD498D1F6AD5F4" +
+			"FFF6BDFA536713F509DCE8047252F1E7D0DD9FCC414C0070B5DCCE36" +
+			"65A21A032D7FBE749181032183AFAD240B7E671E87FBBEC3A8CA4C11" +
+			"AA7A9A23AC69AE2ACF54B664DECD27753D63508F1B02",
+	},
 	Fp503: {
 		id:   Fp503,
 		name: "P-503",
@@ -330,6 +360,108 @@ func TestKeyAgreementP751_AliceEvenNumber(t *testing.T) {
 	testKeyAgreement(t, v)
 }
 
+// Checks that no combination of key parameters, key contents and buffer
+// sizes makes the SIDH API panic.
+func TestNoPanic(t *testing.T) {
+	ids := []uint8{0, Fp434, Fp503, Fp751, 0xFF}
+	variants := []KeyVariant{0, KeyVariantSidhA, KeyVariantSidhB, KeyVariantSike, 1 << 3}
+
+	pubs := []*PublicKey{nil, {}}
+	prvs := []*PrivateKey{nil, {}}
+	for _, id := range ids {
+		for _, v := range variants {
+			pubs = append(pubs, NewPublicKey(id, v))
+			prvs = append(prvs, NewPrivateKey(id, v))
+		}
+	}
+	// Private keys with secret values of wrong size.
+	prv := NewPrivateKey(Fp503, KeyVariantSike)
+	prv.S = prv.S[:1]
+	prvs = append(prvs, prv)
+	prv = NewPrivateKey(Fp503, KeyVariantSidhA)
+	prv.Scalar = nil
+	prvs = append(prvs, prv)
+
+	lengths := func(n int) []int { return []int{0, n - 1, n, n + 1, common.MaxPublicKeySz + 1} }
+	buf := make([]byte, common.MaxPublicKeySz+1)
+
+	for _, pub := range pubs {
+		for _, n := range lengths(pub.Size()) {
+			if n < 0 {
+				continue
+			}
+			_, _ = rand.Read(buf[:n])
+			err := CheckPanic(func() {
+				importErr := pub.Import(buf[:n])
+				exportErr := pub.Export(buf[:n])
+				if pub.check() == nil && n == pub.Size() {
+					CheckNoErr(t, importErr, "import failed")
+					CheckNoErr(t, exportErr, "export failed")
+				}
+			})
+			CheckIsErr(t, err, "public key import/export panics")
+		}
+	}
+
+	for _, prv := range prvs {
+		for _, n := range lengths(prv.Size()) {
+			if n < 0 {
+				continue
+			}
+			_, _ = rand.Read(buf[:n])
+			err := CheckPanic(func() {
+				_ = prv.Import(buf[:n])
+				_ = prv.Export(buf[:n])
+				_ = prv.Generate(rand.Reader)
+				_ = prv.SharedSecretSize()
+			})
+			CheckIsErr(t, err, "private key import/export panics")
+		}
+		for _, pub := range pubs {
+			ss := make([]byte, prv.SharedSecretSize())
+			err := CheckPanic(func() {
+				_ = prv.GeneratePublicKey(pub)
+				if len(ss) > 0 {
+					_ = prv.DeriveSecret(ss[:len(ss)-1], pub)
+				}
+				_ = prv.DeriveSecret(ss, pub)
+			})
+			CheckIsErr(t, err, "key agreement panics")
+		}
+	}
+
+	// Expected errors.
+	err := NewPublicKey(0xFF, KeyVariantSidhA).Import(nil)
+	if err != ErrUnsupportedField {
+		ReportError(t, err, ErrUnsupportedField)
+	}
+	err = NewPrivateKey(Fp503, 1<<3).Generate(rand.Reader)
+	if err != ErrKeyVariant {
+		ReportError(t, err, ErrKeyVariant)
+	}
+	err = NewPublicKey(Fp503, KeyVariantSidhA).Import(buf[:1])
+	if err != ErrKeySize {
+		ReportError(t, err, ErrKeySize)
+	}
+	err = NewPublicKey(Fp503, KeyVariantSidhA).Export(buf[:1])
+	if err != ErrBufferSize {
+		ReportError(t, err, ErrBufferSize)
+	}
+	prvA := NewPrivateKey(Fp503, KeyVariantSidhA)
+	err = prvA.GeneratePublicKey(NewPublicKey(Fp751, KeyVariantSidhA))
+	if err != ErrKeyMismatch {
+		ReportError(t, err, ErrKeyMismatch)
+	}
+	err = prvA.DeriveSecret(buf, NewPublicKey(Fp503, KeyVariantSidhA))
+	if err != ErrKeyMismatch {
+		ReportError(t, err, ErrKeyMismatch)
+	}
+	err = prvA.DeriveSecret(buf[:1], NewPublicKey(Fp503, KeyVariantSidhB))
+	if err != ErrBufferSize {
+		ReportError(t, err, ErrBufferSize)
+	}
+}
+
 /* -------------------------------------------------------------------------
    Wrappers for 'testing' SIDH
    -------------------------------------------------------------------------*/
@@ -377,6 +509,21 @@ func BenchmarkSidhKeyAgreementP503(b *testing.B) {
 	}
 }
 
+func BenchmarkSidhKeyAgreementP434(b *testing.B) {
+	// KeyPairs
+	alicePublic := convToPub(tdataSidh[Fp434].PkA, KeyVariantSidhA, Fp434)
+	bobPublic := convToPub(tdataSidh[Fp434].PkB, KeyVariantSidhB, Fp434)
+	alicePrivate := convToPrv(tdataSidh[Fp434].PrA, KeyVariantSidhA, Fp434)
+	bobPrivate := convToPrv(tdataSidh[Fp434].PrB, KeyVariantSidhB, Fp434)
+	var ss [2 * 63]byte
+
+	for i := 0; i < b.N; i++ {
+		// Derive shared secret
+		bobPrivate.DeriveSecret(ss[:], alicePublic)
+		alicePrivate.DeriveSecret(ss[:], bobPublic)
+	}
+}
+
 func BenchmarkAliceKeyGenPrvP751(b *testing.B) {
 	prv := NewPrivateKey(Fp751, KeyVariantSidhA)
 	for n := 0; n < b.N; n++ {
@@ -391,6 +538,13 @@ func BenchmarkAliceKeyGenPrvP503(b *testing.B) {
 	}
 }
 
+func BenchmarkAliceKeyGenPrvP434(b *testing.B) {
+	prv := NewPrivateKey(Fp434, KeyVariantSidhA)
+	for n := 0; n < b.N; n++ {
+		prv.Generate(rand.Reader)
+	}
+}
+
 func BenchmarkBobKeyGenPrvP751(b *testing.B) {
 	prv := NewPrivateKey(Fp751, KeyVariantSidhB)
 	for n := 0; n < b.N; n++ {
@@ -405,6 +559,13 @@ func BenchmarkBobKeyGenPrvP503(b *testing.B) {
 	}
 }
 
+func BenchmarkBobKeyGenPrvP434(b *testing.B) {
+	prv := NewPrivateKey(Fp434, KeyVariantSidhB)
+	for n := 0; n < b.N; n++ {
+		prv.Generate(rand.Reader)
+	}
+}
+
 func BenchmarkAliceKeyGenPubP751(b *testing.B) {
 	prv := NewPrivateKey(Fp751, KeyVariantSidhA)
 	pub := NewPublicKey(Fp751, KeyVariantSidhA)
@@ -423,6 +584,15 @@ func BenchmarkAliceKeyGenPubP503(b *testing.B) {
 	}
 }
 
+func BenchmarkAliceKeyGenPubP434(b *testing.B) {
+	prv := NewPrivateKey(Fp434, KeyVariantSidhA)
+	pub := NewPublicKey(Fp434, KeyVariantSidhA)
+	prv.Generate(rand.Reader)
+	for n := 0; n < b.N; n++ {
+		prv.GeneratePublicKey(pub)
+	}
+}
+
 func BenchmarkBobKeyGenPubP751(b *testing.B) {
 	prv := NewPrivateKey(Fp751, KeyVariantSidhB)
 	pub := NewPublicKey(Fp751, KeyVariantSidhB)
@@ -441,6 +611,15 @@ func BenchmarkBobKeyGenPubP503(b *testing.B) {
 	}
 }
 
+func BenchmarkBobKeyGenPubP434(b *testing.B) {
+	prv := NewPrivateKey(Fp434, KeyVariantSidhB)
+	pub := NewPublicKey(Fp434, KeyVariantSidhB)
+	prv.Generate(rand.Reader)
+	for n := 0; n < b.N; n++ {
+		prv.GeneratePublicKey(pub)
+	}
+}
+
 func BenchmarkSharedSecretAliceP751(b *testing.B) {
 	aPr := convToPrv(tdataSidh[Fp751].PrA, KeyVariantSidhA, Fp751)
 	bPk := convToPub(tdataSidh[Fp751].PkB, KeyVariantSidhB, Fp751)
@@ -459,6 +638,15 @@ func BenchmarkSharedSecretAliceP503(b *testing.B) {
 	}
 }
 
+func BenchmarkSharedSecretAliceP434(b *testing.B) {
+	aPr := convToPrv(tdataSidh[Fp434].PrA, KeyVariantSidhA, Fp434)
+	bPk := convToPub(tdataSidh[Fp434].PkB, KeyVariantSidhB, Fp434)
+	var ss [2 * 63]byte
+	for n := 0; n < b.N; n++ {
+		aPr.DeriveSecret(ss[:], bPk)
+	}
+}
+
 func BenchmarkSharedSecretBobP751(b *testing.B) {
 	// m_B = 3*randint(0,3^238)
 	aPk := convToPub(tdataSidh[Fp751].PkA, KeyVariantSidhA, Fp751)
@@ -479,6 +667,16 @@ func BenchmarkSharedSecretBobP503(b *testing.B) {
 	}
 }
 
+func BenchmarkSharedSecretBobP434(b *testing.B) {
+	// m_B = 3*randint(0,3^238)
+	aPk := convToPub(tdataSidh[Fp434].PkA, KeyVariantSidhA, Fp434)
+	bPr := convToPrv(tdataSidh[Fp434].PrB, KeyVariantSidhB, Fp434)
+	var ss [2 * 63]byte
+	for n := 0; n < b.N; n++ {
+		bPr.DeriveSecret(ss[:], aPk)
+	}
+}
+
 // Examples
 
 func ExamplePrivateKey() {
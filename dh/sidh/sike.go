@@ -6,17 +6,22 @@ import (
 	"io"
 
 	"github.com/cloudflare/circl/dh/sidh/internal/common"
-	"github.com/cloudflare/circl/dh/sidh/internal/shake"
+	"github.com/cloudflare/circl/sha3"
 )
 
-// SIKE KEM interface
+// SIKE KEM interface. KEM keeps no state between operations, hence it is safe
+// for concurrent use as long as its random source is.
 type KEM struct {
-	allocated   bool
-	rng         io.Reader
-	msg         []byte
-	secretBytes []byte
-	params      *common.SidhParams
-	shake       *shake.Shake
+	allocated bool
+	rng       io.Reader
+	params    *common.SidhParams
+}
+
+// NewSike434 instantiates SIKE/p434 KEM
+func NewSike434(rng io.Reader) *KEM {
+	var c KEM
+	c.Allocate(Fp434, rng)
+	return &c
 }
 
 // NewSike503 instantiates SIKE/p503 KEM
@@ -34,118 +39,266 @@ func NewSike751(rng io.Reader) *KEM {
 }
 
 // Allocate allocates KEM object for multiple SIKE operations. The rng
-// must be cryptographically secure PRNG.
-func (c *KEM) Allocate(id uint8, rng io.Reader) {
+// must be cryptographically secure PRNG. Returns error in case id is not
+// a supported field.
+func (c *KEM) Allocate(id uint8, rng io.Reader) error {
+	params := common.Params(id)
+	if params == nil {
+		return ErrUnsupportedField
+	}
 	c.rng = rng
-	c.params = common.Params(id)
-	c.msg = make([]byte, c.params.MsgLen)
-	c.secretBytes = make([]byte, c.params.A.SecretByteLen)
-	c.shake = shake.NewShake256()
+	c.params = params
 	c.allocated = true
+	return nil
 }
 
 // Encapsulate receives the public key and generates SIKE ciphertext and shared secret.
 // The generated ciphertext is used for authentication.
-// Error is returned in case PRNG fails. Function panics in case wrongly formated
-// input was provided.
+// Error is returned in case PRNG fails or wrongly formated input was provided.
 func (c *KEM) Encapsulate(ciphertext, secret []byte, pub *PublicKey) error {
-	if !c.allocated {
-		panic("KEM unallocated")
+	if c == nil || !c.allocated {
+		return ErrUnallocated
 	}
 
-	if KeyVariantSike != pub.keyVariant {
-		panic("Wrong type of public key")
+	if err := checkSikePublicKey(pub); err != nil {
+		return err
+	}
+
+	if pub.params.ID != c.params.ID {
+		return ErrKeyMismatch
 	}
 
 	if len(secret) < c.SharedSecretSize() {
-		panic("shared secret buffer to small")
+		return ErrBufferSize
 	}
 
 	if len(ciphertext) < c.CiphertextSize() {
-		panic("ciphertext buffer to small")
+		return ErrBufferSize
 	}
 
-	// Generate ephemeral value
-	_, err := io.ReadFull(c.rng, c.msg[:])
-	if err != nil {
+	return encapsulate(ciphertext, secret, c.rng, pub)
+}
+
+// Decapsulate given the keypair and ciphertext as inputs, Decapsulate outputs a shared
+// secret if plaintext verifies correctly, otherwise function outputs random value.
+// Error is returned in case input is wrongly formated, in particular, size of
+// the 'ciphertext' must be exactly equal to c.CiphertextSize().
+func (c *KEM) Decapsulate(secret []byte, prv *PrivateKey, pub *PublicKey, ciphertext []byte) error {
+	if c == nil || !c.allocated {
+		return ErrUnallocated
+	}
+
+	if err := checkSikeKeyPair(prv, pub); err != nil {
 		return err
 	}
 
-	var buf [3 * common.MaxSharedSecretBsz]byte
-	var skA = PrivateKey{
-		key: key{
-			params:     c.params,
-			keyVariant: KeyVariantSidhA},
-		Scalar: c.secretBytes}
-	var pkA = NewPublicKey(c.params.ID, KeyVariantSidhA)
+	if pub.params.ID != c.params.ID {
+		return ErrKeyMismatch
+	}
 
-	pub.Export(buf[:])
-	c.shake.Reset()
-	c.shake.Write(c.msg)
-	c.shake.Write(buf[:3*c.params.SharedSecretSize])
-	c.shake.Read(skA.Scalar)
+	if len(secret) < c.SharedSecretSize() {
+		return ErrBufferSize
+	}
 
-	// Ensure bitlength is not bigger then to 2^e2-1
-	skA.Scalar[len(skA.Scalar)-1] &= (1 << (c.params.A.SecretBitLen % 8)) - 1
-	skA.GeneratePublicKey(pkA)
-	c.generateCiphertext(ciphertext, &skA, pkA, pub, c.msg[:])
+	if len(ciphertext) != c.CiphertextSize() {
+		return ErrCiphertextSize
+	}
 
-	// K = H(msg||(c0||c1))
-	c.shake.Reset()
-	c.shake.Write(c.msg)
-	c.shake.Write(ciphertext)
-	c.shake.Read(secret[:c.SharedSecretSize()])
+	decapsulate(secret, prv, pub, ciphertext)
 	return nil
 }
 
-// Decapsulate given the keypair and ciphertext as inputs, Decapsulate outputs a shared
-// secret if plaintext verifies correctly, otherwise function outputs random value.
-// Decapsulation may panic in case input is wrongly formated, in particular, size of
-// the 'ciphertext' must be exactly equal to c.CiphertextSize().
-func (c *KEM) Decapsulate(secret []byte, prv *PrivateKey, pub *PublicKey, ciphertext []byte) error {
-	if !c.allocated {
-		panic("KEM unallocated")
+// Reset is kept for compatibility. As KEM doesn't keep any state between
+// operations, calling it has no effect.
+func (c *KEM) Reset() {}
+
+// Returns size of resulting ciphertext, or zero if the KEM is unallocated.
+func (c *KEM) CiphertextSize() int {
+	if c == nil || !c.allocated {
+		return 0
 	}
+	return c.params.CiphertextSize
+}
 
-	if KeyVariantSike != pub.keyVariant {
-		panic("Wrong type of public key")
+// Returns size of resulting shared secret, or zero if the KEM is unallocated.
+func (c *KEM) SharedSecretSize() int {
+	if c == nil || !c.allocated {
+		return 0
 	}
+	return c.params.KemSize
+}
 
-	if pub.keyVariant != prv.keyVariant {
-		panic("Public and private key are of different type")
+// SeedSize is the size of the seed used by DeriveKeyPair.
+const SeedSize = 32
+
+// DeriveKeyPair deterministically derives a SIKE key pair for the field id
+// from a seed of SeedSize bytes. The values s and sk of the private key are
+// read, in this order, from SHAKE256(seed), as the reference implementation
+// does when its random source is seeded with it.
+//
+// Concatenating the outputs of prv.Export and pub.Export gives the secret key
+// in the format of the reference implementation, that is s || sk || pk. Error
+// is returned in case id is not supported or the size of seed is wrong.
+func DeriveKeyPair(id uint8, seed []byte) (*PrivateKey, *PublicKey, error) {
+	if len(seed) != SeedSize {
+		return nil, nil, ErrSeedSize
 	}
+	h := sha3.NewShake256()
+	h.Write(seed)
+	return deriveKeyPair(id, h)
+}
 
-	if len(secret) < c.SharedSecretSize() {
-		panic("shared secret buffer to small")
+// deriveKeyPair generates a SIKE key pair for the field id from the stream
+// rng, sampling sk from <0..2^s - 1> like crypto_kem_keypair of the reference
+// implementation.
+func deriveKeyPair(id uint8, rng io.Reader) (*PrivateKey, *PublicKey, error) {
+	prv := NewPrivateKey(id, KeyVariantSike)
+	pub := NewPublicKey(id, KeyVariantSike)
+	if err := prv.generate(rng, false); err != nil {
+		return nil, nil, err
+	}
+	if err := prv.GeneratePublicKey(pub); err != nil {
+		return nil, nil, err
 	}
+	return prv, pub, nil
+}
 
-	if len(ciphertext) != c.CiphertextSize() {
-		panic("ciphertext buffer to small")
+// Encapsulate generates SIKE ciphertext and shared secret for the public key
+// pub, using rng as source of randomness. Sizes of the returned values are
+// given in the table of the package documentation. Error is returned in case
+// rng fails or pub is not a SIKE public key.
+//
+// Temporary values are local to each call, so the function is safe for
+// concurrent use as long as rng is.
+func Encapsulate(rng io.Reader, pub *PublicKey) (ciphertext, secret []byte, err error) {
+	if err = checkSikePublicKey(pub); err != nil {
+		return nil, nil, err
+	}
+	ciphertext = make([]byte, pub.params.CiphertextSize)
+	secret = make([]byte, pub.params.KemSize)
+	if err = encapsulate(ciphertext, secret, rng, pub); err != nil {
+		return nil, nil, err
 	}
+	return ciphertext, secret, nil
+}
 
+// Decapsulate returns the shared secret encapsulated in ciphertext, using the
+// SIKE key pair (prv, pub). If the ciphertext doesn't verify correctly, a
+// random value, unpredictable to the sender, is returned instead. Error is
+// returned in case keys are not compatible or the size of ciphertext is wrong.
+//
+// Safe for concurrent use.
+func Decapsulate(prv *PrivateKey, pub *PublicKey, ciphertext []byte) ([]byte, error) {
+	if err := checkSikeKeyPair(prv, pub); err != nil {
+		return nil, err
+	}
+	if len(ciphertext) != pub.params.CiphertextSize {
+		return nil, ErrCiphertextSize
+	}
+	secret := make([]byte, pub.params.KemSize)
+	decapsulate(secret, prv, pub, ciphertext)
+	return secret, nil
+}
+
+// checkSikePublicKey returns an error if pub is not a valid SIKE public key.
+func checkSikePublicKey(pub *PublicKey) error {
+	if err := pub.check(); err != nil {
+		return err
+	}
+	if pub.keyVariant != KeyVariantSike {
+		return ErrKeyVariant
+	}
+	return nil
+}
+
+// checkSikeKeyPair returns an error if prv and pub are not valid and
+// compatible SIKE keys.
+func checkSikeKeyPair(prv *PrivateKey, pub *PublicKey) error {
+	if err := checkSikePublicKey(pub); err != nil {
+		return err
+	}
+	if err := prv.check(); err != nil {
+		return err
+	}
+	if prv.keyVariant != pub.keyVariant || prv.params.ID != pub.params.ID {
+		return ErrKeyMismatch
+	}
+	return prv.checkSize()
+}
+
+// encapsulate implements SIKE encapsulation. Assumes inputs have been
+// validated by the caller.
+func encapsulate(ciphertext, secret []byte, rng io.Reader, pub *PublicKey) error {
+	var params = pub.params
+	var msg [common.MaxMsgBsz]byte
+	var scalar [common.MaxSidhPrivateKeyBsz]byte
+	var buf [3 * common.MaxSharedSecretBsz]byte
+	var m = msg[:params.MsgLen]
+
+	// Generate ephemeral value
+	if _, err := io.ReadFull(rng, m); err != nil {
+		return err
+	}
+
+	var skA = PrivateKey{
+		key: key{
+			params:     params,
+			keyVariant: KeyVariantSidhA},
+		Scalar: scalar[:params.A.SecretByteLen]}
+	var pkA = PublicKey{
+		key: key{
+			params:     params,
+			keyVariant: KeyVariantSidhA}}
+
+	// r = G(m||pub)
+	h := sha3.NewShake256()
+	pub.Export(buf[:])
+	h.Write(m)
+	h.Write(buf[:3*params.SharedSecretSize])
+	h.Read(skA.Scalar)
+
+	// Ensure bitlength is not bigger then to 2^e2-1
+	skA.Scalar[len(skA.Scalar)-1] &= (1 << (params.A.SecretBitLen % 8)) - 1
+	skA.GeneratePublicKey(&pkA)
+	generateCiphertext(ciphertext, &skA, &pkA, pub, m)
+
+	// K = H(msg||(c0||c1))
+	h.Reset()
+	h.Write(m)
+	h.Write(ciphertext[:params.CiphertextSize])
+	h.Read(secret[:params.KemSize])
+	return nil
+}
+
+// decapsulate implements SIKE decapsulation. Assumes inputs have been
+// validated by the caller.
+func decapsulate(secret []byte, prv *PrivateKey, pub *PublicKey, ciphertext []byte) {
+	var params = pub.params
 	var m [common.MaxMsgBsz]byte
 	var r [common.MaxSidhPrivateKeyBsz]byte
 	var pkBytes [3 * common.MaxSharedSecretBsz]byte
 	var skA = PrivateKey{
 		key: key{
-			params:     c.params,
+			params:     params,
 			keyVariant: KeyVariantSidhA},
-		Scalar: c.secretBytes}
-	var pkA = NewPublicKey(c.params.ID, KeyVariantSidhA)
-	c1Len := c.decrypt(m[:], prv, ciphertext)
+		Scalar: r[:params.A.SecretByteLen]}
+	var pkA = PublicKey{
+		key: key{
+			params:     params,
+			keyVariant: KeyVariantSidhA}}
+	c1Len := decrypt(m[:], prv, ciphertext)
 
 	// r' = G(m'||pub)
+	h := sha3.NewShake256()
 	pub.Export(pkBytes[:])
-	c.shake.Reset()
-	c.shake.Write(m[:c1Len])
-	c.shake.Write(pkBytes[:3*c.params.SharedSecretSize])
-	c.shake.Read(r[:c.params.A.SecretByteLen])
+	h.Write(m[:c1Len])
+	h.Write(pkBytes[:3*params.SharedSecretSize])
+	h.Read(skA.Scalar)
 	// Ensure bitlength is not bigger than 2^e2-1
-	r[c.params.A.SecretByteLen-1] &= (1 << (c.params.A.SecretBitLen % 8)) - 1
+	skA.Scalar[len(skA.Scalar)-1] &= (1 << (params.A.SecretBitLen % 8)) - 1
 
 	// Never fails
-	skA.Import(r[:c.params.A.SecretByteLen])
-	skA.GeneratePublicKey(pkA)
+	skA.GeneratePublicKey(&pkA)
 	pkA.Export(pkBytes[:])
 
 	// S is chosen at random when generating a key and unknown to other party. It is
@@ -154,47 +307,23 @@ func (c *KEM) Decapsulate(secret []byte, prv *PrivateKey, pub *PublicKey, cipher
 	//
 	// See more details in "On the security of supersingular isogeny cryptosystems"
 	// (S. Galbraith, et al., 2016, ePrint #859).
-	mask := subtle.ConstantTimeCompare(pkBytes[:c.params.PublicKeySize], ciphertext[:pub.params.PublicKeySize])
+	mask := subtle.ConstantTimeCompare(pkBytes[:params.PublicKeySize], ciphertext[:params.PublicKeySize])
 	common.Cpick(mask, m[:c1Len], m[:c1Len], prv.S)
-	c.shake.Reset()
-	c.shake.Write(m[:c1Len])
-	c.shake.Write(ciphertext)
-	c.shake.Read(secret[:c.SharedSecretSize()])
-	return nil
+	h.Reset()
+	h.Write(m[:c1Len])
+	h.Write(ciphertext)
+	h.Read(secret[:params.KemSize])
 }
 
-// Resets internal state of KEM. Function should be used
-// after Allocate and between subsequent calls to Encapsulate
-// and/or Decapsulate.
-func (c *KEM) Reset() {
-	for i := range c.msg {
-		c.msg[i] = 0
-	}
-
-	for i := range c.secretBytes {
-		c.secretBytes[i] = 0
-	}
-}
-
-// Returns size of resulting ciphertext
-func (c *KEM) CiphertextSize() int {
-	return c.params.CiphertextSize
-}
-
-// Returns size of resulting shared secret
-func (c *KEM) SharedSecretSize() int {
-	return c.params.KemSize
-}
-
-func (c *KEM) generateCiphertext(ctext []byte, skA *PrivateKey, pkA, pkB *PublicKey, ptext []byte) {
+func generateCiphertext(ctext []byte, skA *PrivateKey, pkA, pkB *PublicKey, ptext []byte) {
 	var n [common.MaxMsgBsz]byte
 	var j [common.MaxSharedSecretBsz]byte
 	var ptextLen = skA.params.MsgLen
 
 	skA.DeriveSecret(j[:], pkB)
-	c.shake.Reset()
-	c.shake.Write(j[:skA.params.SharedSecretSize])
-	c.shake.Read(n[:ptextLen])
+	h := sha3.NewShake256()
+	h.Write(j[:skA.params.SharedSecretSize])
+	h.Read(n[:ptextLen])
 	for i := range ptext {
 		n[i] ^= ptext[i]
 	}
@@ -206,7 +335,7 @@ func (c *KEM) generateCiphertext(ctext []byte, skA *PrivateKey, pkA, pkB *Public
 // encrypt uses SIKE public key to encrypt plaintext. Requires cryptographically secure
 // PRNG. Returns ciphertext in case encryption succeeds. Returns error in case PRNG fails
 // or wrongly formated input was provided.
-func (c *KEM) encrypt(ctext []byte, rng io.Reader, pub *PublicKey, ptext []byte) error {
+func encrypt(ctext []byte, rng io.Reader, pub *PublicKey, ptext []byte) error {
 	var ptextLen = len(ptext)
 	// c1 must be security level + 64 bits (see [SIKE] 1.4 and 4.3.3)
 	if ptextLen != pub.params.KemSize {
@@ -221,29 +350,32 @@ func (c *KEM) encrypt(ctext []byte, rng io.Reader, pub *PublicKey, ptext []byte)
 	}
 
 	skA.GeneratePublicKey(pkA)
-	c.generateCiphertext(ctext, skA, pkA, pub, ptext)
+	generateCiphertext(ctext, skA, pkA, pub, ptext)
 	return nil
 }
 
 // decrypt uses SIKE private key to decrypt ciphertext. Returns plaintext in case
 // decryption succeeds or error in case unexptected input was provided.
 // Constant time
-func (c *KEM) decrypt(n []byte, prv *PrivateKey, ctext []byte) int {
+func decrypt(n []byte, prv *PrivateKey, ctext []byte) int {
 	var c1Len int
 	var j [common.MaxSharedSecretBsz]byte
 	var pkLen = prv.params.PublicKeySize
+	var c0 = PublicKey{
+		key: key{
+			params:     prv.params,
+			keyVariant: KeyVariantSidhA}}
 
 	// ctext is a concatenation of (ciphertext = pubkey_A || c1)
 	// it must be security level + 64 bits (see [SIKE] 1.4 and 4.3.3)
 	// Lengths has been already checked by Decapsulate()
 	c1Len = len(ctext) - pkLen
-	c0 := NewPublicKey(prv.params.ID, KeyVariantSidhA)
 	// Never fails
 	c0.Import(ctext[:pkLen])
-	prv.DeriveSecret(j[:], c0)
-	c.shake.Reset()
-	c.shake.Write(j[:prv.params.SharedSecretSize])
-	c.shake.Read(n[:c1Len])
+	prv.DeriveSecret(j[:], &c0)
+	h := sha3.NewShake256()
+	h.Write(j[:prv.params.SharedSecretSize])
+	h.Read(n[:c1Len])
 	for i := range n[:c1Len] {
 		n[i] ^= ctext[pkLen+i]
 	}
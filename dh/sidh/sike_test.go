@@ -7,11 +7,14 @@ import (
 	"encoding/hex"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/cloudflare/circl/dh/sidh/internal/common"
+	"github.com/cloudflare/circl/internal/nist"
 	. "github.com/cloudflare/circl/internal/test"
 )
 
@@ -25,6 +28,22 @@ type sikeVec struct {
 }
 
 var tdataSike = map[uint8]sikeVec{
+	Fp434: {
+		Fp434, "P-434", NewSike434(rand.Reader),
+		"testdata/PQCkemKAT_374.rsp",
+		"1BD0A2E81307B6F96461317DDF535ACC0E59C742627BAE60D27605E10FAF722D" +
+			"22A73E184CB572A12E79DCD58C6B54FB01442114CBE9010B6CAEC25D04C16C5E" +
+			"42540C1524C545B8C67614ED4183C9FA5BD0BE45A7F89FBC770EE8E7E5E391C7" +
+			"EE6F35F74C29E6D9E35B1663DA01E48E9DEB2347512D366FDE505161677055E3" +
+			"EF23054D276E817E2C57025DA1C10D2461F68617F2D11256EEE4E2D7DBDF6C8E" +
+			"34F3A0FD00C625428CB41857002159DAB94267ABE42D630C6AAA91AF837C7A67" +
+			"40754EA6634C45454C51B0BB4D44C3CCCCE4B32C00901CF69C008D013348379B" +
+			"2F9837F428A01B6173584691F2A6F3A3C4CF487D20D261B36C8CDB1BC158E2A5" +
+			"162A9DA4F7A97AA0879B9897E2B6891B672201F9AEFBF799C27B2587120AC586" +
+			"A511360926FB7DA8EBF5CB5272F396AE06608422BE9792E2CE9BEF21BF55B7EF" +
+			"F8DC7EC8C99910D3F800",
+		"4B622DE1350119C45A9F2E2EF3DC5DF56A27FCDFCDDAF58CD69B903752D68C20" +
+			"0934E160B234E49EDE247601"},
 	Fp503: {
 		Fp503, "P-503", NewSike503(rand.Reader),
 		"testdata/PQCkemKAT_434.rsp",
@@ -90,9 +109,9 @@ func testPKERoundTrip(t *testing.T, v sikeVec) {
 	CheckNoErr(t, err, "Public key import failed")
 	err = skB.Import(skHex)
 	CheckNoErr(t, err, "Private key import failed")
-	err = v.kem.encrypt(ct, rand.Reader, pkB, msg[:])
+	err = encrypt(ct, rand.Reader, pkB, msg[:])
 	CheckNoErr(t, err, "PKE roundtrip - encryption failed")
-	ptLen := v.kem.decrypt(pt[:], skB, ct)
+	ptLen := decrypt(pt[:], skB, ct)
 	CheckNoErr(t, err, "PKE roundtrip - decription failed")
 
 	if !bytes.Equal(pt[:ptLen], msg[:]) {
@@ -119,9 +138,9 @@ func testPKEKeyGeneration(t *testing.T, v sikeVec) {
 	CheckNoErr(t, err, "PKE key generation")
 	sk.GeneratePublicKey(pk)
 
-	err = v.kem.encrypt(ct, rand.Reader, pk, msg[:])
+	err = encrypt(ct, rand.Reader, pk, msg[:])
 	CheckNoErr(t, err, "PKE encryption")
-	ptLen := v.kem.decrypt(pt[:], sk, ct)
+	ptLen := decrypt(pt[:], sk, ct)
 	CheckNoErr(t, err, "PKE key decryption")
 
 	if !bytes.Equal(pt[:ptLen], msg[:]) {
@@ -142,7 +161,7 @@ func testNegativePKE(t *testing.T, v sikeVec) {
 	sk.GeneratePublicKey(pk)
 
 	// bytelen(msg) - 1
-	err = v.kem.encrypt(ct, rand.Reader, pk, msg[:v.kem.params.KemSize+8-1])
+	err = encrypt(ct, rand.Reader, pk, msg[:v.kem.params.KemSize+8-1])
 	CheckIsErr(t, err, "PKE encryption doesn't fail")
 	for _, v := range ct {
 		if v != 0 {
@@ -219,18 +238,18 @@ func testNegativeKEM(t *testing.T, v sikeVec) {
 
 	// Try decapsulate too small ciphertext
 	v.kem.Reset()
-	CheckNoErr(
-		t,
-		CheckPanic(func() { v.kem.Decapsulate(ssTmp[:ssBsz], sk, pk, ct[:len(ct)-2]) }),
-		"Decapsulation must panic if ciphertext is too small")
+	err = v.kem.Decapsulate(ssTmp[:ssBsz], sk, pk, ct[:len(ct)-2])
+	if err != ErrCiphertextSize {
+		t.Error("Decapsulation must fail if ciphertext is too small")
+	}
 
 	ctTmp := make([]byte, len(ct)+1)
 	// Try decapsulate too big ciphertext
 	v.kem.Reset()
-	CheckNoErr(
-		t,
-		CheckPanic(func() { v.kem.Decapsulate(ssTmp[:ssBsz], sk, pk, ctTmp) }),
-		"Decapsulation must panic if ciphertext is too big")
+	err = v.kem.Decapsulate(ssTmp[:ssBsz], sk, pk, ctTmp)
+	if err != ErrCiphertextSize {
+		t.Error("Decapsulation must fail if ciphertext is too big")
+	}
 
 	// Change ciphertext
 	ct[0] = ct[0] - 1
@@ -247,17 +266,223 @@ func testNegativeKEM(t *testing.T, v sikeVec) {
 	pkSidh := NewPublicKey(v.id, KeyVariantSidhB)
 	prSidh := NewPrivateKey(v.id, KeyVariantSidhB)
 	v.kem.Reset()
-	CheckNoErr(
-		t,
-		CheckPanic(func() { v.kem.Encapsulate(ct, ssE[:], pkSidh) }),
-		"encapsulation accepts SIDH public key")
+	err = v.kem.Encapsulate(ct, ssE[:], pkSidh)
+	if err != ErrKeyVariant {
+		t.Error("encapsulation accepts SIDH public key")
+	}
 
 	// Try decapsulating with SIDH key
 	v.kem.Reset()
-	CheckNoErr(
-		t,
-		CheckPanic(func() { v.kem.Decapsulate(ssD[:ssBsz], prSidh, pk, ct) }),
-		"encapsulation accepts SIDH public key")
+	err = v.kem.Decapsulate(ssD[:ssBsz], prSidh, pk, ct)
+	if err != ErrKeyMismatch {
+		t.Error("decapsulation accepts SIDH private key")
+	}
+}
+
+// Checks that no combination of KEM state, keys, ciphertexts and buffer sizes
+// makes the KEM API panic.
+func TestKEMNoPanic(t *testing.T) {
+	kems := []*KEM{nil, {}, NewSike434(rand.Reader), NewSike503(rand.Reader), NewSike751(rand.Reader)}
+	var kem KEM
+	err := kem.Allocate(0xFF, rand.Reader)
+	if err != ErrUnsupportedField {
+		ReportError(t, err, ErrUnsupportedField)
+	}
+	kems = append(kems, &kem)
+	_, _, err = DeriveKeyPair(0xFF, make([]byte, SeedSize))
+	if err != ErrUnsupportedField {
+		ReportError(t, err, ErrUnsupportedField)
+	}
+
+	pubs := []*PublicKey{nil, {}}
+	prvs := []*PrivateKey{nil, {}}
+	for _, id := range []uint8{0, Fp434, Fp503, Fp751} {
+		for _, v := range []KeyVariant{0, KeyVariantSidhA, KeyVariantSike} {
+			pub := NewPublicKey(id, v)
+			prv := NewPrivateKey(id, v)
+			if prv.Generate(rand.Reader) == nil {
+				CheckNoErr(t, prv.GeneratePublicKey(pub), "public key generation failed")
+			}
+			pubs = append(pubs, pub)
+			prvs = append(prvs, prv)
+		}
+	}
+
+	var ss [common.MaxSharedSecretBsz]byte
+	ct := make([]byte, common.MaxCiphertextBsz+1)
+	for _, c := range kems {
+		ctLens := []int{0, c.CiphertextSize(), common.MaxCiphertextBsz + 1}
+		if c.CiphertextSize() > 0 {
+			ctLens = append(ctLens, c.CiphertextSize()-1, c.CiphertextSize()+1)
+		}
+		for _, pub := range pubs {
+			err := CheckPanic(func() {
+				_ = c.Encapsulate(ct[:c.CiphertextSize()], ss[:c.SharedSecretSize()], pub)
+				_ = c.Encapsulate(ct[:1], ss[:], pub)
+				_ = c.Encapsulate(ct, ss[:1], pub)
+			})
+			CheckIsErr(t, err, "encapsulation panics")
+
+			for _, prv := range prvs {
+				for _, n := range ctLens {
+					_, _ = rand.Read(ct[:n])
+					err := CheckPanic(func() {
+						_ = c.Decapsulate(ss[:c.SharedSecretSize()], prv, pub, ct[:n])
+						_ = c.Decapsulate(ss[:1], prv, pub, ct[:n])
+					})
+					CheckIsErr(t, err, "decapsulation panics")
+				}
+			}
+		}
+	}
+
+	// Expected errors.
+	err = kems[0].Encapsulate(ct, ss[:], pubs[2])
+	if err != ErrUnallocated {
+		ReportError(t, err, ErrUnallocated)
+	}
+	err = kems[1].Decapsulate(ss[:], prvs[2], pubs[2], ct)
+	if err != ErrUnallocated {
+		ReportError(t, err, ErrUnallocated)
+	}
+	c := NewSike503(rand.Reader)
+	pub := NewPublicKey(Fp503, KeyVariantSike)
+	prv := NewPrivateKey(Fp503, KeyVariantSike)
+	CheckNoErr(t, prv.Generate(rand.Reader), "key generation failed")
+	CheckNoErr(t, prv.GeneratePublicKey(pub), "public key generation failed")
+	err = c.Encapsulate(ct[:c.CiphertextSize()-1], ss[:], pub)
+	if err != ErrBufferSize {
+		ReportError(t, err, ErrBufferSize)
+	}
+	err = c.Encapsulate(ct, ss[:], NewPublicKey(Fp751, KeyVariantSike))
+	if err != ErrKeyMismatch {
+		ReportError(t, err, ErrKeyMismatch)
+	}
+	err = c.Decapsulate(ss[:c.SharedSecretSize()-1], prv, pub, ct[:c.CiphertextSize()])
+	if err != ErrBufferSize {
+		ReportError(t, err, ErrBufferSize)
+	}
+	err = c.Decapsulate(ss[:], prv, pub, ct)
+	if err != ErrCiphertextSize {
+		ReportError(t, err, ErrCiphertextSize)
+	}
+	err = c.Decapsulate(ss[:], prv, NewPublicKey(Fp503, KeyVariantSidhA), ct[:c.CiphertextSize()])
+	if err != ErrKeyVariant {
+		ReportError(t, err, ErrKeyVariant)
+	}
+}
+
+// Runs Encapsulate and Decapsulate concurrently with shared keys and a shared
+// KEM object. Meant to be run with the race detector.
+func testConcurrent(t *testing.T, v sikeVec) {
+	const goroutines, iterations = 8, 2
+	pk := NewPublicKey(v.id, KeyVariantSike)
+	sk := NewPrivateKey(v.id, KeyVariantSike)
+	CheckNoErr(t, sk.Generate(rand.Reader), "key generation failed")
+	CheckNoErr(t, sk.GeneratePublicKey(pk), "public key generation failed")
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 2*goroutines*iterations)
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var ssD [common.MaxSharedSecretBsz]byte
+			ct := make([]byte, v.kem.CiphertextSize())
+			for i := 0; i < iterations; i++ {
+				ct1, ss1, err := Encapsulate(rand.Reader, pk)
+				if err != nil {
+					errs <- err
+					continue
+				}
+				ss2, err := Decapsulate(sk, pk, ct1)
+				if err != nil || !bytes.Equal(ss1, ss2) {
+					errs <- fmt.Errorf("stateless KEM failed: %v", err)
+				}
+
+				ssE := make([]byte, v.kem.SharedSecretSize())
+				err = v.kem.Encapsulate(ct, ssE, pk)
+				if err != nil {
+					errs <- err
+					continue
+				}
+				err = v.kem.Decapsulate(ssD[:len(ssE)], sk, pk, ct)
+				if err != nil || !bytes.Equal(ssE, ssD[:len(ssE)]) {
+					errs <- fmt.Errorf("shared KEM failed: %v", err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+// Derives key pairs from a seed and checks that keys exported in the format of
+// the reference implementation and imported back give the same shared secrets.
+func testDeriveKeyPair(t *testing.T, v sikeVec) {
+	var seed [SeedSize]byte
+	for i := range seed {
+		seed[i] = byte(i)
+	}
+	prv, pub, err := DeriveKeyPair(v.id, seed[:])
+	CheckNoErr(t, err, "key derivation failed")
+	prv2, pub2, err := DeriveKeyPair(v.id, seed[:])
+	CheckNoErr(t, err, "key derivation failed")
+
+	// Secret key of the reference implementation is s || sk || pk.
+	sk := make([]byte, prv.Size()+pub.Size())
+	CheckNoErr(t, prv.Export(sk), "private key export failed")
+	CheckNoErr(t, pub.Export(sk[prv.Size():]), "public key export failed")
+	sk2 := make([]byte, len(sk))
+	CheckNoErr(t, prv2.Export(sk2), "private key export failed")
+	CheckNoErr(t, pub2.Export(sk2[prv2.Size():]), "public key export failed")
+	if !bytes.Equal(sk, sk2) {
+		t.Fatal("key derivation is not deterministic")
+	}
+
+	prvI := NewPrivateKey(v.id, KeyVariantSike)
+	pubI := NewPublicKey(v.id, KeyVariantSike)
+	CheckNoErr(t, prvI.Import(sk[:prvI.Size()]), "private key import failed")
+	CheckNoErr(t, pubI.Import(sk[prvI.Size():]), "public key import failed")
+	pubG := NewPublicKey(v.id, KeyVariantSike)
+	pkG := make([]byte, pubG.Size())
+	CheckNoErr(t, prvI.GeneratePublicKey(pubG), "public key generation failed")
+	CheckNoErr(t, pubG.Export(pkG), "public key export failed")
+	if !bytes.Equal(pkG, sk[prvI.Size():]) {
+		ReportError(t, pkG, sk[prvI.Size():], v.name)
+	}
+
+	ct, ss, err := Encapsulate(rand.Reader, pub)
+	CheckNoErr(t, err, "encapsulation failed")
+	ssI, err := Decapsulate(prvI, pubI, ct)
+	CheckNoErr(t, err, "decapsulation failed")
+	if !bytes.Equal(ss, ssI) {
+		ReportError(t, ssI, ss, v.name)
+	}
+	ct, ss, err = Encapsulate(rand.Reader, pubI)
+	CheckNoErr(t, err, "encapsulation failed")
+	ssD, err := Decapsulate(prv, pub, ct)
+	CheckNoErr(t, err, "decapsulation failed")
+	if !bytes.Equal(ss, ssD) {
+		ReportError(t, ssD, ss, v.name)
+	}
+
+	seed[0] ^= 1
+	prv3, _, err := DeriveKeyPair(v.id, seed[:])
+	CheckNoErr(t, err, "key derivation failed")
+	sk3 := make([]byte, prv3.Size())
+	CheckNoErr(t, prv3.Export(sk3), "private key export failed")
+	if bytes.Equal(sk3, sk[:prv.Size()]) {
+		t.Fatal("different seeds give the same key")
+	}
+
+	_, _, err = DeriveKeyPair(v.id, seed[1:])
+	if err != ErrSeedSize {
+		ReportError(t, err, ErrSeedSize, v.name)
+	}
 }
 
 // In case invalid ciphertext is provided, SIKE's decapsulation must
@@ -357,6 +582,24 @@ func testKAT(t *testing.T, v sikeVec) {
 		return bytes.Equal(pubKeyBytes, pk)
 	}
 
+	// Keys derived from the DRBG stream of the KAT seed must be the ones of
+	// the reference implementation.
+	testDerive := func(seed, pk, sk []byte) {
+		var s [48]byte
+		copy(s[:], seed)
+		g := nist.NewDRBG(&s)
+		prv, pub, err := deriveKeyPair(v.id, &g)
+		CheckNoErr(t, err, "key derivation failed")
+		pkGot := make([]byte, pub.Size())
+		skGot := make([]byte, prv.Size()+pub.Size())
+		CheckNoErr(t, pub.Export(pkGot), "public key export failed")
+		CheckNoErr(t, prv.Export(skGot), "private key export failed")
+		copy(skGot[prv.Size():], pkGot)
+		if !bytes.Equal(pkGot, pk) || !bytes.Equal(skGot, sk) {
+			t.Fatalf("%v: derived key pair differs from the KAT", v.name)
+		}
+	}
+
 	f, err := os.Open(v.KatFile)
 	if err != nil {
 		t.Fatal(err)
@@ -379,12 +622,13 @@ func testKAT(t *testing.T, v sikeVec) {
 		// count
 		_ = strings.Split(string(line), "=")[1]
 		// seed
-		_ = readAndCheckLine(r)
+		seed := readAndCheckLine(r)
 		// pk
 		pk := readAndCheckLine(r)
 		// sk (secret key in test vector is concatenation of
 		// MSG + SECRET_BOB_KEY + PUBLIC_BOB_KEY. We use only MSG+SECRET_BOB_KEY
 		sk := readAndCheckLine(r)
+		testDerive(seed, pk, sk)
 		sk = sk[:v.kem.params.MsgLen+int(v.kem.params.B.SecretByteLen)]
 		// ct
 		ct := readAndCheckLine(r)
@@ -397,6 +641,63 @@ func testKAT(t *testing.T, v sikeVec) {
 	}
 }
 
+// Regenerates the KAT file byte-for-byte, driving the KEM with the DRBG of
+// NIST's PQCgenKAT.c.
+func testKATGeneration(t *testing.T, v sikeVec) {
+	want, err := ioutil.ReadFile(v.KatFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var seed [48]byte
+	for i := range seed {
+		seed[i] = byte(i)
+	}
+	kseed := make([][48]byte, 100)
+	g := nist.NewDRBG(&seed)
+	for i := range kseed {
+		g.Fill(kseed[i][:])
+	}
+
+	// Some of the files in testdata have CRLF line endings.
+	eol := "\n"
+	if bytes.HasSuffix(want, []byte("\r\n")) {
+		eol = "\r\n"
+	}
+
+	var got bytes.Buffer
+	fmt.Fprintf(&got, "# SIKEp%s%s%s", strings.TrimPrefix(v.name, "P-"), eol, eol)
+	for i := range kseed {
+		g := nist.NewDRBG(&kseed[i])
+		prv, pub, err := deriveKeyPair(v.id, &g)
+		CheckNoErr(t, err, "key derivation failed")
+		pk := make([]byte, pub.Size())
+		sk := make([]byte, prv.Size()+pub.Size())
+		CheckNoErr(t, pub.Export(pk), "public key export failed")
+		CheckNoErr(t, prv.Export(sk), "private key export failed")
+		copy(sk[prv.Size():], pk)
+
+		ct, ss, err := Encapsulate(&g, pub)
+		CheckNoErr(t, err, "encapsulation failed")
+		ss2, err := Decapsulate(prv, pub, ct)
+		CheckNoErr(t, err, "decapsulation failed")
+		if !bytes.Equal(ss, ss2) {
+			ReportError(t, ss2, ss, v.name, i)
+		}
+
+		fmt.Fprintf(&got, "count = %d%s", i, eol)
+		fmt.Fprintf(&got, "seed = %X%s", kseed[i], eol)
+		fmt.Fprintf(&got, "pk = %X%s", pk, eol)
+		fmt.Fprintf(&got, "sk = %X%s", sk, eol)
+		fmt.Fprintf(&got, "ct = %X%s", ct, eol)
+		fmt.Fprintf(&got, "ss = %X%s%s", ss, eol, eol)
+	}
+
+	if !bytes.Equal(got.Bytes(), want) {
+		t.Fatalf("%v: generated KAT file differs from %v", v.name, v.KatFile)
+	}
+}
+
 // Interface to "testing"
 
 /* -------------------------------------------------------------------------
@@ -414,6 +715,14 @@ func TestNegativePKE(t *testing.T)      { testSike(t, &tdataSike, testNegativePK
 func TestKEMKeyGeneration(t *testing.T) { testSike(t, &tdataSike, testKEMKeyGeneration) }
 func TestNegativeKEM(t *testing.T)      { testSike(t, &tdataSike, testNegativeKEM) }
 func TestKAT(t *testing.T)              { testSike(t, &tdataSike, testKAT) }
+func TestConcurrent(t *testing.T)       { testSike(t, &tdataSike, testConcurrent) }
+func TestDeriveKeyPair(t *testing.T)    { testSike(t, &tdataSike, testDeriveKeyPair) }
+func TestKATGeneration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+	testSike(t, &tdataSike, testKATGeneration)
+}
 func TestNegativeKEMSameWrongResult(t *testing.T) {
 	testSike(t, &tdataSike, testNegativeKEMSameWrongResult)
 }
@@ -1,2 +1,4 @@
+//go:generate go run gen.go
+
 // Package ecc provides implementation of arithmetic on some elliptic curves.
 package ecc
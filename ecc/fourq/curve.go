@@ -83,6 +83,34 @@ func (P *Point) ScalarBaseMult(k *[Size]byte) {
 	P.fromR1(&_P)
 }
 
+// DoubleScalarMult calculates P = k*G + l*Q, where G is the generator point.
+// Unlike ScalarMult, Q is not multiplied by the cofactor, so Q can be any
+// point on the curve. Runs in non-constant time to be used in signature
+// verification, so only use it with public inputs.
+func (P *Point) DoubleScalarMult(k, l *[Size]byte, Q *Point) {
+	var _P, _Q pointR1
+	Q.toR1(&_Q)
+	_P.doubleScalarMult(k, l, &_Q)
+	P.fromR1(&_P)
+}
+
+// MultiScalarMult calculates P = k[0]*Q[0] + ... + k[n-1]*Q[n-1]. As in
+// DoubleScalarMult, points are not multiplied by the cofactor. Panics if k and
+// Q have different lengths. Runs in non-constant time, so only use it with
+// public inputs.
+func (P *Point) MultiScalarMult(k [][Size]byte, Q []Point) {
+	if len(k) != len(Q) {
+		panic("fourq: number of scalars and points must be equal")
+	}
+	_Q := make([]pointR1, len(Q))
+	for i := range Q {
+		Q[i].toR1(&_Q[i])
+	}
+	var _P pointR1
+	_P.multiScalarMult(k, _Q)
+	P.fromR1(&_P)
+}
+
 func (P *Point) fromR1(Q *pointR1) {
 	Q.ToAffine()
 	P.X = Q.X
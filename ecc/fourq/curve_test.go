@@ -73,6 +73,68 @@ func TestMarshal(t *testing.T) {
 	})
 }
 
+func TestDoubleScalarMult(t *testing.T) {
+	testTimes := 1 << 10
+	c := Params()
+	var k, l, s, ls [Size]byte
+	var P, Q, R, S Point
+	for i := 0; i < testTimes; i++ {
+		bigK, _ := rand.Int(rand.Reader, c.N)
+		bigL, _ := rand.Int(rand.Reader, c.N)
+		bigS, _ := rand.Int(rand.Reader, c.N)
+		conv.BigInt2BytesLe(k[:], bigK)
+		conv.BigInt2BytesLe(l[:], bigL)
+		conv.BigInt2BytesLe(s[:], bigS)
+		conv.BigInt2BytesLe(ls[:], bigL.Mul(bigL, bigS).Mod(bigL, c.N))
+
+		// Q = s*G, so k*G + l*Q = k*G + (l*s)*G
+		Q.ScalarBaseMult(&s)
+		P.DoubleScalarMult(&k, &l, &Q)
+		R.ScalarBaseMult(&k)
+		S.ScalarBaseMult(&ls)
+		R.Add(&R, &S)
+
+		if P != R {
+			test.ReportError(t, P, R, k, l, s)
+		}
+	}
+}
+
+func TestMultiScalarMult(t *testing.T) {
+	testTimes := 1 << 6
+	c := Params()
+	var P, R, S Point
+	for _, n := range []int{0, 1, 3, 8} {
+		k := make([][Size]byte, n)
+		Q := make([]Point, n)
+		var s [Size]byte
+		for i := 0; i < testTimes; i++ {
+			R.SetIdentity()
+			for j := 0; j < n; j++ {
+				bigK, _ := rand.Int(rand.Reader, c.N)
+				bigS, _ := rand.Int(rand.Reader, c.N)
+				conv.BigInt2BytesLe(k[j][:], bigK)
+				conv.BigInt2BytesLe(s[:], bigS)
+				Q[j].ScalarBaseMult(&s)
+
+				conv.BigInt2BytesLe(s[:], bigK.Mul(bigK, bigS).Mod(bigK, c.N))
+				S.ScalarBaseMult(&s)
+				R.Add(&R, &S)
+			}
+			P.MultiScalarMult(k, Q)
+
+			if P != R {
+				test.ReportError(t, P, R, n, k)
+			}
+		}
+	}
+
+	t.Run("length mismatch", func(t *testing.T) {
+		err := test.CheckPanic(func() { P.MultiScalarMult(make([][Size]byte, 2), make([]Point, 3)) })
+		test.CheckNoErr(t, err, "MultiScalarMult must panic")
+	})
+}
+
 func BenchmarkCurve(b *testing.B) {
 	var P, Q, R Point
 	var k [32]byte
@@ -107,4 +169,10 @@ func BenchmarkCurve(b *testing.B) {
 			P.ScalarMult(&k, &Q)
 		}
 	})
+
+	b.Run("DoubleScalarMult", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			P.DoubleScalarMult(&k, &k, &Q)
+		}
+	})
 }
@@ -6,6 +6,9 @@ import (
 	"crypto/subtle"
 	"encoding/binary"
 	"math/bits"
+
+	"github.com/cloudflare/circl/internal/conv"
+	"github.com/cloudflare/circl/math"
 )
 
 type pointR1 struct {
@@ -108,6 +111,80 @@ func (P *pointR1) ScalarMult(k *[32]byte, Q *pointR1) {
 	}
 }
 
+// doubleScalarMult calculates P = k*G + l*Q, where G is the generator point.
+// Scalars are recoded in w-NAF form and processed in an interleaved fashion,
+// sharing doublings. Runs in non-constant time.
+func (P *pointR1) doubleScalarMult(k, l *[Size]byte, Q *pointR1) {
+	var TabQ [8]pointR2
+	var R2 pointR2
+	var R3 pointR3
+	nafK := omegaNAF(k, baseOmega)
+	nafL := omegaNAF(l, 5)
+	if len(nafK) > len(nafL) {
+		nafL = append(nafL, make([]int32, len(nafK)-len(nafL))...)
+	} else if len(nafK) < len(nafL) {
+		nafK = append(nafK, make([]int32, len(nafL)-len(nafK))...)
+	}
+
+	Q.oddMultiples(&TabQ)
+	P.SetIdentity()
+	for i := len(nafK) - 1; i >= 0; i-- {
+		P.double()
+		// Generator point
+		if nafK[i] != 0 {
+			R3 = baseOddMultiples[absolute(nafK[i])>>1]
+			if nafK[i] < 0 {
+				R3.cneg(1)
+			}
+			P.mixAdd(&R3)
+		}
+		// Input point
+		if nafL[i] != 0 {
+			R2 = TabQ[absolute(nafL[i])>>1]
+			if nafL[i] < 0 {
+				R2.cneg(1)
+			}
+			P.add(&R2)
+		}
+	}
+}
+
+// multiScalarMult calculates P = sum k[i]*Q[i] for i=0,...,len(k)-1, using
+// interleaved w-NAF recodings of the scalars. Runs in non-constant time.
+func (P *pointR1) multiScalarMult(k [][Size]byte, Q []pointR1) {
+	var R pointR2
+	n := len(k)
+	naf := make([][]int32, n)
+	tab := make([][8]pointR2, n)
+	maxLen := 0
+	for j := 0; j < n; j++ {
+		naf[j] = omegaNAF(&k[j], 5)
+		if len(naf[j]) > maxLen {
+			maxLen = len(naf[j])
+		}
+		Q[j].oddMultiples(&tab[j])
+	}
+
+	P.SetIdentity()
+	for i := maxLen - 1; i >= 0; i-- {
+		P.double()
+		for j := 0; j < n; j++ {
+			if i < len(naf[j]) && naf[j][i] != 0 {
+				R = tab[j][absolute(naf[j][i])>>1]
+				if naf[j][i] < 0 {
+					R.cneg(1)
+				}
+				P.add(&R)
+			}
+		}
+	}
+}
+
+// omegaNAF obtains the w-NAF recoding of a little-endian scalar k.
+func omegaNAF(k *[Size]byte, w uint) []int32 {
+	return math.OmegaNAF(conv.BytesLe2BigInt(k[:]), w)
+}
+
 // absolute returns always a positive value.
 func absolute(x int32) int32 {
 	mask := x >> 31
@@ -125,6 +125,59 @@ func TestScalarMult(t *testing.T) {
 	})
 }
 
+func TestPointDoubleScalarMult(t *testing.T) {
+	const testTimes = 1 << 10
+	var P, Q, R, S pointR1
+	var k, l [Size]byte
+	var RR pointR2
+
+	for i := 0; i < testTimes; i++ {
+		Q.random()
+		_, _ = rand.Read(k[:])
+		_, _ = rand.Read(l[:])
+		P.doubleScalarMult(&k, &l, &Q)
+
+		R.ScalarBaseMult(&k)
+		S.ScalarMult(&l, &Q)
+		RR.FromR1(&S)
+		R.add(&RR)
+
+		got := P.isEqual(&R)
+		want := true
+		if got != want {
+			test.ReportError(t, got, want, k, l, Q)
+		}
+	}
+}
+
+func TestPointMultiScalarMult(t *testing.T) {
+	const testTimes = 1 << 6
+	var P, R, S pointR1
+	var RR pointR2
+
+	for _, n := range []int{0, 1, 2, 5, 16} {
+		k := make([][Size]byte, n)
+		Q := make([]pointR1, n)
+		for i := 0; i < testTimes; i++ {
+			R.SetIdentity()
+			for j := 0; j < n; j++ {
+				Q[j].random()
+				_, _ = rand.Read(k[j][:])
+				S.ScalarMult(&k[j], &Q[j])
+				RR.FromR1(&S)
+				R.add(&RR)
+			}
+			P.multiScalarMult(k, Q)
+
+			got := P.isEqual(&R)
+			want := true
+			if got != want {
+				test.ReportError(t, got, want, n, k)
+			}
+		}
+	}
+}
+
 func TestScalar(t *testing.T) {
 	const testTimes = 1 << 12
 	var x, xx [5]uint64
@@ -264,4 +317,9 @@ func BenchmarkPoint(b *testing.B) {
 			P.ScalarMult(&k, &R)
 		}
 	})
+	b.Run("dblscmul", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			P.doubleScalarMult(&k, &k, &R)
+		}
+	})
 }
@@ -131,3 +131,491 @@ var tableBaseFixed = [fxV][fx2w1]pointR3{
 		},
 	},
 }
+
+// baseOmega is the window size used to recode the scalar that multiplies the
+// generator point in DoubleScalarMult.
+const baseOmega = uint(7)
+
+// baseOddMultiples has [2*i+1] * G at position i.
+var baseOddMultiples = [1 << (baseOmega - 2)]pointR3{
+	// 1G
+	{
+		addYX: Fq{
+			[SizeFp]byte{0x31, 0xe6, 0x03, 0xa7, 0xf3, 0x34, 0x8a, 0xe1, 0x5f, 0x2b, 0x50, 0x1d, 0xbf, 0x60, 0x74, 0x28},
+			[SizeFp]byte{0x53, 0x03, 0xf9, 0xe4, 0xf7, 0x62, 0x2e, 0xe0, 0xde, 0xac, 0x86, 0x8b, 0x37, 0xa0, 0x3b, 0x0c},
+		},
+		subYX: Fq{
+			[SizeFp]byte{0xdc, 0x7e, 0x93, 0xb0, 0x98, 0x0f, 0xbf, 0x90, 0x55, 0xc5, 0xf0, 0x24, 0x78, 0x7c, 0x0b, 0x74},
+			[SizeFp]byte{0x66, 0x13, 0xa0, 0x23, 0x91, 0x23, 0x21, 0xb3, 0xa5, 0x57, 0x95, 0x3a, 0xb9, 0xf5, 0xfc, 0x4f},
+		},
+		dt2: Fq{
+			[SizeFp]byte{0xbb, 0x42, 0xda, 0xab, 0xcb, 0xfc, 0x7a, 0x29, 0xc6, 0x97, 0x6c, 0x55, 0x37, 0xd1, 0x48, 0x59},
+			[SizeFp]byte{0x4c, 0x68, 0x30, 0x33, 0x39, 0x9a, 0x18, 0xa8, 0x27, 0x1f, 0x34, 0x0a, 0x72, 0x2b, 0xaf, 0x0c},
+		},
+	},
+	// 3G
+	{
+		addYX: Fq{
+			[SizeFp]byte{0xc4, 0x68, 0xcf, 0x5b, 0xb1, 0x56, 0x27, 0x89, 0xba, 0x26, 0xa5, 0x98, 0x7c, 0xf7, 0x42, 0x57},
+			[SizeFp]byte{0x9b, 0x9f, 0xf8, 0xe9, 0x1d, 0x5a, 0x0a, 0x34, 0xf7, 0xd0, 0x75, 0xee, 0x0a, 0x68, 0xef, 0x14},
+		},
+		subYX: Fq{
+			[SizeFp]byte{0x1f, 0xa4, 0x43, 0x40, 0xe1, 0x70, 0xe7, 0x84, 0x95, 0x3c, 0xc3, 0x16, 0x11, 0xc4, 0x12, 0x02},
+			[SizeFp]byte{0xe2, 0xc0, 0x4d, 0xde, 0xe6, 0x91, 0xb7, 0x35, 0x28, 0x5d, 0x8d, 0x51, 0x08, 0xdf, 0x49, 0x59},
+		},
+		dt2: Fq{
+			[SizeFp]byte{0xdb, 0x10, 0xed, 0x44, 0x07, 0x12, 0x0e, 0x6a, 0xd3, 0x91, 0x43, 0x84, 0xce, 0x83, 0x51, 0x5a},
+			[SizeFp]byte{0x50, 0xba, 0xfd, 0x8a, 0x15, 0x8b, 0x61, 0x6f, 0x88, 0x20, 0x0e, 0x47, 0x7e, 0x03, 0xe2, 0x2c},
+		},
+	},
+	// 5G
+	{
+		addYX: Fq{
+			[SizeFp]byte{0x3c, 0xba, 0x64, 0x9a, 0x14, 0xfa, 0x49, 0x1f, 0x51, 0x04, 0x67, 0x19, 0xd5, 0x76, 0x98, 0x5f},
+			[SizeFp]byte{0x6b, 0x58, 0x55, 0x6f, 0x05, 0x05, 0x01, 0x03, 0x26, 0xd7, 0x8f, 0x7d, 0x55, 0x1a, 0x0f, 0x02},
+		},
+		subYX: Fq{
+			[SizeFp]byte{0xc8, 0x86, 0x6d, 0xb0, 0x75, 0xb1, 0x4c, 0xdf, 0x90, 0x83, 0xe5, 0x7f, 0xbe, 0xbc, 0x4f, 0x69},
+			[SizeFp]byte{0x67, 0x1b, 0x6a, 0x75, 0x4a, 0x29, 0x33, 0x79, 0xec, 0xf8, 0x58, 0x4b, 0x92, 0xe9, 0xdb, 0x09},
+		},
+		dt2: Fq{
+			[SizeFp]byte{0xb6, 0x97, 0xf1, 0xcd, 0x03, 0x44, 0x0f, 0x59, 0xa7, 0x0b, 0x7a, 0xc8, 0x9f, 0x96, 0x07, 0x1c},
+			[SizeFp]byte{0x67, 0x23, 0x25, 0x12, 0x77, 0x47, 0x96, 0xc4, 0x96, 0xb0, 0xf1, 0x22, 0x60, 0x97, 0x08, 0x55},
+		},
+	},
+	// 7G
+	{
+		addYX: Fq{
+			[SizeFp]byte{0x75, 0x17, 0x2e, 0x45, 0x1e, 0x36, 0xda, 0xef, 0xfb, 0x38, 0xc8, 0xac, 0xcc, 0x0c, 0x0a, 0x7a},
+			[SizeFp]byte{0x5f, 0xdc, 0xe5, 0x0b, 0x1c, 0x79, 0x7e, 0xb0, 0x93, 0xcb, 0xcb, 0x18, 0xb4, 0xb6, 0xd9, 0x24},
+		},
+		subYX: Fq{
+			[SizeFp]byte{0x03, 0x7e, 0x11, 0xc6, 0xf3, 0x70, 0x79, 0x49, 0x95, 0xd5, 0x96, 0xcb, 0x58, 0xa1, 0x86, 0x39},
+			[SizeFp]byte{0x2b, 0x61, 0x92, 0xe6, 0x6c, 0x58, 0x80, 0x8f, 0xd6, 0xf9, 0x4d, 0x7e, 0xda, 0xaf, 0x5c, 0x30},
+		},
+		dt2: Fq{
+			[SizeFp]byte{0x4a, 0x91, 0x52, 0x64, 0xe0, 0xc2, 0xa1, 0xc1, 0x79, 0x30, 0x58, 0xeb, 0xc0, 0x89, 0xf9, 0x7e},
+			[SizeFp]byte{0x99, 0xb0, 0x64, 0x73, 0x1f, 0x5b, 0x76, 0x3a, 0x6b, 0x9c, 0x29, 0x58, 0x6d, 0x23, 0xee, 0x4f},
+		},
+	},
+	// 9G
+	{
+		addYX: Fq{
+			[SizeFp]byte{0x19, 0x84, 0x0e, 0x77, 0x5f, 0x09, 0x81, 0x6f, 0x09, 0xbc, 0x96, 0x73, 0x6b, 0xd8, 0xbb, 0x53},
+			[SizeFp]byte{0x10, 0x42, 0x2b, 0x6b, 0x72, 0xba, 0x72, 0x2b, 0x8b, 0xc7, 0x01, 0x29, 0x1d, 0xda, 0x5d, 0x62},
+		},
+		subYX: Fq{
+			[SizeFp]byte{0x3e, 0x2b, 0xcd, 0x18, 0x7b, 0xbc, 0xf5, 0x0f, 0x32, 0xd3, 0x58, 0x73, 0x8c, 0x59, 0x56, 0x05},
+			[SizeFp]byte{0xd7, 0x50, 0xff, 0x20, 0x5f, 0x24, 0x91, 0x09, 0x7e, 0xa9, 0x19, 0xe9, 0xe5, 0x58, 0x7f, 0x0e},
+		},
+		dt2: Fq{
+			[SizeFp]byte{0x56, 0x87, 0x75, 0x3b, 0x37, 0x61, 0x05, 0x5a, 0x8a, 0x19, 0x7c, 0xf8, 0x93, 0xbc, 0x47, 0x64},
+			[SizeFp]byte{0x20, 0x75, 0x4c, 0xc3, 0x04, 0x06, 0x23, 0xf9, 0xfa, 0x1b, 0x5c, 0x47, 0x25, 0x44, 0x21, 0x6b},
+		},
+	},
+	// 11G
+	{
+		addYX: Fq{
+			[SizeFp]byte{0x97, 0x94, 0x7f, 0x6a, 0x2d, 0xe6, 0x3d, 0xe9, 0x3c, 0x49, 0xf4, 0x86, 0x9d, 0x45, 0x29, 0x21},
+			[SizeFp]byte{0xe4, 0xcf, 0x64, 0xc4, 0xc7, 0x94, 0x63, 0x45, 0xb3, 0xa1, 0xf4, 0xc3, 0xfe, 0x34, 0x24, 0x61},
+		},
+		subYX: Fq{
+			[SizeFp]byte{0xf3, 0x61, 0x42, 0xf4, 0xdd, 0x1e, 0xd9, 0x1e, 0xff, 0xa3, 0xe0, 0xf9, 0x54, 0x38, 0x6d, 0x0c},
+			[SizeFp]byte{0xe3, 0xe4, 0xa7, 0x88, 0x31, 0x15, 0xfd, 0xd3, 0x0c, 0x91, 0x16, 0xca, 0xbd, 0x1f, 0x69, 0x24},
+		},
+		dt2: Fq{
+			[SizeFp]byte{0x9d, 0x5c, 0x62, 0xd7, 0x5c, 0x46, 0x97, 0xbe, 0xf4, 0x59, 0xf7, 0x73, 0xd3, 0x1c, 0xa6, 0x2a},
+			[SizeFp]byte{0x2b, 0xd6, 0x26, 0xa3, 0x63, 0x57, 0x4d, 0x82, 0xba, 0x20, 0xda, 0x50, 0x9e, 0xe3, 0x0a, 0x1a},
+		},
+	},
+	// 13G
+	{
+		addYX: Fq{
+			[SizeFp]byte{0xb9, 0xc3, 0xe4, 0x1e, 0x48, 0xc8, 0xd0, 0x32, 0xc6, 0x18, 0xdd, 0x9c, 0x10, 0x87, 0x36, 0x6c},
+			[SizeFp]byte{0xda, 0x95, 0xbf, 0x2f, 0x14, 0x17, 0x27, 0xe5, 0xc6, 0xe9, 0x2c, 0xb5, 0x1f, 0xa4, 0xbf, 0x67},
+		},
+		subYX: Fq{
+			[SizeFp]byte{0x74, 0x14, 0xa0, 0x88, 0xa0, 0xd6, 0x24, 0x4e, 0x26, 0x66, 0xfb, 0xe3, 0x0a, 0xca, 0xa6, 0x49},
+			[SizeFp]byte{0x1e, 0x19, 0x03, 0x91, 0xaa, 0x8f, 0x7f, 0xd6, 0x62, 0x30, 0x6d, 0xaa, 0xf5, 0x88, 0x48, 0x67},
+		},
+		dt2: Fq{
+			[SizeFp]byte{0x99, 0x5a, 0xe8, 0xc2, 0x24, 0x38, 0xa7, 0x4b, 0x14, 0xb3, 0x35, 0x8d, 0xd1, 0x2f, 0x6b, 0x40},
+			[SizeFp]byte{0xc1, 0x8a, 0x72, 0xea, 0x1b, 0x7b, 0x08, 0xa7, 0x0e, 0x16, 0x7b, 0x31, 0x22, 0xf2, 0xd2, 0x11},
+		},
+	},
+	// 15G
+	{
+		addYX: Fq{
+			[SizeFp]byte{0x69, 0xa4, 0x23, 0x7e, 0x00, 0x6e, 0x94, 0xf8, 0xa2, 0x31, 0xce, 0xbb, 0xfa, 0x96, 0xa1, 0x22},
+			[SizeFp]byte{0xba, 0x16, 0x12, 0xdc, 0x1b, 0xee, 0x09, 0x53, 0x24, 0xa3, 0x27, 0x38, 0x95, 0xe9, 0x0f, 0x24},
+		},
+		subYX: Fq{
+			[SizeFp]byte{0xc7, 0xb5, 0xae, 0x63, 0x9b, 0xb8, 0xfc, 0xf9, 0xb0, 0xb1, 0x16, 0xed, 0x49, 0x81, 0x3b, 0x60},
+			[SizeFp]byte{0xfb, 0x61, 0xcf, 0x02, 0x6c, 0x87, 0xf1, 0xb1, 0x8b, 0x94, 0x2f, 0x61, 0xaf, 0x32, 0x5e, 0x4a},
+		},
+		dt2: Fq{
+			[SizeFp]byte{0x13, 0x88, 0x9a, 0xe6, 0xed, 0x1a, 0x49, 0xfc, 0xa5, 0x3a, 0xe5, 0x36, 0x91, 0x37, 0xd9, 0x1a},
+			[SizeFp]byte{0x23, 0xc1, 0xe6, 0xd5, 0xb1, 0x0d, 0xa5, 0x5d, 0xca, 0x12, 0x2c, 0xfe, 0xf7, 0x14, 0x40, 0x2f},
+		},
+	},
+	// 17G
+	{
+		addYX: Fq{
+			[SizeFp]byte{0xf5, 0xc3, 0x85, 0x76, 0x1d, 0x79, 0xf6, 0xe4, 0x9b, 0x5a, 0x74, 0xc3, 0x21, 0x85, 0x21, 0x4c},
+			[SizeFp]byte{0x97, 0x5f, 0x55, 0x98, 0xaf, 0x21, 0x05, 0x0c, 0x7b, 0xda, 0xca, 0x53, 0x29, 0xa1, 0x62, 0x14},
+		},
+		subYX: Fq{
+			[SizeFp]byte{0x1b, 0x2c, 0x45, 0xd6, 0x63, 0xab, 0xb2, 0x0b, 0x87, 0xbb, 0x98, 0xec, 0x31, 0xc5, 0x83, 0x57},
+			[SizeFp]byte{0x9c, 0xbc, 0x5d, 0x60, 0x53, 0xef, 0x7d, 0x73, 0x19, 0x67, 0xe8, 0x30, 0xb9, 0x82, 0xf9, 0x49},
+		},
+		dt2: Fq{
+			[SizeFp]byte{0xe3, 0x11, 0x52, 0xcb, 0x90, 0x67, 0xb1, 0x75, 0x9e, 0xe9, 0xba, 0xcd, 0x74, 0x65, 0xad, 0x45},
+			[SizeFp]byte{0x51, 0x98, 0xec, 0xfe, 0x2d, 0xb7, 0x62, 0x10, 0x88, 0x8c, 0x46, 0xcc, 0x09, 0x9a, 0x02, 0x45},
+		},
+	},
+	// 19G
+	{
+		addYX: Fq{
+			[SizeFp]byte{0xf2, 0xa1, 0xf3, 0x77, 0xde, 0x40, 0x22, 0x53, 0xea, 0xd0, 0x9a, 0xaa, 0x1e, 0x29, 0xbd, 0x17},
+			[SizeFp]byte{0xa0, 0xa0, 0xf8, 0xc2, 0xef, 0xd7, 0xa2, 0xe0, 0x8e, 0x77, 0x21, 0x20, 0x05, 0x12, 0x74, 0x3a},
+		},
+		subYX: Fq{
+			[SizeFp]byte{0xdf, 0x90, 0xcc, 0x6a, 0x97, 0xb0, 0xdf, 0xb0, 0xf3, 0xb1, 0xa7, 0x89, 0xb6, 0x03, 0xd6, 0x7f},
+			[SizeFp]byte{0xc6, 0xd6, 0x00, 0xcb, 0x9c, 0x57, 0x52, 0x11, 0xa3, 0x49, 0x18, 0x63, 0x3b, 0x74, 0x40, 0x63},
+		},
+		dt2: Fq{
+			[SizeFp]byte{0x01, 0xda, 0x0c, 0x0e, 0x29, 0x47, 0xaa, 0xeb, 0x0b, 0xef, 0x3f, 0xd5, 0xa6, 0x65, 0x32, 0x14},
+			[SizeFp]byte{0x5a, 0xe7, 0x81, 0xd9, 0x6f, 0x5d, 0x32, 0x45, 0x2a, 0x6f, 0x58, 0x39, 0xcc, 0x80, 0x97, 0x0e},
+		},
+	},
+	// 21G
+	{
+		addYX: Fq{
+			[SizeFp]byte{0xdd, 0x28, 0x86, 0x7a, 0x20, 0x8d, 0xf6, 0xa4, 0x41, 0xe8, 0x93, 0x18, 0xb5, 0x30, 0xd2, 0x50},
+			[SizeFp]byte{0xb6, 0x04, 0xb5, 0x4b, 0x9a, 0x76, 0xbd, 0xf3, 0x2e, 0x29, 0x69, 0x39, 0x06, 0x5c, 0x97, 0x55},
+		},
+		subYX: Fq{
+			[SizeFp]byte{0x6f, 0x75, 0xb8, 0x5f, 0xa2, 0x7b, 0x72, 0x07, 0xfd, 0x31, 0xd7, 0x8e, 0xcf, 0x86, 0xff, 0x07},
+			[SizeFp]byte{0xf0, 0xa1, 0x35, 0xcc, 0x40, 0xfa, 0x57, 0xef, 0xfc, 0x18, 0x42, 0x87, 0x70, 0x3a, 0x75, 0x70},
+		},
+		dt2: Fq{
+			[SizeFp]byte{0x3c, 0x97, 0x2b, 0x34, 0xe2, 0x54, 0x59, 0x61, 0x86, 0xdf, 0x59, 0x1a, 0x8f, 0xd6, 0xa9, 0x5a},
+			[SizeFp]byte{0x68, 0x44, 0xe4, 0xf5, 0x9f, 0x9e, 0x8e, 0x3b, 0x23, 0x3d, 0x0a, 0xd6, 0x14, 0x91, 0x74, 0x2e},
+		},
+	},
+	// 23G
+	{
+		addYX: Fq{
+			[SizeFp]byte{0x4b, 0xdb, 0x76, 0xc1, 0x1e, 0xb9, 0xa1, 0x14, 0x6d, 0xae, 0x9a, 0xd6, 0x63, 0x1a, 0xf9, 0x55},
+			[SizeFp]byte{0x27, 0x6d, 0x1b, 0x7b, 0x32, 0x82, 0x23, 0xf4, 0xfd, 0xaa, 0xac, 0x5f, 0x47, 0x1f, 0xcf, 0x2a},
+		},
+		subYX: Fq{
+			[SizeFp]byte{0x68, 0x89, 0xb5, 0x79, 0xb4, 0x69, 0x90, 0xfd, 0x77, 0x5f, 0xa4, 0xc4, 0xe5, 0xf4, 0xaa, 0x3b},
+			[SizeFp]byte{0xb6, 0xaa, 0x7a, 0x8a, 0xb9, 0x9a, 0xac, 0xa2, 0x81, 0x09, 0xf5, 0x18, 0x50, 0xcb, 0x66, 0x54},
+		},
+		dt2: Fq{
+			[SizeFp]byte{0x05, 0x32, 0xba, 0x71, 0x77, 0xa2, 0x6b, 0x3e, 0xe4, 0xbb, 0x1b, 0xea, 0xcd, 0x90, 0xea, 0x31},
+			[SizeFp]byte{0x93, 0x73, 0x55, 0x5c, 0x6b, 0x41, 0x00, 0x00, 0x7d, 0x0d, 0x51, 0x5a, 0x41, 0xb0, 0x4c, 0x46},
+		},
+	},
+	// 25G
+	{
+		addYX: Fq{
+			[SizeFp]byte{0xbf, 0x2b, 0xff, 0x06, 0xd2, 0x87, 0x20, 0xd0, 0x36, 0xe7, 0xab, 0x7f, 0xcd, 0x8e, 0x9c, 0x2b},
+			[SizeFp]byte{0x0d, 0xab, 0xca, 0x42, 0x38, 0x6d, 0xb5, 0xb2, 0xa7, 0x00, 0x77, 0x76, 0xb7, 0xa0, 0x6e, 0x04},
+		},
+		subYX: Fq{
+			[SizeFp]byte{0x10, 0x73, 0x31, 0x9e, 0x88, 0x7a, 0x3a, 0x11, 0xca, 0xd0, 0xf7, 0xbe, 0x54, 0xa3, 0x92, 0x59},
+			[SizeFp]byte{0xbd, 0x88, 0x03, 0xd5, 0x4e, 0xa9, 0xdd, 0x3e, 0x54, 0x91, 0x83, 0x67, 0xf7, 0x61, 0x26, 0x05},
+		},
+		dt2: Fq{
+			[SizeFp]byte{0xe0, 0x28, 0x9e, 0xe1, 0xf6, 0xed, 0x28, 0x4c, 0xe5, 0x44, 0xf6, 0xd2, 0xf2, 0xc2, 0x19, 0x1d},
+			[SizeFp]byte{0x3d, 0xab, 0x35, 0xdb, 0x48, 0x21, 0x73, 0x5d, 0xf5, 0x80, 0x35, 0xb8, 0x14, 0x47, 0x0c, 0x68},
+		},
+	},
+	// 27G
+	{
+		addYX: Fq{
+			[SizeFp]byte{0xec, 0xcc, 0x80, 0xbb, 0x82, 0xf2, 0x74, 0xa3, 0x1c, 0xe1, 0x7a, 0xc7, 0x9b, 0x60, 0x9e, 0x78},
+			[SizeFp]byte{0xf2, 0x45, 0x9b, 0x59, 0x7d, 0x57, 0xd2, 0x10, 0xb1, 0x21, 0x77, 0x85, 0x5b, 0x8b, 0x54, 0x1c},
+		},
+		subYX: Fq{
+			[SizeFp]byte{0xdf, 0x3f, 0x54, 0xb4, 0x26, 0xa7, 0xae, 0x7b, 0xd2, 0x4e, 0x1b, 0x2d, 0x91, 0x62, 0x15, 0x3c},
+			[SizeFp]byte{0x82, 0x20, 0xe8, 0xb7, 0x03, 0x22, 0x36, 0xd6, 0x00, 0xa9, 0xc7, 0xd3, 0x23, 0xe5, 0x14, 0x14},
+		},
+		dt2: Fq{
+			[SizeFp]byte{0xa9, 0x23, 0x1d, 0x1c, 0x95, 0x49, 0xa3, 0x7c, 0xb4, 0x0f, 0xe8, 0x3c, 0x5e, 0x26, 0xa4, 0x4d},
+			[SizeFp]byte{0x36, 0xef, 0xa9, 0xac, 0xbc, 0xeb, 0x81, 0x79, 0x0b, 0x98, 0xbf, 0xb5, 0xe5, 0xc9, 0xba, 0x4e},
+		},
+	},
+	// 29G
+	{
+		addYX: Fq{
+			[SizeFp]byte{0xa4, 0xb5, 0x9c, 0xf4, 0xdc, 0xc1, 0xd2, 0xab, 0x0f, 0x34, 0xc6, 0x25, 0xfc, 0xac, 0x54, 0x3f},
+			[SizeFp]byte{0xbd, 0x1c, 0xd1, 0xbb, 0xfa, 0xef, 0x2e, 0x20, 0x8c, 0x5e, 0x69, 0xb3, 0x7c, 0x6b, 0x21, 0x67},
+		},
+		subYX: Fq{
+			[SizeFp]byte{0xf1, 0xc9, 0x3f, 0xb2, 0xf9, 0xbc, 0x7c, 0xff, 0xfb, 0x7a, 0xfa, 0xf7, 0xdf, 0xeb, 0xeb, 0x2e},
+			[SizeFp]byte{0x5e, 0xf8, 0x11, 0xa1, 0xef, 0x6b, 0x15, 0x71, 0x2c, 0x90, 0x22, 0xf5, 0x8d, 0xd9, 0x8f, 0x1b},
+		},
+		dt2: Fq{
+			[SizeFp]byte{0x91, 0x97, 0x51, 0x62, 0xad, 0xeb, 0x28, 0x6b, 0xed, 0xd8, 0x01, 0x0e, 0x96, 0xea, 0xf0, 0x6c},
+			[SizeFp]byte{0xd5, 0x67, 0x69, 0x00, 0xc2, 0x7b, 0x61, 0xb4, 0xad, 0xf0, 0x3d, 0xcb, 0x65, 0xa0, 0x3d, 0x32},
+		},
+	},
+	// 31G
+	{
+		addYX: Fq{
+			[SizeFp]byte{0x9c, 0x4d, 0xe2, 0x41, 0x07, 0x7d, 0x68, 0x31, 0xc2, 0x7c, 0x9a, 0x50, 0x2b, 0x8f, 0xdb, 0x02},
+			[SizeFp]byte{0x27, 0x05, 0x32, 0x24, 0x59, 0xf8, 0x43, 0x92, 0x2b, 0x6d, 0x6e, 0x1d, 0xf0, 0x60, 0xc3, 0x68},
+		},
+		subYX: Fq{
+			[SizeFp]byte{0x6a, 0x30, 0xd5, 0x77, 0xe8, 0xc5, 0x51, 0x23, 0xa9, 0xf3, 0xc5, 0x85, 0xfc, 0xcc, 0x56, 0x6f},
+			[SizeFp]byte{0x8f, 0x92, 0xc4, 0x37, 0x28, 0x65, 0x09, 0x1b, 0x71, 0xf9, 0x83, 0x4c, 0x55, 0x37, 0x33, 0x0b},
+		},
+		dt2: Fq{
+			[SizeFp]byte{0xec, 0x83, 0xc7, 0xcc, 0xe2, 0x1b, 0x93, 0xe2, 0x4f, 0xc6, 0x08, 0xba, 0x94, 0x96, 0x82, 0x46},
+			[SizeFp]byte{0xac, 0xc6, 0xe2, 0x58, 0x63, 0xe3, 0x35, 0x9f, 0x70, 0xd1, 0x00, 0xb0, 0x33, 0xb3, 0x74, 0x14},
+		},
+	},
+	// 33G
+	{
+		addYX: Fq{
+			[SizeFp]byte{0x40, 0x66, 0xc9, 0x6f, 0x75, 0x92, 0xd7, 0x24, 0x5e, 0x8c, 0x86, 0xef, 0x9f, 0xda, 0x8f, 0x61},
+			[SizeFp]byte{0x75, 0x93, 0xfd, 0x5a, 0x12, 0x5b, 0xff, 0xb7, 0x58, 0xc2, 0x40, 0x04, 0x7e, 0xd9, 0x8d, 0x77},
+		},
+		subYX: Fq{
+			[SizeFp]byte{0x27, 0x96, 0x21, 0x86, 0x48, 0x31, 0xff, 0xfb, 0x11, 0xe8, 0xa7, 0xe2, 0xe1, 0xe1, 0x17, 0x34},
+			[SizeFp]byte{0xdc, 0x7b, 0x7b, 0x8f, 0xa8, 0x59, 0xe9, 0x21, 0x72, 0x86, 0x3c, 0x8c, 0xeb, 0xc2, 0x08, 0x35},
+		},
+		dt2: Fq{
+			[SizeFp]byte{0x0f, 0x43, 0x1c, 0x11, 0xde, 0xcd, 0x7e, 0x82, 0x34, 0xa1, 0x7a, 0xb0, 0x9f, 0xb1, 0xbc, 0x21},
+			[SizeFp]byte{0x46, 0x57, 0x2f, 0xab, 0x50, 0xfa, 0xc1, 0xe0, 0xfa, 0x58, 0x66, 0x4e, 0x0b, 0x68, 0x1e, 0x40},
+		},
+	},
+	// 35G
+	{
+		addYX: Fq{
+			[SizeFp]byte{0xcc, 0x93, 0x36, 0x31, 0xab, 0x4b, 0xc2, 0x2c, 0x7a, 0x44, 0x64, 0xb9, 0x12, 0x1c, 0x54, 0x20},
+			[SizeFp]byte{0xcc, 0xc3, 0x81, 0xfb, 0xb6, 0x75, 0x49, 0x37, 0xf7, 0x17, 0x4e, 0x34, 0xfb, 0x5e, 0x90, 0x52},
+		},
+		subYX: Fq{
+			[SizeFp]byte{0x9e, 0x5f, 0x8b, 0x6d, 0xb5, 0xc9, 0xc5, 0x79, 0xec, 0xa3, 0xb9, 0xd2, 0x75, 0xbf, 0x90, 0x33},
+			[SizeFp]byte{0xe4, 0xf4, 0x5b, 0x89, 0x7d, 0x80, 0xf3, 0x7e, 0x51, 0x6b, 0x04, 0x42, 0x5a, 0x16, 0x14, 0x28},
+		},
+		dt2: Fq{
+			[SizeFp]byte{0x58, 0xe1, 0x6f, 0x32, 0x09, 0xfd, 0x8c, 0x7f, 0xec, 0x62, 0x97, 0x4c, 0x4f, 0xfb, 0x32, 0x32},
+			[SizeFp]byte{0x25, 0x4d, 0x19, 0xcc, 0xda, 0xd6, 0x78, 0x56, 0xe8, 0x45, 0x75, 0x0a, 0xfb, 0xaf, 0x7c, 0x6f},
+		},
+	},
+	// 37G
+	{
+		addYX: Fq{
+			[SizeFp]byte{0x63, 0x79, 0x3e, 0xb2, 0x37, 0x16, 0x98, 0xbd, 0xf5, 0x0e, 0x8a, 0xb8, 0x7c, 0x7b, 0x1d, 0x69},
+			[SizeFp]byte{0x14, 0x29, 0x06, 0xe2, 0x9a, 0x31, 0xba, 0x10, 0x5b, 0xa8, 0x95, 0x82, 0x4f, 0x14, 0xfb, 0x06},
+		},
+		subYX: Fq{
+			[SizeFp]byte{0x8f, 0x2f, 0xf6, 0x6b, 0x97, 0x20, 0xe6, 0x80, 0xb4, 0xd6, 0x73, 0xec, 0x71, 0x59, 0x42, 0x2a},
+			[SizeFp]byte{0x1c, 0x0b, 0xd1, 0x41, 0xe7, 0xa9, 0x0a, 0x80, 0x9b, 0x46, 0xa0, 0xd1, 0x8b, 0x7d, 0x0d, 0x23},
+		},
+		dt2: Fq{
+			[SizeFp]byte{0x8c, 0xfe, 0x8d, 0x42, 0x37, 0xce, 0xaa, 0x65, 0x67, 0xb6, 0x58, 0x7f, 0x29, 0xb5, 0xca, 0x0f},
+			[SizeFp]byte{0xb8, 0xf7, 0x3a, 0x94, 0x26, 0x95, 0x0e, 0xcf, 0xe7, 0xda, 0xd4, 0x75, 0x5b, 0x91, 0x90, 0x7d},
+		},
+	},
+	// 39G
+	{
+		addYX: Fq{
+			[SizeFp]byte{0x6b, 0x9d, 0x25, 0x56, 0x61, 0xa4, 0x55, 0x74, 0xb5, 0xe1, 0xcc, 0x74, 0x63, 0xc0, 0xbc, 0x29},
+			[SizeFp]byte{0xfd, 0xae, 0x87, 0xaa, 0xd3, 0x0e, 0xfb, 0xf2, 0x58, 0xdd, 0x54, 0x0e, 0xaf, 0x06, 0x1a, 0x21},
+		},
+		subYX: Fq{
+			[SizeFp]byte{0xbc, 0xe9, 0x3d, 0x72, 0xc5, 0x95, 0x0c, 0x6c, 0xa7, 0x8c, 0x00, 0x25, 0xed, 0xb6, 0x99, 0x62},
+			[SizeFp]byte{0x18, 0xfb, 0x4d, 0x4d, 0x78, 0x3e, 0xd6, 0x7f, 0x30, 0xdb, 0xc1, 0x9b, 0x4d, 0x3b, 0xc9, 0x2c},
+		},
+		dt2: Fq{
+			[SizeFp]byte{0xea, 0x13, 0x5d, 0x4c, 0xd4, 0xe2, 0xc7, 0xeb, 0xa0, 0x11, 0x3d, 0x4d, 0x8d, 0xe1, 0x78, 0x32},
+			[SizeFp]byte{0x79, 0x5f, 0x21, 0x5a, 0xd2, 0x3d, 0x9e, 0x34, 0x6d, 0x41, 0x30, 0x0b, 0x15, 0xa7, 0xb2, 0x7e},
+		},
+	},
+	// 41G
+	{
+		addYX: Fq{
+			[SizeFp]byte{0xcb, 0x94, 0xa0, 0xf6, 0xd5, 0xd7, 0xf3, 0x05, 0x05, 0x14, 0x33, 0x8e, 0xd4, 0x71, 0x37, 0x2a},
+			[SizeFp]byte{0x09, 0xf0, 0x96, 0xdc, 0xe9, 0x39, 0xef, 0x08, 0x92, 0x49, 0x36, 0x3a, 0x37, 0x48, 0x22, 0x01},
+		},
+		subYX: Fq{
+			[SizeFp]byte{0x33, 0x4d, 0xfd, 0xc9, 0x2f, 0xf9, 0x58, 0xf7, 0x6c, 0xca, 0xd3, 0xdf, 0xc6, 0xd8, 0x39, 0x23},
+			[SizeFp]byte{0xb4, 0x73, 0x26, 0x96, 0x65, 0x09, 0x00, 0x8b, 0x54, 0x90, 0x9d, 0xb9, 0x3e, 0xf4, 0x6f, 0x74},
+		},
+		dt2: Fq{
+			[SizeFp]byte{0xff, 0x2e, 0x42, 0x4a, 0x05, 0xdc, 0xec, 0x47, 0x0c, 0x7f, 0x7b, 0x26, 0xc8, 0xf7, 0xd8, 0x33},
+			[SizeFp]byte{0xae, 0x42, 0x1a, 0x92, 0xac, 0x00, 0xfe, 0x22, 0xe6, 0xd8, 0xfc, 0x31, 0x3d, 0x7f, 0xe5, 0x31},
+		},
+	},
+	// 43G
+	{
+		addYX: Fq{
+			[SizeFp]byte{0x69, 0x08, 0xc5, 0xa1, 0x15, 0x23, 0x91, 0xbb, 0xaf, 0xbb, 0x7e, 0xfa, 0xb0, 0xcd, 0xc8, 0x4a},
+			[SizeFp]byte{0xdf, 0x3e, 0x97, 0x60, 0x4a, 0xd7, 0x41, 0x05, 0xd7, 0xc5, 0xb2, 0x34, 0x03, 0x90, 0x34, 0x72},
+		},
+		subYX: Fq{
+			[SizeFp]byte{0x33, 0xfa, 0xad, 0x30, 0xf7, 0x45, 0xe5, 0xf2, 0x96, 0xac, 0xb5, 0x3d, 0xe6, 0x44, 0x4e, 0x22},
+			[SizeFp]byte{0xb9, 0xde, 0x6f, 0x5c, 0x00, 0x3d, 0xba, 0xfc, 0xb5, 0x36, 0x99, 0x55, 0xe6, 0xa4, 0x93, 0x2c},
+		},
+		dt2: Fq{
+			[SizeFp]byte{0x58, 0xd7, 0x88, 0xad, 0xd7, 0xa0, 0x27, 0x77, 0xdd, 0x9c, 0x71, 0x16, 0x02, 0x10, 0x33, 0x2e},
+			[SizeFp]byte{0x54, 0x02, 0x2c, 0xeb, 0x9a, 0xf8, 0x2e, 0x7b, 0xb4, 0xaf, 0x58, 0x47, 0xb7, 0xe5, 0x6d, 0x1f},
+		},
+	},
+	// 45G
+	{
+		addYX: Fq{
+			[SizeFp]byte{0x21, 0xb3, 0x4f, 0x11, 0x47, 0x90, 0xe8, 0x6a, 0x0d, 0xd8, 0xc6, 0x6e, 0x9a, 0x5e, 0x60, 0x3d},
+			[SizeFp]byte{0xd8, 0x74, 0xa8, 0x27, 0xc7, 0x15, 0xe9, 0x18, 0x2f, 0x91, 0xd0, 0xe9, 0xb5, 0x88, 0x90, 0x69},
+		},
+		subYX: Fq{
+			[SizeFp]byte{0x10, 0x6f, 0x05, 0x8e, 0x61, 0x44, 0x93, 0xaf, 0xb3, 0xe0, 0x45, 0x82, 0xdf, 0x69, 0x91, 0x1b},
+			[SizeFp]byte{0x91, 0xc8, 0xf4, 0x70, 0x3d, 0xc3, 0xb8, 0x5e, 0xc3, 0x13, 0x2b, 0x22, 0xfb, 0xdd, 0x09, 0x16},
+		},
+		dt2: Fq{
+			[SizeFp]byte{0xed, 0x66, 0xb3, 0xd1, 0x85, 0xc8, 0x31, 0x81, 0xb0, 0xa7, 0xb1, 0x9c, 0x9d, 0xcf, 0xc3, 0x7b},
+			[SizeFp]byte{0x68, 0x39, 0xc9, 0x2f, 0x8d, 0x47, 0x97, 0xd2, 0xf5, 0xa7, 0x4e, 0x3a, 0x57, 0xb4, 0xcb, 0x13},
+		},
+	},
+	// 47G
+	{
+		addYX: Fq{
+			[SizeFp]byte{0x6b, 0x98, 0xd5, 0x64, 0xcc, 0xb5, 0x37, 0xdd, 0xdc, 0xb5, 0x1a, 0xd8, 0xd7, 0xd1, 0xd3, 0x7e},
+			[SizeFp]byte{0x9e, 0x3c, 0x97, 0x23, 0x5f, 0x48, 0x53, 0xac, 0xd7, 0x91, 0x3b, 0x33, 0x5d, 0x67, 0x05, 0x07},
+		},
+		subYX: Fq{
+			[SizeFp]byte{0xc1, 0x86, 0x31, 0xc4, 0x13, 0xd2, 0xe5, 0xad, 0x14, 0xdf, 0xbf, 0xb4, 0x57, 0xdf, 0x8b, 0x6a},
+			[SizeFp]byte{0x63, 0x79, 0x71, 0xde, 0xa1, 0x88, 0x7f, 0xa8, 0xe2, 0xbc, 0x19, 0xb5, 0x20, 0x92, 0xf2, 0x17},
+		},
+		dt2: Fq{
+			[SizeFp]byte{0x10, 0xc6, 0x95, 0x0f, 0xfb, 0xd7, 0xf2, 0x7a, 0x7c, 0x4a, 0x14, 0x3b, 0x92, 0xd3, 0xd1, 0x28},
+			[SizeFp]byte{0xe1, 0x13, 0x28, 0x97, 0xd8, 0xc3, 0x73, 0x8e, 0xc1, 0x72, 0x2e, 0xc6, 0x40, 0x0b, 0x10, 0x00},
+		},
+	},
+	// 49G
+	{
+		addYX: Fq{
+			[SizeFp]byte{0xda, 0x50, 0x1f, 0xfa, 0x81, 0x7a, 0xde, 0x84, 0x44, 0x82, 0x9d, 0x58, 0xd6, 0x91, 0xa3, 0x4f},
+			[SizeFp]byte{0x85, 0xb2, 0x34, 0x08, 0x6f, 0x59, 0xc3, 0xbc, 0xce, 0xe9, 0x24, 0x0a, 0xd6, 0xcb, 0x4a, 0x4d},
+		},
+		subYX: Fq{
+			[SizeFp]byte{0x0d, 0x5a, 0x83, 0xc1, 0xb8, 0x98, 0xfa, 0x97, 0x0b, 0x1d, 0x90, 0x29, 0x8e, 0xcf, 0xab, 0x33},
+			[SizeFp]byte{0x82, 0xd0, 0xb3, 0x75, 0x19, 0x3d, 0xa7, 0x60, 0x8d, 0x94, 0x5b, 0x32, 0xa4, 0x6a, 0x66, 0x60},
+		},
+		dt2: Fq{
+			[SizeFp]byte{0x39, 0x4a, 0x28, 0x69, 0xb7, 0xad, 0x54, 0xad, 0x28, 0x9b, 0x60, 0x13, 0xd1, 0x98, 0x7a, 0x22},
+			[SizeFp]byte{0x72, 0x38, 0x6a, 0xae, 0xfc, 0x1f, 0x1e, 0x4a, 0x8c, 0x81, 0x7f, 0xd6, 0x4b, 0xe4, 0x4e, 0x1e},
+		},
+	},
+	// 51G
+	{
+		addYX: Fq{
+			[SizeFp]byte{0x15, 0xd3, 0x87, 0x43, 0xbb, 0xc6, 0x74, 0x5a, 0x95, 0x87, 0xb1, 0xb1, 0xc0, 0x28, 0x94, 0x01},
+			[SizeFp]byte{0x55, 0xb0, 0xbb, 0x70, 0xe2, 0x53, 0xc1, 0x5c, 0x61, 0x4a, 0xdc, 0x00, 0xdf, 0xab, 0x3c, 0x2b},
+		},
+		subYX: Fq{
+			[SizeFp]byte{0x57, 0x4b, 0x92, 0x26, 0xc0, 0x10, 0x41, 0x83, 0x17, 0xf2, 0xd9, 0xf2, 0x85, 0xe9, 0x30, 0x2d},
+			[SizeFp]byte{0xf5, 0x89, 0x33, 0x33, 0x79, 0x69, 0x11, 0x47, 0x17, 0x24, 0x20, 0x18, 0x6a, 0xfd, 0xe3, 0x53},
+		},
+		dt2: Fq{
+			[SizeFp]byte{0x64, 0x58, 0x2e, 0x9c, 0xd7, 0x3c, 0x39, 0xb1, 0x82, 0x2e, 0x11, 0xe4, 0x35, 0x29, 0xd9, 0x58},
+			[SizeFp]byte{0x6d, 0x5b, 0x30, 0xc8, 0x7e, 0x9a, 0x98, 0x86, 0x7a, 0xf3, 0x28, 0xee, 0x4e, 0xfe, 0xa8, 0x42},
+		},
+	},
+	// 53G
+	{
+		addYX: Fq{
+			[SizeFp]byte{0x01, 0x19, 0x59, 0x01, 0xef, 0x12, 0xe2, 0x74, 0xb9, 0xb1, 0x97, 0x03, 0x7a, 0x91, 0x77, 0x32},
+			[SizeFp]byte{0x44, 0x75, 0x68, 0x3d, 0x6e, 0xbe, 0xbc, 0x7b, 0xb6, 0xaf, 0x09, 0x1d, 0x70, 0x57, 0x89, 0x0b},
+		},
+		subYX: Fq{
+			[SizeFp]byte{0x68, 0x36, 0x50, 0x74, 0xee, 0xc8, 0xfb, 0x6c, 0x48, 0x83, 0x9f, 0xda, 0x5a, 0x92, 0xa9, 0x48},
+			[SizeFp]byte{0x4e, 0x0f, 0x2d, 0xba, 0x53, 0x57, 0x04, 0x57, 0x66, 0x3d, 0x22, 0x66, 0x38, 0xca, 0x69, 0x7d},
+		},
+		dt2: Fq{
+			[SizeFp]byte{0x1f, 0x27, 0x17, 0x29, 0xe2, 0x4c, 0x05, 0xc7, 0xde, 0x51, 0x3b, 0x13, 0xe1, 0xe1, 0xbc, 0x41},
+			[SizeFp]byte{0x5e, 0xc3, 0x1e, 0xf8, 0x2d, 0xe4, 0x3a, 0x3a, 0xc3, 0x7c, 0xd4, 0x42, 0x0f, 0xda, 0xaa, 0x7e},
+		},
+	},
+	// 55G
+	{
+		addYX: Fq{
+			[SizeFp]byte{0xcc, 0x57, 0x8a, 0x04, 0xf1, 0x38, 0xb1, 0x13, 0x8f, 0x5a, 0x91, 0x7e, 0xbd, 0x8a, 0xf9, 0x64},
+			[SizeFp]byte{0x32, 0xc7, 0xa0, 0x16, 0xeb, 0x95, 0xf1, 0x7a, 0xd2, 0x34, 0xd6, 0x91, 0xa7, 0x81, 0xbe, 0x11},
+		},
+		subYX: Fq{
+			[SizeFp]byte{0xb8, 0x61, 0x0f, 0x43, 0x47, 0xdf, 0xd8, 0x97, 0x04, 0x10, 0x27, 0x81, 0xb3, 0xc7, 0x67, 0x07},
+			[SizeFp]byte{0xa6, 0x0a, 0x94, 0xfb, 0x36, 0x91, 0x94, 0x3e, 0xba, 0x6d, 0x95, 0xcd, 0x40, 0xe3, 0xde, 0x3b},
+		},
+		dt2: Fq{
+			[SizeFp]byte{0x02, 0x26, 0x1d, 0xf9, 0x4f, 0xec, 0x50, 0xb2, 0xdb, 0x59, 0x7f, 0xd4, 0x54, 0x24, 0xde, 0x4c},
+			[SizeFp]byte{0xcb, 0x78, 0xd9, 0x30, 0x95, 0x74, 0x5e, 0xaf, 0x35, 0xd8, 0xd4, 0x19, 0x21, 0x2f, 0x8e, 0x5a},
+		},
+	},
+	// 57G
+	{
+		addYX: Fq{
+			[SizeFp]byte{0xdf, 0x44, 0x07, 0x5a, 0x42, 0xb5, 0x1c, 0xdf, 0x55, 0xd0, 0x35, 0xbf, 0xa7, 0x08, 0x3b, 0x3d},
+			[SizeFp]byte{0x9c, 0x71, 0xe4, 0x2d, 0x83, 0x5e, 0x33, 0xc6, 0x42, 0x4d, 0x15, 0x09, 0x7e, 0xd9, 0xb8, 0x6e},
+		},
+		subYX: Fq{
+			[SizeFp]byte{0xd9, 0x0d, 0xd2, 0xe3, 0x8d, 0x3f, 0x6a, 0x2f, 0xda, 0x33, 0x62, 0x27, 0xfd, 0x3c, 0xf2, 0x13},
+			[SizeFp]byte{0x1c, 0xa4, 0x0f, 0xfc, 0x0d, 0xb8, 0xa6, 0xb4, 0xd7, 0xd7, 0xcf, 0x3a, 0x40, 0x76, 0xd8, 0x58},
+		},
+		dt2: Fq{
+			[SizeFp]byte{0x9b, 0x13, 0x8e, 0x8b, 0x07, 0x22, 0xd4, 0x2a, 0x4d, 0x49, 0xaf, 0xbb, 0x2a, 0xee, 0xdb, 0x73},
+			[SizeFp]byte{0xc8, 0xa3, 0xec, 0x91, 0x88, 0x75, 0xa2, 0x09, 0x38, 0x09, 0x8b, 0x17, 0xf1, 0xa9, 0xf9, 0x6e},
+		},
+	},
+	// 59G
+	{
+		addYX: Fq{
+			[SizeFp]byte{0xda, 0x37, 0xc6, 0x90, 0xcb, 0x9e, 0x7e, 0xfc, 0x7c, 0x1a, 0x0b, 0xc1, 0x5f, 0x34, 0x04, 0x3a},
+			[SizeFp]byte{0x1f, 0xff, 0xf9, 0x62, 0xcb, 0xe9, 0x24, 0xc0, 0xd8, 0x33, 0xaa, 0xa4, 0x3a, 0x9c, 0x4f, 0x6c},
+		},
+		subYX: Fq{
+			[SizeFp]byte{0xf0, 0xc1, 0x5a, 0xb9, 0x95, 0x69, 0x9d, 0x04, 0x1b, 0x3a, 0x76, 0x95, 0x51, 0x84, 0x43, 0x22},
+			[SizeFp]byte{0x76, 0xc2, 0x0a, 0x70, 0x31, 0x6a, 0x46, 0xa1, 0x05, 0x59, 0x32, 0x3a, 0x12, 0xb7, 0x0f, 0x60},
+		},
+		dt2: Fq{
+			[SizeFp]byte{0x24, 0x5a, 0xd3, 0xa0, 0x64, 0x1a, 0x39, 0x9d, 0x08, 0xf1, 0x41, 0x06, 0x55, 0x3b, 0x09, 0x3b},
+			[SizeFp]byte{0x1f, 0x22, 0x2e, 0xfd, 0x5b, 0xde, 0x75, 0x22, 0x1e, 0xdb, 0x63, 0x59, 0x46, 0xe7, 0xf5, 0x25},
+		},
+	},
+	// 61G
+	{
+		addYX: Fq{
+			[SizeFp]byte{0x84, 0xfb, 0xe7, 0xf7, 0x07, 0x01, 0x22, 0x3e, 0x8e, 0x5a, 0xb8, 0xc1, 0x3b, 0xa2, 0x06, 0x6f},
+			[SizeFp]byte{0x48, 0x0e, 0xeb, 0xf6, 0x19, 0x8d, 0x19, 0xb4, 0xda, 0x5f, 0xd4, 0xda, 0x61, 0x17, 0xc1, 0x5d},
+		},
+		subYX: Fq{
+			[SizeFp]byte{0x0d, 0x2a, 0xb5, 0x2a, 0x49, 0x3e, 0x30, 0xba, 0x28, 0xf5, 0xa9, 0x3d, 0xc7, 0x69, 0x7c, 0x12},
+			[SizeFp]byte{0xbe, 0x90, 0xc7, 0xf6, 0x0c, 0xb7, 0xa5, 0xd3, 0x5c, 0xda, 0x19, 0x08, 0xc5, 0xb0, 0x72, 0x0d},
+		},
+		dt2: Fq{
+			[SizeFp]byte{0xf7, 0xcd, 0xc2, 0x2e, 0xd6, 0x90, 0x3f, 0x19, 0xaf, 0x6d, 0xf4, 0xc4, 0xcf, 0xd0, 0xf7, 0x67},
+			[SizeFp]byte{0xea, 0x80, 0xf3, 0x52, 0x3d, 0x08, 0xec, 0x7a, 0x4d, 0xbf, 0x28, 0x4a, 0xda, 0x1d, 0x0a, 0x7c},
+		},
+	},
+	// 63G
+	{
+		addYX: Fq{
+			[SizeFp]byte{0xa7, 0xcb, 0x08, 0x60, 0xfe, 0x20, 0xfd, 0x46, 0x95, 0xd5, 0x15, 0x41, 0x91, 0x8c, 0x58, 0x7a},
+			[SizeFp]byte{0x78, 0x5f, 0xf4, 0xec, 0xda, 0xd3, 0xb1, 0x8f, 0x36, 0xb0, 0xe7, 0x94, 0xc0, 0xda, 0x51, 0x08},
+		},
+		subYX: Fq{
+			[SizeFp]byte{0x92, 0xa8, 0x32, 0x2a, 0x6e, 0xa7, 0xe0, 0xca, 0x2f, 0xdb, 0xdd, 0x22, 0x13, 0x86, 0x4f, 0x10},
+			[SizeFp]byte{0x06, 0x90, 0x1f, 0x6e, 0xe4, 0x81, 0x9d, 0xb7, 0x12, 0x89, 0x49, 0xa2, 0xd7, 0x28, 0x4d, 0x1e},
+		},
+		dt2: Fq{
+			[SizeFp]byte{0xbf, 0x89, 0x4b, 0x97, 0xd3, 0x75, 0x31, 0xaf, 0xc2, 0x55, 0x9c, 0xa6, 0xf9, 0x00, 0x3d, 0x61},
+			[SizeFp]byte{0x6f, 0x22, 0x65, 0x8e, 0x3e, 0x88, 0xf6, 0x23, 0x05, 0xef, 0x6d, 0x5c, 0xd6, 0x7e, 0x2f, 0x07},
+		},
+	},
+}
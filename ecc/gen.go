@@ -0,0 +1,86 @@
+// +build ignore
+
+// Autogenerates the code shared by the NIST curves from templates to prevent
+// too much duplicated code between the packages of the different curves.
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"text/template"
+)
+
+type Instance struct {
+	Name    string
+	Bits    int
+	Size    int    // Size in bytes of field elements and scalars.
+	Section string // Section of FIPS 186-3 defining the curve.
+	// OwnCurve is set when the package implements the Curve interface by
+	// itself instead of using templates/curve.templ.go.
+	OwnCurve bool
+	// OddMultiplesTests is the number of random points in TestOddMultiples,
+	// which is slow for large fields.
+	OddMultiplesTests int
+}
+
+func (m Instance) Pkg() string {
+	return strings.ToLower(m.Name)
+}
+
+var (
+	Instances = []Instance{
+		{Name: "P256", Bits: 256, Size: 32, Section: "D.2.3", OddMultiplesTests: 32},
+		{Name: "P384", Bits: 384, Size: 48, Section: "D.2.4", OwnCurve: true, OddMultiplesTests: 32},
+		{Name: "P521", Bits: 521, Size: 66, Section: "D.2.5", OddMultiplesTests: 4},
+	}
+	TemplateWarning = "// Code generated from"
+)
+
+func main() {
+	generatePackageFiles("point.templ.go", "point.go", false)
+	generatePackageFiles("point_test.templ.go", "point_test.go", false)
+	generatePackageFiles("scalarmult.templ.go", "scalarmult.go", true)
+	generatePackageFiles("api_test.templ.go", "api_test.go", false)
+	generatePackageFiles("curve_test.templ.go", "{{.Pkg}}_test.go", false)
+	generatePackageFiles("curve.templ.go", "{{.Pkg}}.go", true)
+}
+
+// Generates instance/out from templates/in. If curveOnly is set, instances
+// with their own implementation of the Curve interface are skipped.
+func generatePackageFiles(in, out string, curveOnly bool) {
+	tl, err := template.ParseFiles("templates/" + in)
+	if err != nil {
+		panic(err)
+	}
+	name, err := template.New("name").Parse(out)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, curve := range Instances {
+		if curveOnly && curve.OwnCurve {
+			continue
+		}
+		buf := new(bytes.Buffer)
+		err := tl.Execute(buf, curve)
+		if err != nil {
+			panic(err)
+		}
+
+		res := buf.String()
+		offset := strings.Index(res, TemplateWarning)
+		if offset == -1 {
+			panic("Missing template warning in " + in)
+		}
+
+		fileName := new(bytes.Buffer)
+		if err := name.Execute(fileName, curve); err != nil {
+			panic(err)
+		}
+		err = ioutil.WriteFile(curve.Pkg()+"/"+fileName.String(), []byte(res[offset:]), 0644)
+		if err != nil {
+			panic(err)
+		}
+	}
+}
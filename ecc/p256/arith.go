@@ -0,0 +1,150 @@
+package p256
+
+import (
+	"math/big"
+	"math/bits"
+
+	"github.com/cloudflare/circl/internal/conv"
+)
+
+const (
+	// sizeFp is the length in bytes of field elements and scalars.
+	sizeFp = 32
+	// numWords is the number of 64-bit words of a field element.
+	numWords = 4
+)
+
+// fp256 is a prime field element stored as little-endian 64-bit words.
+type fp256 [numWords]uint64
+
+func (e fp256) BigInt() *big.Int { return conv.Uint64Le2BigInt(e[:]) }
+func (e fp256) String() string   { return "0x" + e.BigInt().Text(16) }
+
+func (e *fp256) SetBigInt(b *big.Int) {
+	if b.BitLen() > 256 || b.Sign() < 0 {
+		b = new(big.Int).Mod(b, p.BigInt())
+	}
+	conv.BigInt2Uint64Le(e[:], b)
+}
+
+func montEncode(c, a *fp256) { fp256Mul(c, a, &r2) }
+func montDecode(c, a *fp256) { fp256Mul(c, a, &fp256{1}) }
+func fp256Sqr(c, a *fp256)   { fp256Mul(c, a, a) }
+
+// fp256Inv calculates z = x^(p-2) using Fermat's little theorem. The exponent
+// is public, so the sequence of operations does not depend on x.
+func fp256Inv(z, x *fp256) {
+	t := &fp256{}
+	montEncode(t, &fp256{1})
+	for i := numWords*64 - 1; i >= 0; i-- {
+		fp256Sqr(t, t)
+		if (pMinus2[i/64]>>uint(i%64))&1 == 1 {
+			fp256Mul(t, t, x)
+		}
+	}
+	*z = *t
+}
+
+// fp256Cmov sets x to y if b != 0.
+func fp256Cmov(x, y *fp256, b int) {
+	mask := -(uint64(b|-b) >> 63)
+	for i := range x {
+		x[i] = (x[i] &^ mask) | (y[i] & mask)
+	}
+}
+
+func fp256Neg(c, a *fp256) { fp256Sub(c, &fp256{}, a) }
+
+func fp256Add(c, a, b *fp256) {
+	var t, z fp256
+	var carry, borrow uint64
+	for i := range t {
+		t[i], carry = bits.Add64(a[i], b[i], carry)
+	}
+	for i := range z {
+		z[i], borrow = bits.Sub64(t[i], p[i], borrow)
+	}
+	_, borrow = bits.Sub64(carry, 0, borrow)
+	fp256Cmov(&z, &t, int(borrow))
+	*c = z
+}
+
+func fp256Sub(c, a, b *fp256) {
+	var t fp256
+	var borrow, carry uint64
+	for i := range t {
+		t[i], borrow = bits.Sub64(a[i], b[i], borrow)
+	}
+	mask := -borrow
+	for i := range t {
+		t[i], carry = bits.Add64(t[i], p[i]&mask, carry)
+	}
+	*c = t
+}
+
+// fp256Mul calculates c = a*b/R mod p using the CIOS method of Montgomery
+// multiplication.
+func fp256Mul(c, a, b *fp256) {
+	var t [numWords + 2]uint64
+	var hi, lo, cc, carry uint64
+	for i := 0; i < numWords; i++ {
+		carry = 0
+		for j := 0; j < numWords; j++ {
+			hi, lo = bits.Mul64(a[j], b[i])
+			lo, cc = bits.Add64(lo, t[j], 0)
+			hi += cc
+			lo, cc = bits.Add64(lo, carry, 0)
+			hi += cc
+			t[j], carry = lo, hi
+		}
+		t[numWords], cc = bits.Add64(t[numWords], carry, 0)
+		t[numWords+1] = cc
+
+		m := t[0] * pInv
+		hi, lo = bits.Mul64(m, p[0])
+		_, cc = bits.Add64(lo, t[0], 0)
+		carry = hi + cc
+		for j := 1; j < numWords; j++ {
+			hi, lo = bits.Mul64(m, p[j])
+			lo, cc = bits.Add64(lo, t[j], 0)
+			hi += cc
+			lo, cc = bits.Add64(lo, carry, 0)
+			hi += cc
+			t[j-1], carry = lo, hi
+		}
+		t[numWords-1], cc = bits.Add64(t[numWords], carry, 0)
+		t[numWords] = t[numWords+1] + cc
+	}
+
+	var z, r fp256
+	var borrow uint64
+	copy(r[:], t[:numWords])
+	for i := range z {
+		z[i], borrow = bits.Sub64(r[i], p[i], borrow)
+	}
+	_, borrow = bits.Sub64(t[numWords], 0, borrow)
+	fp256Cmov(&z, &r, int(borrow))
+	*c = z
+}
+
+// pInv satisfies p*pInv = -1 mod 2^64.
+const pInv = 0x1
+
+var (
+	// p is the order of the base field, represented as little-endian 64-bit words.
+	p = fp256{
+		0xffffffffffffffff, 0x00000000ffffffff, 0x0000000000000000, 0xffffffff00000001,
+	}
+	// pMinus2 is p-2, the exponent used for inversion.
+	pMinus2 = fp256{
+		0xfffffffffffffffd, 0x00000000ffffffff, 0x0000000000000000, 0xffffffff00000001,
+	}
+	// r2 is R^2 where R = 2^256 mod p.
+	r2 = fp256{
+		0x0000000000000003, 0xfffffffbffffffff, 0xfffffffffffffffe, 0x00000004fffffffd,
+	}
+	// bb is the Montgomery encoding of the curve parameter B.
+	bb = fp256{
+		0xd89cdf6229c4bddf, 0xacf005cd78843090, 0xe5a220abf7212ed6, 0xdc30061d04874834,
+	}
+)
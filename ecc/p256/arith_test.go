@@ -0,0 +1,263 @@
+package p256
+
+import (
+	"crypto/elliptic"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+)
+
+func randomFp() fp256 {
+	k, _ := rand.Int(rand.Reader, elliptic.P256().Params().P)
+	var x fp256
+	x.SetBigInt(k)
+	return x
+}
+
+func TestFpCmov(t *testing.T) {
+	var x, y, z fp256
+	for _, b := range []int{-2, -1, 1, 2} {
+		x = randomFp()
+		y = randomFp()
+		z = x
+		fp256Cmov(&z, &y, b)
+		got := z
+		want := y
+		if got != want {
+			test.ReportError(t, got, want, b, x, y)
+		}
+	}
+	x = randomFp()
+	y = randomFp()
+	z = x
+	fp256Cmov(&z, &y, 0)
+	got := z
+	want := x
+	if got != want {
+		test.ReportError(t, got, want, 0, x, y)
+	}
+}
+
+func TestFpNegZero(t *testing.T) {
+	zero, x := &fp256{}, &fp256{}
+	fp256Neg(x, zero)
+	got := x.BigInt()
+	want := zero.BigInt()
+	if got.Cmp(want) != 0 {
+		test.ReportError(t, got, want, x)
+	}
+}
+
+func TestFpSetBigInt(t *testing.T) {
+	P := elliptic.P256().Params().P
+
+	neg := big.NewInt(-0xFF)                       // negative
+	zero := big.NewInt(0)                          // zero
+	one := big.NewInt(1)                           // one
+	two96 := new(big.Int).Lsh(one, 96)             // 2^96
+	two256 := new(big.Int).Lsh(one, 256)           // 2^256
+	two256two96 := new(big.Int).Sub(two256, two96) // 2^256-2^96
+	two512 := new(big.Int).Lsh(one, 512)           // 2^512
+
+	for id, b := range []*big.Int{
+		neg, zero, one, two96, two256, two256two96, two512} {
+		var x fp256
+		x.SetBigInt(b)
+		got := x.BigInt()
+		if b.BitLen() > 256 || b.Sign() < 0 {
+			b.Mod(b, P)
+		}
+		want := b
+		if got.Cmp(want) != 0 {
+			test.ReportError(t, got, want, id)
+		}
+	}
+}
+
+func TestMulZero(t *testing.T) {
+	x, zero := &fp256{}, &fp256{}
+	*x = randomFp()
+
+	fp256Mul(x, x, zero)
+	got := x.BigInt()
+	want := zero.BigInt()
+
+	if got.Cmp(want) != 0 {
+		test.ReportError(t, got, want, x)
+	}
+}
+
+func TestFp(t *testing.T) {
+	P := elliptic.P256().Params().P
+	x, y, z := &fp256{}, &fp256{}, &fp256{}
+	testTimes := 1 << 12
+
+	var bigR, bigR2, bigRinv big.Int
+	one := big.NewInt(1)
+	bigR.Lsh(one, 256).Mod(&bigR, P)
+	bigR2.Lsh(one, 2*256).Mod(&bigR2, P)
+	bigRinv.ModInverse(&bigR, P)
+
+	t.Run("Encode", func(t *testing.T) {
+		for i := 0; i < testTimes; i++ {
+			*x = randomFp()
+			bigX := x.BigInt()
+
+			// fp256
+			montEncode(z, x)
+			got := z.BigInt()
+
+			// big.Int
+			want := bigX.Mul(bigX, &bigR).Mod(bigX, P)
+			if got.Cmp(want) != 0 {
+				test.ReportError(t, got, want, x)
+			}
+		}
+	})
+
+	t.Run("Decode", func(t *testing.T) {
+		for i := 0; i < testTimes; i++ {
+			*x = randomFp()
+			bigX := x.BigInt()
+
+			// fp256
+			montDecode(z, x)
+			got := z.BigInt()
+
+			// big.Int
+			want := bigX.Mul(bigX, new(big.Int).ModInverse(&bigR, P)).Mod(bigX, P)
+			if got.Cmp(want) != 0 {
+				test.ReportError(t, got, want, x)
+			}
+		}
+	})
+
+	t.Run("Neg", func(t *testing.T) {
+		for i := 0; i < testTimes; i++ {
+			*x = randomFp()
+			bigX := x.BigInt()
+
+			// fp256
+			fp256Neg(z, x)
+			got := z.BigInt()
+
+			// big.Int
+			want := bigX.Neg(bigX).Mod(bigX, P)
+			if got.Cmp(want) != 0 {
+				test.ReportError(t, got, want, x)
+			}
+		}
+	})
+
+	t.Run("Add", func(t *testing.T) {
+		for i := 0; i < testTimes; i++ {
+			*x = randomFp()
+			*y = randomFp()
+			bigX := x.BigInt()
+			bigY := y.BigInt()
+
+			// fp256
+			fp256Add(z, x, y)
+			got := z.BigInt()
+
+			// big.Int
+			want := bigX.Add(bigX, bigY)
+			want = want.Mod(want, P)
+			if got.Cmp(want) != 0 {
+				test.ReportError(t, got, want, x, y)
+			}
+		}
+	})
+
+	t.Run("Sub", func(t *testing.T) {
+		for i := 0; i < testTimes; i++ {
+			*x = randomFp()
+			*y = randomFp()
+			bigX := x.BigInt()
+			bigY := y.BigInt()
+
+			// fp256
+			fp256Sub(z, x, y)
+			got := z.BigInt()
+
+			// big.Int
+			want := bigX.Sub(bigX, bigY)
+			want = want.Mod(want, P)
+			if got.Cmp(want) != 0 {
+				test.ReportError(t, got, want, x, y)
+			}
+		}
+	})
+
+	t.Run("Mul", func(t *testing.T) {
+		for i := 0; i < testTimes; i++ {
+			*x = randomFp()
+			*y = randomFp()
+			bigX := x.BigInt()
+			bigY := y.BigInt()
+
+			// fp256
+			fp256Mul(z, x, y)
+			got := z.BigInt()
+
+			// big.Int
+			want := bigX.Mul(bigX, bigY).Mul(bigX, &bigRinv).Mod(bigX, P)
+			if got.Cmp(want) != 0 {
+				test.ReportError(t, got, want, x, y)
+			}
+		}
+	})
+
+	t.Run("Inv", func(t *testing.T) {
+		for i := 0; i < testTimes; i++ {
+			*x = randomFp()
+			bigX := x.BigInt()
+
+			// fp256
+			fp256Inv(z, x)
+			got := z.BigInt()
+
+			// big.Int
+			want := bigX.ModInverse(bigX, P).Mul(bigX, &bigR2).Mod(bigX, P)
+			if got.Cmp(want) != 0 {
+				test.ReportError(t, got, want, x)
+			}
+		}
+	})
+}
+
+func BenchmarkFp(b *testing.B) {
+	x, y, z := &fp256{}, &fp256{}, &fp256{}
+
+	b.Run("Add", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			fp256Add(z, x, y)
+		}
+	})
+
+	b.Run("Sub", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			fp256Sub(z, x, y)
+		}
+	})
+
+	b.Run("Mul", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			fp256Mul(z, x, y)
+		}
+	})
+
+	b.Run("Sqr", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			fp256Sqr(z, x)
+		}
+	})
+
+	b.Run("Inv", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			fp256Inv(z, x)
+		}
+	})
+}
@@ -1,8 +1,7 @@
-// +build arm64 amd64
-
 package p384
 
 import (
+	"crypto/subtle"
 	"math/big"
 
 	"github.com/cloudflare/circl/internal/conv"
@@ -115,20 +114,21 @@ func fp384Inv(z, x *fp384) {
 	fp384Mul(z, t4, t1)
 }
 
-//go:noescape
-func fp384Cmov(x, y *fp384, b int)
-
-//go:noescape
-func fp384Neg(c, a *fp384)
-
-//go:noescape
-func fp384Add(c, a, b *fp384)
-
-//go:noescape
-func fp384Sub(c, a, b *fp384)
-
-//go:noescape
-func fp384Mul(c, a, b *fp384)
+// fp384Sqrt calculates z = sqrt(x) = x^((p+1)/4) and returns true, if x is a
+// quadratic residue, otherwise returns false. Runs in constant-time.
+func fp384Sqrt(z, x *fp384) bool {
+	t, t2 := &fp384{}, &fp384{}
+	montEncode(t, &fp384{1})
+	for i := 8*sizeFp - 1; i >= 0; i-- {
+		fp384Sqr(t, t)
+		if (sqrtExp[i/8]>>uint(i%8))&1 == 1 {
+			fp384Mul(t, t, x)
+		}
+	}
+	fp384Sqr(t2, t)
+	*z = *t
+	return subtle.ConstantTimeCompare(t2[:], x[:]) == 1
+}
 
 var (
 	// p is the order of the base field, represented as little-endian 64-bit words.
@@ -159,4 +159,18 @@ var (
 		0xe2, 0x8a, 0x93, 0x94, 0xee, 0x4b, 0x37, 0xe3, 0x94, 0x20, 0x02, 0x1f,
 		0xf4, 0x21, 0x2b, 0xb6, 0xf9, 0xbf, 0x4f, 0x60, 0x4b, 0x11, 0x08, 0xcd,
 	}
+	// rOne is R = 2^384 mod p, the Montgomery encoding of one.
+	rOne = fp384{
+		0x01, 0x00, 0x00, 0x00, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	}
+	// sqrtExp is (p+1)/4, the exponent used for square roots.
+	sqrtExp = fp384{
+		0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xc0,
+		0xff, 0xff, 0xff, 0xbf, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x3f,
+	}
 )
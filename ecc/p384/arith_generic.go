@@ -0,0 +1,116 @@
+package p384
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// numWords is the number of 64-bit words of a field element.
+const numWords = sizeFp / 8
+
+// pInv satisfies p*pInv = -1 mod 2^64.
+const pInv = 0x100000001
+
+type fp384Words [numWords]uint64
+
+func (e *fp384) words() (w fp384Words) {
+	for i := range w {
+		w[i] = binary.LittleEndian.Uint64(e[8*i:])
+	}
+	return
+}
+
+func (e *fp384) setWords(w *fp384Words) {
+	for i := range w {
+		binary.LittleEndian.PutUint64(e[8*i:], w[i])
+	}
+}
+
+// condSubP returns x-p if x >= p, otherwise returns x; where x is the number
+// represented by the words x and the extra bit hi.
+func condSubP(x *fp384Words, hi uint64) (z fp384Words) {
+	pw := p.words()
+	var borrow uint64
+	for i := range z {
+		z[i], borrow = bits.Sub64(x[i], pw[i], borrow)
+	}
+	_, borrow = bits.Sub64(hi, 0, borrow)
+	mask := -borrow
+	for i := range z {
+		z[i] = (z[i] &^ mask) | (x[i] & mask)
+	}
+	return z
+}
+
+func fp384CmovGeneric(x, y *fp384, b int) {
+	mask := byte(-(uint64(b|-b) >> 63))
+	for i := range x {
+		x[i] = (x[i] &^ mask) | (y[i] & mask)
+	}
+}
+
+func fp384NegGeneric(c, a *fp384) { fp384SubGeneric(c, &fp384{}, a) }
+
+func fp384AddGeneric(c, a, b *fp384) {
+	x, y := a.words(), b.words()
+	var carry uint64
+	for i := range x {
+		x[i], carry = bits.Add64(x[i], y[i], carry)
+	}
+	z := condSubP(&x, carry)
+	c.setWords(&z)
+}
+
+func fp384SubGeneric(c, a, b *fp384) {
+	x, y, pw := a.words(), b.words(), p.words()
+	var borrow, carry uint64
+	for i := range x {
+		x[i], borrow = bits.Sub64(x[i], y[i], borrow)
+	}
+	mask := -borrow
+	for i := range x {
+		x[i], carry = bits.Add64(x[i], pw[i]&mask, carry)
+	}
+	c.setWords(&x)
+}
+
+// fp384MulGeneric calculates c = a*b/R mod p using the CIOS method of
+// Montgomery multiplication.
+func fp384MulGeneric(c, a, b *fp384) {
+	x, y, pw := a.words(), b.words(), p.words()
+	var t [numWords + 2]uint64
+	var hi, lo, cc, carry uint64
+	for i := 0; i < numWords; i++ {
+		carry = 0
+		for j := 0; j < numWords; j++ {
+			hi, lo = bits.Mul64(x[j], y[i])
+			lo, cc = bits.Add64(lo, t[j], 0)
+			hi += cc
+			lo, cc = bits.Add64(lo, carry, 0)
+			hi += cc
+			t[j], carry = lo, hi
+		}
+		t[numWords], cc = bits.Add64(t[numWords], carry, 0)
+		t[numWords+1] = cc
+
+		m := t[0] * pInv
+		hi, lo = bits.Mul64(m, pw[0])
+		_, cc = bits.Add64(lo, t[0], 0)
+		carry = hi + cc
+		for j := 1; j < numWords; j++ {
+			hi, lo = bits.Mul64(m, pw[j])
+			lo, cc = bits.Add64(lo, t[j], 0)
+			hi += cc
+			lo, cc = bits.Add64(lo, carry, 0)
+			hi += cc
+			t[j-1], carry = lo, hi
+		}
+		t[numWords-1], cc = bits.Add64(t[numWords], carry, 0)
+		t[numWords] = t[numWords+1] + cc
+	}
+
+	var r fp384Words
+	copy(r[:], t[:numWords])
+	z := condSubP(&r, t[numWords])
+	c.setWords(&z)
+}
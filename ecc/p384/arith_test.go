@@ -1,5 +1,3 @@
-// +build arm64 amd64
-
 package p384
 
 import (
@@ -223,6 +221,98 @@ func TestFp(t *testing.T) {
 	})
 }
 
+func TestFpSqrt(t *testing.T) {
+	P := elliptic.P384().Params().P
+	x, z := &fp384{}, &fp384{}
+	testTimes := 1 << 8
+
+	for i := 0; i < testTimes; i++ {
+		_, _ = rand.Read(x[:])
+		bigX := new(big.Int).Mod(x.BigInt(), P)
+		x.SetBigInt(bigX)
+		montEncode(x, x)
+
+		// fp384
+		got := fp384Sqrt(z, x)
+
+		// big.Int
+		sqrt := new(big.Int).ModSqrt(bigX, P)
+		want := sqrt != nil
+		if got != want {
+			test.ReportError(t, got, want, bigX)
+		}
+		if want {
+			montDecode(z, z)
+			sqrt2 := new(big.Int).Sub(P, sqrt)
+			if r := z.BigInt(); r.Cmp(sqrt) != 0 && r.Cmp(sqrt2) != 0 {
+				test.ReportError(t, r, sqrt, bigX)
+			}
+		}
+	}
+}
+
+func TestFpGeneric(t *testing.T) {
+	P := elliptic.P384().Params().P
+	one := big.NewInt(1)
+	edge := []*big.Int{
+		big.NewInt(0), one, new(big.Int).Sub(P, one),
+		new(big.Int).Lsh(one, 383), new(big.Int).Rsh(P, 1),
+	}
+	var x, y fp384
+	var got, want fp384
+	testTimes := 1 << 12
+
+	check := func(t *testing.T, x, y *fp384) {
+		t.Helper()
+		fp384Add(&got, x, y)
+		fp384AddGeneric(&want, x, y)
+		if got != want {
+			test.ReportError(t, got, want, "add", x, y)
+		}
+		fp384Sub(&got, x, y)
+		fp384SubGeneric(&want, x, y)
+		if got != want {
+			test.ReportError(t, got, want, "sub", x, y)
+		}
+		fp384Neg(&got, x)
+		fp384NegGeneric(&want, x)
+		if got != want {
+			test.ReportError(t, got, want, "neg", x)
+		}
+		fp384Mul(&got, x, y)
+		fp384MulGeneric(&want, x, y)
+		if got != want {
+			test.ReportError(t, got, want, "mul", x, y)
+		}
+		for _, b := range []int{-1, 0, 1, 2} {
+			got, want = *x, *x
+			fp384Cmov(&got, y, b)
+			fp384CmovGeneric(&want, y, b)
+			if got != want {
+				test.ReportError(t, got, want, "cmov", x, y, b)
+			}
+		}
+	}
+
+	t.Run("edge", func(t *testing.T) {
+		for _, a := range edge {
+			for _, b := range edge {
+				x.SetBigInt(a)
+				y.SetBigInt(b)
+				check(t, &x, &y)
+			}
+		}
+	})
+
+	t.Run("random", func(t *testing.T) {
+		for i := 0; i < testTimes; i++ {
+			_, _ = rand.Read(x[:])
+			_, _ = rand.Read(y[:])
+			check(t, &x, &y)
+		}
+	})
+}
+
 func BenchmarkFp(b *testing.B) {
 	x, y, z := &fp384{}, &fp384{}, &fp384{}
 
@@ -244,6 +334,12 @@ func BenchmarkFp(b *testing.B) {
 		}
 	})
 
+	b.Run("MulGeneric", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			fp384MulGeneric(z, x, y)
+		}
+	})
+
 	b.Run("Sqr", func(b *testing.B) {
 		for i := 0; i < b.N; i++ {
 			fp384Sqr(z, x)
@@ -0,0 +1,374 @@
+package p384
+
+import (
+	"crypto/subtle"
+	"errors"
+	"math/big"
+	"math/bits"
+
+	"github.com/cloudflare/circl/math"
+)
+
+// Size of SEC1 encodings of points.
+const (
+	// CompressedSize is the length in bytes of compressed points.
+	CompressedSize = 1 + sizeFp
+	// UncompressedSize is the length in bytes of uncompressed points.
+	UncompressedSize = 1 + 2*sizeFp
+)
+
+var (
+	// ErrEncoding is returned when a point has an invalid encoding.
+	ErrEncoding = errors.New("p384: invalid point encoding")
+	// ErrNotOnCurve is returned when a point does not lie on the curve.
+	ErrNotOnCurve = errors.New("p384: point not on curve")
+	// ErrLength is returned when the numbers of scalars and points differ.
+	ErrLength = errors.New("p384: number of scalars and points differ")
+)
+
+// Point represents an affine point of the curve. The zero value is the
+// identity point. Its methods do not allocate memory on the heap.
+type Point struct{ x, y fp384 }
+
+// SetIdentity assigns to P the identity element.
+func (P *Point) SetIdentity() { *P = Point{} }
+
+// IsIdentity returns true if P is the identity element.
+func (P *Point) IsIdentity() bool { return (*affinePoint)(P).isZero() }
+
+// SetGenerator assigns to P the generator point G.
+func (P *Point) SetGenerator() { *P = Point(baseOddMultiples[0]) }
+
+// IsOnCurve reports whether P lies on the curve. The identity point is not
+// considered to be on the curve.
+func (P *Point) IsOnCurve() bool {
+	y2, x3, threeX := &fp384{}, &fp384{}, &fp384{}
+	fp384Sqr(y2, &P.y)
+	fp384Sqr(x3, &P.x)
+	fp384Mul(x3, x3, &P.x)
+	fp384Add(threeX, &P.x, &P.x)
+	fp384Add(threeX, threeX, &P.x)
+	fp384Sub(x3, x3, threeX)
+	fp384Add(x3, x3, &bb)
+	return *y2 == *x3 && !P.IsIdentity()
+}
+
+// IsEqual returns true if P and Q represent the same point.
+func (P *Point) IsEqual(Q *Point) bool {
+	return subtle.ConstantTimeCompare(P.x[:], Q.x[:])&
+		subtle.ConstantTimeCompare(P.y[:], Q.y[:]) == 1
+}
+
+// Neg calculates P = -Q.
+func (P *Point) Neg(Q *Point) {
+	P.x = Q.x
+	fp384Neg(&P.y, &Q.y)
+}
+
+// Add calculates P = Q + R.
+func (P *Point) Add(Q, R *Point) {
+	var QQ, RR projectivePoint
+	Q.toProjective(&QQ)
+	R.toProjective(&RR)
+	QQ.completeAdd(&QQ, &RR)
+	P.fromProjective(&QQ)
+}
+
+// Double calculates P = 2Q.
+func (P *Point) Double(Q *Point) {
+	var QQ projectivePoint
+	Q.toProjective(&QQ)
+	QQ.double(&QQ)
+	P.fromProjective(&QQ)
+}
+
+// ScalarMult calculates P = k*Q, where k is an integer in big-endian form.
+// Runs in constant-time.
+func (P *Point) ScalarMult(k *[ScalarSize]byte, Q *Point) {
+	var s scalar
+	var d [numDigits]int32
+	s.fromBytes(k)
+	s.reduce()
+	isEven := s.toOdd()
+	s.signedDigits(&d)
+
+	var R, T jacobianPoint
+	var TabQ [1 << (omega - 2)]jacobianPoint
+	Q.toJacobian(&TabQ[0])
+	T = TabQ[0]
+	T.double()
+	for i := 1; i < len(TabQ); i++ {
+		TabQ[i].add(&TabQ[i-1], &T)
+	}
+
+	// The most significant digit is always positive. Since the scalar is odd
+	// and smaller than the order, the partial sums never collide with the
+	// points of the table, except possibly in the last addition.
+	lookup(&R, &TabQ, d[numDigits-1])
+	for i := numDigits - 2; i > 0; i-- {
+		for j := uint(0); j < omega-1; j++ {
+			R.double()
+		}
+		lookup(&T, &TabQ, d[i])
+		R.add(&R, &T)
+	}
+	for j := uint(0); j < omega-1; j++ {
+		R.double()
+	}
+	lookup(&T, &TabQ, d[0])
+
+	// Calculate the last addition using the complete formula.
+	var RR, TT projectivePoint
+	RR.fromJacobian(&R)
+	TT.fromJacobian(&T)
+	RR.completeAdd(&RR, &TT)
+	RR.cneg(isEven)
+	P.fromProjective(&RR)
+}
+
+// lookup sets P to the point of the table corresponding to the digit d, and
+// negates it if d is negative. Runs in constant-time.
+func lookup(P *jacobianPoint, Tab *[1 << (omega - 2)]jacobianPoint, d int32) {
+	idx := absolute(d) >> 1
+	for i := range Tab {
+		P.cmov(&Tab[i], subtle.ConstantTimeEq(int32(i), idx))
+	}
+	P.cneg(int(d>>31) & 1)
+}
+
+// ScalarBaseMult calculates P = k*G, where G is the generator point and k is
+// an integer in big-endian form. Runs in constant-time.
+func (P *Point) ScalarBaseMult(k *[ScalarSize]byte) {
+	var G Point
+	G.SetGenerator()
+	P.ScalarMult(k, &G)
+}
+
+// CombinedMult calculates P = m*G + n*Q, where G is the generator point and
+// the scalars m and n are integers in big-endian form. Runs in non-constant
+// time to be used in signature verification, so only use it with public
+// inputs. Unlike the other methods, it allocates memory for the recoding of
+// the scalars.
+func (P *Point) CombinedMult(m, n *[ScalarSize]byte, Q *Point) {
+	const nOmega = uint(5)
+	var k big.Int
+	k.SetBytes(m[:])
+	nafM := math.OmegaNAF(&k, baseOmega)
+	k.SetBytes(n[:])
+	nafN := math.OmegaNAF(&k, nOmega)
+
+	if len(nafM) > len(nafN) {
+		nafN = append(nafN, make([]int32, len(nafM)-len(nafN))...)
+	} else if len(nafM) < len(nafN) {
+		nafM = append(nafM, make([]int32, len(nafN)-len(nafM))...)
+	}
+
+	TabQ := (*affinePoint)(Q).oddMultiples(nOmega)
+	var jR jacobianPoint
+	var aR affinePoint
+	R := zeroPoint().toJacobian()
+	for i := len(nafN) - 1; i >= 0; i-- {
+		R.double()
+		// Generator point
+		if nafM[i] != 0 {
+			idxM := absolute(nafM[i]) >> 1
+			aR = baseOddMultiples[idxM]
+			if nafM[i] < 0 {
+				aR.neg()
+			}
+			R.mixadd(R, &aR)
+		}
+		// Input point
+		if nafN[i] != 0 {
+			idxN := absolute(nafN[i]) >> 1
+			jR = TabQ[idxN]
+			if nafN[i] < 0 {
+				jR.neg()
+			}
+			R.add(R, &jR)
+		}
+	}
+	*P = Point(*R.toAffine())
+}
+
+// MultiScalarMult calculates P = k[0]*Q[0] + ... + k[n-1]*Q[n-1], where the
+// scalars are integers in big-endian form. It uses an interleaved omega-NAF
+// recoding, so it runs in non-constant time and must only be used with public
+// inputs, e.g., for batch verification of signatures. It returns ErrLength
+// if k and Q have different lengths.
+func (P *Point) MultiScalarMult(k []*[ScalarSize]byte, Q []*Point) error {
+	if len(k) != len(Q) {
+		return ErrLength
+	}
+	const nOmega = uint(5)
+	var s big.Int
+	naf := make([][]int32, len(k))
+	tab := make([][]projectivePoint, len(Q))
+	maxLen := 0
+	for i := range k {
+		s.SetBytes(k[i][:])
+		naf[i] = math.OmegaNAF(&s, nOmega)
+		if len(naf[i]) > maxLen {
+			maxLen = len(naf[i])
+		}
+		oddQ := (*affinePoint)(Q[i]).oddMultiples(nOmega)
+		tab[i] = make([]projectivePoint, len(oddQ))
+		for j := range oddQ {
+			tab[i][j] = *oddQ[j].toProjective()
+		}
+	}
+
+	// Partial sums may be equal to the point being added, so the complete
+	// addition formula is used.
+	var T projectivePoint
+	R := zeroPoint().toProjective()
+	for j := maxLen - 1; j >= 0; j-- {
+		R.double(R)
+		for i := range naf {
+			if j < len(naf[i]) && naf[i][j] != 0 {
+				T = tab[i][absolute(naf[i][j])>>1]
+				if naf[i][j] < 0 {
+					T.neg()
+				}
+				R.completeAdd(R, &T)
+			}
+		}
+	}
+	*P = Point(*R.toAffine())
+	return nil
+}
+
+// AppendCompressed appends the SEC1 compressed encoding of P to b, and returns
+// the resulting slice. The identity point is encoded as a single zero byte.
+func (P *Point) AppendCompressed(b []byte) []byte {
+	if P.IsIdentity() {
+		return append(b, 0x00)
+	}
+	var x, y fp384
+	montDecode(&x, &P.x)
+	montDecode(&y, &P.y)
+	b = append(b, 0x02|(y[0]&1))
+	return appendFp(b, &x)
+}
+
+// AppendUncompressed appends the SEC1 uncompressed encoding of P to b, and
+// returns the resulting slice. The identity point is encoded as a single zero
+// byte.
+func (P *Point) AppendUncompressed(b []byte) []byte {
+	if P.IsIdentity() {
+		return append(b, 0x00)
+	}
+	var x, y fp384
+	montDecode(&x, &P.x)
+	montDecode(&y, &P.y)
+	b = append(b, 0x04)
+	b = appendFp(b, &x)
+	return appendFp(b, &y)
+}
+
+// Unmarshal sets P to the point encoded in b, which is either the SEC1
+// compressed or uncompressed encoding of a point, or a single zero byte for
+// the identity point. It returns an error if the encoding is not canonical
+// or if the point is not on the curve, in such case P is not modified.
+func (P *Point) Unmarshal(b []byte) error {
+	var Q Point
+	switch {
+	case len(b) == 1 && b[0] == 0x00:
+		P.SetIdentity()
+		return nil
+	case len(b) == UncompressedSize && b[0] == 0x04:
+		if !setFp(&Q.x, b[1:1+sizeFp]) || !setFp(&Q.y, b[1+sizeFp:]) {
+			return ErrEncoding
+		}
+		if !Q.IsOnCurve() {
+			return ErrNotOnCurve
+		}
+	case len(b) == CompressedSize && (b[0] == 0x02 || b[0] == 0x03):
+		if !setFp(&Q.x, b[1:]) {
+			return ErrEncoding
+		}
+		// y^2 = x^3 - 3x + b
+		y2, threeX := &fp384{}, &fp384{}
+		fp384Sqr(y2, &Q.x)
+		fp384Mul(y2, y2, &Q.x)
+		fp384Add(threeX, &Q.x, &Q.x)
+		fp384Add(threeX, threeX, &Q.x)
+		fp384Sub(y2, y2, threeX)
+		fp384Add(y2, y2, &bb)
+		if !fp384Sqrt(&Q.y, y2) {
+			return ErrNotOnCurve
+		}
+		var y, minusY fp384
+		montDecode(&y, &Q.y)
+		fp384Neg(&minusY, &Q.y)
+		fp384Cmov(&Q.y, &minusY, int((y[0]^b[0])&1))
+	default:
+		return ErrEncoding
+	}
+	*P = Q
+	return nil
+}
+
+// toProjective converts P to projective coordinates.
+func (P *Point) toProjective(Q *projectivePoint) {
+	zero := fp384{}
+	isZero := subtle.ConstantTimeCompare(P.x[:], zero[:]) &
+		subtle.ConstantTimeCompare(P.y[:], zero[:])
+	Q.x = P.x
+	Q.y = P.y
+	Q.z = rOne
+	fp384Cmov(&Q.y, &rOne, isZero)
+	fp384Cmov(&Q.z, &fp384{}, isZero)
+}
+
+// toJacobian converts P to Jacobian coordinates.
+func (P *Point) toJacobian(Q *jacobianPoint) {
+	zero := fp384{}
+	isZero := subtle.ConstantTimeCompare(P.x[:], zero[:]) &
+		subtle.ConstantTimeCompare(P.y[:], zero[:])
+	Q.x = P.x
+	Q.y = P.y
+	Q.z = rOne
+	fp384Cmov(&Q.x, &rOne, isZero)
+	fp384Cmov(&Q.y, &rOne, isZero)
+	fp384Cmov(&Q.z, &fp384{}, isZero)
+}
+
+// fromJacobian sets P to the projective representation of Q.
+func (P *projectivePoint) fromJacobian(Q *jacobianPoint) {
+	P.y = Q.y
+	fp384Mul(&P.x, &Q.x, &Q.z)
+	fp384Sqr(&P.z, &Q.z)
+	fp384Mul(&P.z, &P.z, &Q.z)
+}
+
+// fromProjective sets P to the affine representation of Q.
+func (P *Point) fromProjective(Q *projectivePoint) {
+	z := &fp384{}
+	fp384Inv(z, &Q.z)
+	fp384Mul(&P.x, &Q.x, z)
+	fp384Mul(&P.y, &Q.y, z)
+}
+
+// setFp sets e to the big-endian number b in Montgomery form, and returns
+// false if the number is not smaller than p.
+func setFp(e *fp384, b []byte) bool {
+	for i := 0; i < sizeFp; i++ {
+		e[i] = b[sizeFp-1-i]
+	}
+	ew, pw := e.words(), p.words()
+	var borrow uint64
+	for i := range ew {
+		_, borrow = bits.Sub64(ew[i], pw[i], borrow)
+	}
+	montEncode(e, e)
+	return borrow == 1
+}
+
+// appendFp appends the big-endian encoding of e to b.
+func appendFp(b []byte, e *fp384) []byte {
+	for i := sizeFp - 1; i >= 0; i-- {
+		b = append(b, e[i])
+	}
+	return b
+}
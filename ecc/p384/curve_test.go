@@ -0,0 +1,382 @@
+package p384_test
+
+import (
+	"bytes"
+	"crypto/elliptic"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/cloudflare/circl/ecc/p384"
+	"github.com/cloudflare/circl/internal/test"
+)
+
+func randomScalar() (k [p384.ScalarSize]byte) {
+	_, _ = rand.Read(k[:])
+	return
+}
+
+func toScalar(n *big.Int) (k [p384.ScalarSize]byte) {
+	b := n.Bytes()
+	copy(k[p384.ScalarSize-len(b):], b)
+	return
+}
+
+func randomPoint() (P p384.Point, x, y *big.Int) {
+	k := randomScalar()
+	P.ScalarBaseMult(&k)
+	x, y = elliptic.P384().ScalarBaseMult(k[:])
+	return
+}
+
+// checkPoint compares P against the point (x,y) given by the standard library,
+// where (0,0) represents the identity.
+func checkPoint(t *testing.T, P *p384.Point, x, y *big.Int, inputs ...interface{}) {
+	t.Helper()
+	var got, want []byte
+	got = P.AppendUncompressed(nil)
+	if x.Sign() == 0 && y.Sign() == 0 {
+		want = []byte{0x00}
+	} else {
+		want = elliptic.Marshal(elliptic.P384(), x, y)
+	}
+	if !bytes.Equal(got, want) {
+		test.ReportError(t, got, want, inputs...)
+	}
+}
+
+func TestPointScalarMult(t *testing.T) {
+	const testTimes = 1 << 7
+	StdCurve := elliptic.P384()
+	params := StdCurve.Params()
+	one := big.NewInt(1)
+
+	t.Run("special k", func(t *testing.T) {
+		for _, n := range []*big.Int{
+			big.NewInt(0), one, big.NewInt(2), big.NewInt(6), big.NewInt(152294),
+			new(big.Int).Sub(params.N, one), params.N,
+			new(big.Int).Add(params.N, one),
+			new(big.Int).Sub(new(big.Int).Lsh(one, 384), one),
+		} {
+			k := toScalar(n)
+			var P p384.Point
+			P.ScalarBaseMult(&k)
+			x, y := StdCurve.ScalarBaseMult(k[:])
+			checkPoint(t, &P, x, y, n)
+		}
+	})
+
+	t.Run("kG", func(t *testing.T) {
+		for i := 0; i < testTimes; i++ {
+			k := randomScalar()
+			var P p384.Point
+			P.ScalarBaseMult(&k)
+			x, y := StdCurve.ScalarBaseMult(k[:])
+			checkPoint(t, &P, x, y, k)
+		}
+	})
+
+	t.Run("kQ", func(t *testing.T) {
+		for i := 0; i < testTimes; i++ {
+			Q, xQ, yQ := randomPoint()
+			k := randomScalar()
+			var P p384.Point
+			P.ScalarMult(&k, &Q)
+			x, y := StdCurve.ScalarMult(xQ, yQ, k[:])
+			checkPoint(t, &P, x, y, k, xQ, yQ)
+		}
+	})
+
+	t.Run("kO", func(t *testing.T) {
+		var P, Q p384.Point
+		k := randomScalar()
+		P.ScalarMult(&k, &Q)
+		got := P.IsIdentity()
+		want := true
+		if got != want {
+			test.ReportError(t, got, want, k)
+		}
+	})
+
+	t.Run("mG+nQ", func(t *testing.T) {
+		for i := 0; i < testTimes; i++ {
+			Q, xQ, yQ := randomPoint()
+			M, _ := rand.Int(rand.Reader, params.N)
+			N, _ := rand.Int(rand.Reader, params.N)
+			m, n := toScalar(M), toScalar(N)
+			var P p384.Point
+			P.CombinedMult(&m, &n, &Q)
+			x1, y1 := StdCurve.ScalarBaseMult(m[:])
+			x2, y2 := StdCurve.ScalarMult(xQ, yQ, n[:])
+			x, y := StdCurve.Add(x1, y1, x2, y2)
+			checkPoint(t, &P, x, y, m, n)
+		}
+	})
+}
+
+func TestPointArith(t *testing.T) {
+	const testTimes = 1 << 7
+	StdCurve := elliptic.P384()
+	zero := new(big.Int)
+
+	for i := 0; i < testTimes; i++ {
+		var P, O, R p384.Point
+		Q, xQ, yQ := randomPoint()
+		S, xS, yS := randomPoint()
+
+		R.Add(&Q, &S)
+		x, y := StdCurve.Add(xQ, yQ, xS, yS)
+		checkPoint(t, &R, x, y, "Q+S")
+
+		R.Add(&Q, &Q)
+		P.Double(&Q)
+		x, y = StdCurve.Double(xQ, yQ)
+		checkPoint(t, &R, x, y, "Q+Q")
+		checkPoint(t, &P, x, y, "2Q")
+
+		P.Neg(&Q)
+		R.Add(&Q, &P)
+		checkPoint(t, &R, zero, zero, "Q-Q")
+
+		R.Add(&Q, &O)
+		checkPoint(t, &R, xQ, yQ, "Q+O")
+		R.Add(&O, &Q)
+		checkPoint(t, &R, xQ, yQ, "O+Q")
+		R.Add(&O, &O)
+		checkPoint(t, &R, zero, zero, "O+O")
+		R.Double(&O)
+		checkPoint(t, &R, zero, zero, "2O")
+
+		got := Q.IsEqual(&S) || !Q.IsEqual(&Q) || !Q.IsOnCurve() || O.IsOnCurve()
+		want := false
+		if got != want {
+			test.ReportError(t, got, want, xQ, yQ)
+		}
+	}
+}
+
+func TestPointEncoding(t *testing.T) {
+	const testTimes = 1 << 7
+	StdCurve := elliptic.P384()
+
+	t.Run("valid", func(t *testing.T) {
+		for i := 0; i < testTimes; i++ {
+			P, x, y := randomPoint()
+			for _, v := range []struct {
+				got, want []byte
+			}{
+				{P.AppendCompressed(nil), elliptic.MarshalCompressed(StdCurve, x, y)},
+				{P.AppendUncompressed(nil), elliptic.Marshal(StdCurve, x, y)},
+			} {
+				if !bytes.Equal(v.got, v.want) {
+					test.ReportError(t, v.got, v.want, x, y)
+				}
+				var Q p384.Point
+				err := Q.Unmarshal(v.got)
+				test.CheckNoErr(t, err, "unmarshal failed")
+				if !Q.IsEqual(&P) {
+					test.ReportError(t, Q, P, v.got)
+				}
+			}
+		}
+	})
+
+	t.Run("identity", func(t *testing.T) {
+		var P, Q p384.Point
+		Q.SetGenerator()
+		for _, b := range [][]byte{P.AppendCompressed(nil), P.AppendUncompressed(nil)} {
+			err := Q.Unmarshal(b)
+			test.CheckNoErr(t, err, "unmarshal failed")
+			got := Q.IsIdentity() && len(b) == 1
+			want := true
+			if got != want {
+				test.ReportError(t, got, want, b)
+			}
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		P, _, _ := randomPoint()
+		comp := P.AppendCompressed(nil)
+		uncomp := P.AppendUncompressed(nil)
+		pBytes := StdCurve.Params().P.Bytes()
+
+		offCurve := append([]byte{}, uncomp...)
+		offCurve[len(offCurve)-1] ^= 1
+		bigX := append([]byte{0x04}, pBytes...)
+		bigX = append(bigX, uncomp[1+48:]...)
+		bigXComp := append([]byte{0x02}, pBytes...)
+		// x=1 is not the abscissa of a point of P-384.
+		noSqrt := make([]byte, p384.CompressedSize)
+		noSqrt[0], noSqrt[p384.CompressedSize-1] = 0x02, 0x01
+
+		for i, b := range [][]byte{
+			nil, {}, {0x01}, {0x00, 0x00}, comp[:len(comp)-1], uncomp[1:],
+			append([]byte{0x04}, comp[1:]...), append([]byte{0x05}, uncomp[1:]...),
+			append([]byte{0x02}, uncomp[1:]...), offCurve, bigX, bigXComp, noSqrt,
+		} {
+			Q := P
+			err := Q.Unmarshal(b)
+			test.CheckIsErr(t, err, "should fail")
+			if !Q.IsEqual(&P) {
+				test.ReportError(t, Q, P, i)
+			}
+		}
+	})
+}
+
+func TestPointMultiScalarMult(t *testing.T) {
+	const testTimes = 1 << 5
+	StdCurve := elliptic.P384()
+
+	for n := 0; n < testTimes; n++ {
+		k := make([]*[p384.ScalarSize]byte, n)
+		Q := make([]*p384.Point, n)
+		wantX, wantY := new(big.Int), new(big.Int)
+		for i := range k {
+			ki := randomScalar()
+			k[i] = &ki
+			Q[i] = new(p384.Point)
+			switch i % 4 {
+			case 1: // repeated point and scalar
+				*k[i], *Q[i] = *k[i-1], *Q[i-1]
+			case 3: // identity point
+			default:
+				*Q[i], _, _ = randomPoint()
+			}
+			if !Q[i].IsIdentity() {
+				x, y := elliptic.Unmarshal(StdCurve, Q[i].AppendUncompressed(nil))
+				x, y = StdCurve.ScalarMult(x, y, k[i][:])
+				if wantX.Sign() == 0 && wantY.Sign() == 0 {
+					wantX, wantY = x, y
+				} else {
+					wantX, wantY = StdCurve.Add(wantX, wantY, x, y)
+				}
+			}
+		}
+
+		var P p384.Point
+		err := P.MultiScalarMult(k, Q)
+		test.CheckNoErr(t, err, "MultiScalarMult failed")
+		checkPoint(t, &P, wantX, wantY, n)
+	}
+
+	var P p384.Point
+	got := P.MultiScalarMult(make([]*[p384.ScalarSize]byte, 1), nil)
+	want := p384.ErrLength
+	if got != want {
+		test.ReportError(t, got, want)
+	}
+}
+
+func TestMultiScalarMult(t *testing.T) {
+	const testTimes = 1 << 4
+	CirclCurve := p384.P384()
+	StdCurve := elliptic.P384()
+	params := StdCurve.Params()
+
+	for n := 1; n < testTimes; n++ {
+		X := make([]*big.Int, n)
+		Y := make([]*big.Int, n)
+		k := make([][]byte, n)
+		var wantX, wantY *big.Int
+		for i := range k {
+			_, X[i], Y[i] = randomPoint()
+			K, _ := rand.Int(rand.Reader, params.N)
+			k[i] = K.Bytes()
+			x, y := StdCurve.ScalarMult(X[i], Y[i], k[i])
+			if i == 0 {
+				wantX, wantY = x, y
+			} else {
+				wantX, wantY = StdCurve.Add(wantX, wantY, x, y)
+			}
+		}
+		gotX, gotY, err := CirclCurve.MultiScalarMult(X, Y, k)
+		test.CheckNoErr(t, err, "MultiScalarMult failed")
+		if gotX.Cmp(wantX) != 0 || gotY.Cmp(wantY) != 0 {
+			test.ReportError(t, gotX, wantX, n)
+		}
+	}
+
+	X := []*big.Int{params.Gx}
+	Y := []*big.Int{params.Gy}
+	for _, k := range [][][]byte{nil, make([][]byte, 2)} {
+		_, _, got := CirclCurve.MultiScalarMult(X, Y, k)
+		want := p384.ErrLength
+		if got != want {
+			test.ReportError(t, got, want, len(k))
+		}
+	}
+	_, _, got := CirclCurve.MultiScalarMult(X, nil, [][]byte{{1}})
+	want := p384.ErrLength
+	if got != want {
+		test.ReportError(t, got, want)
+	}
+}
+
+func TestPointAllocs(t *testing.T) {
+	var P, Q p384.Point
+	Q.SetGenerator()
+	k := randomScalar()
+	b := make([]byte, 0, p384.UncompressedSize)
+	for _, v := range []struct {
+		name string
+		f    func()
+	}{
+		{"ScalarMult", func() { P.ScalarMult(&k, &Q) }},
+		{"ScalarBaseMult", func() { P.ScalarBaseMult(&k) }},
+		{"Add", func() { P.Add(&P, &Q) }},
+		{"Double", func() { P.Double(&Q) }},
+		{"AppendCompressed", func() { b = Q.AppendCompressed(b[:0]) }},
+		{"AppendUncompressed", func() { b = Q.AppendUncompressed(b[:0]) }},
+		{"Unmarshal", func() { _ = P.Unmarshal(b) }},
+	} {
+		got := testing.AllocsPerRun(8, v.f)
+		want := 0.0
+		if got != want {
+			test.ReportError(t, got, want, v.name)
+		}
+	}
+}
+
+func BenchmarkPointAPI(b *testing.B) {
+	var P, Q p384.Point
+	Q.SetGenerator()
+	k := randomScalar()
+	m := randomScalar()
+	enc := Q.AppendCompressed(nil)
+
+	b.Run("ScalarMult", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			P.ScalarMult(&k, &Q)
+		}
+	})
+	b.Run("ScalarBaseMult", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			P.ScalarBaseMult(&k)
+		}
+	})
+	b.Run("CombinedMult", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			P.CombinedMult(&k, &m, &Q)
+		}
+	})
+	b.Run("MultiScalarMult/16", func(b *testing.B) {
+		k := make([]*[p384.ScalarSize]byte, 16)
+		Q := make([]*p384.Point, 16)
+		for i := range k {
+			ki := randomScalar()
+			k[i] = &ki
+			Q[i] = new(p384.Point)
+			*Q[i], _, _ = randomPoint()
+		}
+		for i := 0; i < b.N; i++ {
+			P.MultiScalarMult(k, Q)
+		}
+	})
+	b.Run("UnmarshalCompressed", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = P.Unmarshal(enc)
+		}
+	})
+}
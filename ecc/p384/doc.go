@@ -4,8 +4,14 @@
 //  - Around 10x faster in amd64 architecture.
 //  - Reduced number of memory allocations.
 //  - Native support for arm64 architecture.
+//  - Portable Go implementation for other architectures. Use the build tag
+//    noasm to disable the assembler implementation.
 //  - ScalarMult is perfomed using a constant-time algorithm.
+//  - Point type operating on fixed-size byte scalars without allocating
+//    memory, and supporting SEC1 compressed and uncompressed encodings.
 //  - ScalarBaseMult fallbacks into ScalarMult.
 //  - A new method included for double-point multiplication.
+//  - Multi-scalar multiplication for batch verification of signatures.
+//  - ECDH with validation of public keys.
 //
 package p384
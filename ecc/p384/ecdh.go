@@ -0,0 +1,36 @@
+package p384
+
+import (
+	"errors"
+	"math/big"
+)
+
+// ErrIdentity is returned when a point is the identity point.
+var ErrIdentity = errors.New("p384: identity point")
+
+// ECDH calculates the shared secret between the private key priv, an integer
+// in big-endian form, and the peer's public key (x,y). It returns the
+// x-coordinate of priv*(x,y) encoded in big-endian form using 48 bytes.
+//
+// The public key must lie on the curve with coordinates smaller than the
+// field modulus, and neither the public key nor the result can be the
+// identity point; otherwise an error is returned. Since P-384 has prime order,
+// this validation suffices to prevent small-subgroup and invalid-curve
+// attacks.
+func ECDH(priv []byte, x, y *big.Int) ([]byte, error) {
+	c := curve{}
+	if c.IsAtInfinity(x, y) {
+		return nil, ErrIdentity
+	}
+	if !c.IsOnCurve(x, y) {
+		return nil, ErrNotOnCurve
+	}
+	var P Point
+	P.ScalarMult(c.reduceScalar(priv), newPoint(x, y))
+	if P.IsIdentity() {
+		return nil, ErrIdentity
+	}
+	var sharedX fp384
+	montDecode(&sharedX, &P.x)
+	return appendFp(make([]byte, 0, sizeFp), &sharedX), nil
+}
@@ -0,0 +1,173 @@
+package p384_test
+
+import (
+	"bytes"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/cloudflare/circl/ecc/p384"
+	"github.com/cloudflare/circl/internal/test"
+)
+
+func TestMarshal(t *testing.T) {
+	const testTimes = 1 << 7
+	StdCurve := elliptic.P384()
+	for i := 0; i < testTimes; i++ {
+		_, x, y := randomPoint()
+		for _, v := range []struct {
+			got, want []byte
+		}{
+			{p384.Marshal(x, y), elliptic.Marshal(StdCurve, x, y)},
+			{p384.MarshalCompressed(x, y), elliptic.MarshalCompressed(StdCurve, x, y)},
+		} {
+			if !bytes.Equal(v.got, v.want) {
+				test.ReportError(t, v.got, v.want, x, y)
+			}
+			gotX, gotY, err := p384.Unmarshal(v.got)
+			test.CheckNoErr(t, err, "unmarshal failed")
+			if gotX.Cmp(x) != 0 || gotY.Cmp(y) != 0 {
+				test.ReportError(t, gotX, x, v.got)
+			}
+		}
+	}
+
+	zero := new(big.Int)
+	got := p384.MarshalCompressed(zero, zero)
+	want := []byte{0x00}
+	if !bytes.Equal(got, want) {
+		test.ReportError(t, got, want)
+	}
+	_, _, err := p384.Unmarshal([]byte{0x04})
+	test.CheckIsErr(t, err, "should fail")
+}
+
+func TestECDH(t *testing.T) {
+	const testTimes = 1 << 6
+	curve := p384.P384()
+	params := curve.Params()
+
+	t.Run("agreement", func(t *testing.T) {
+		for i := 0; i < testTimes; i++ {
+			a, _ := rand.Int(rand.Reader, params.N)
+			b, _ := rand.Int(rand.Reader, params.N)
+			xA, yA := curve.ScalarBaseMult(a.Bytes())
+			xB, yB := curve.ScalarBaseMult(b.Bytes())
+			sA, errA := p384.ECDH(a.Bytes(), xB, yB)
+			sB, errB := p384.ECDH(b.Bytes(), xA, yA)
+			test.CheckNoErr(t, errA, "ecdh failed")
+			test.CheckNoErr(t, errB, "ecdh failed")
+			if !bytes.Equal(sA, sB) || len(sA) != 48 {
+				test.ReportError(t, sA, sB, a, b)
+			}
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		k := []byte{0x01}
+		x, y := params.Gx, params.Gy
+		zero := new(big.Int)
+		for _, v := range []struct {
+			k    []byte
+			x, y *big.Int
+			err  error
+		}{
+			{k, zero, zero, p384.ErrIdentity},
+			{k, x, new(big.Int).Add(y, big.NewInt(1)), p384.ErrNotOnCurve},
+			{k, new(big.Int).Add(x, params.P), y, p384.ErrNotOnCurve},
+			{k, x, new(big.Int).Neg(y), p384.ErrNotOnCurve},
+			{[]byte{0x00}, x, y, p384.ErrIdentity},
+			{params.N.Bytes(), x, y, p384.ErrIdentity},
+		} {
+			_, err := p384.ECDH(v.k, v.x, v.y)
+			if err != v.err {
+				test.ReportError(t, err, v.err, v.k, v.x, v.y)
+			}
+		}
+	})
+}
+
+type ecdhGroup struct {
+	Curve    string `json:"curve"`
+	Encoding string `json:"encoding"`
+	Tests    []struct {
+		TcID    int      `json:"tcId"`
+		Comment string   `json:"comment"`
+		Public  string   `json:"public"`
+		Private string   `json:"private"`
+		Shared  string   `json:"shared"`
+		Result  string   `json:"result"`
+		Flags   []string `json:"flags"`
+	} `json:"tests"`
+}
+
+type ecdhWycheproof struct {
+	Alg    string      `json:"algorithm"`
+	Num    int         `json:"numberOfTests"`
+	Groups []ecdhGroup `json:"testGroups"`
+}
+
+func TestECDHWycheproof(t *testing.T) {
+	// Test vectors from Wycheproof (testvectors_v1).
+	const nameFile = "testdata/ecdh_secp384r1_ecpoint_test.json"
+	jsonFile, err := os.Open(nameFile)
+	if err != nil {
+		t.Fatalf("File %v can not be opened. Error: %v", nameFile, err)
+	}
+	defer jsonFile.Close()
+	input, _ := ioutil.ReadAll(jsonFile)
+
+	var kat ecdhWycheproof
+	err = json.Unmarshal(input, &kat)
+	if err != nil {
+		t.Fatalf("File %v can not be loaded. Error: %v", nameFile, err)
+	}
+
+	for _, g := range kat.Groups {
+		if g.Curve != "secp384r1" || g.Encoding != "ecpoint" {
+			t.Fatalf("Group not expected %v %v", g.Curve, g.Encoding)
+		}
+		for _, tc := range g.Tests {
+			public, _ := hex.DecodeString(tc.Public)
+			private, _ := hex.DecodeString(tc.Private)
+			want, _ := hex.DecodeString(tc.Shared)
+
+			var got []byte
+			x, y, err := p384.Unmarshal(public)
+			if err == nil {
+				got, err = p384.ECDH(private, x, y)
+			}
+			// Compressed points are supported, so acceptable vectors must pass.
+			if tc.Result == "invalid" {
+				test.CheckIsErr(t, err, "should fail")
+			} else if err != nil || !bytes.Equal(got, want) {
+				test.ReportError(t, got, want, tc.TcID, tc.Comment, err)
+			}
+		}
+	}
+}
+
+func BenchmarkECDH(b *testing.B) {
+	curve := p384.P384()
+	params := curve.Params()
+	k, _ := rand.Int(rand.Reader, params.N)
+	x, y := curve.ScalarBaseMult(k.Bytes())
+	enc := p384.MarshalCompressed(x, y)
+	priv := k.Bytes()
+
+	b.Run("ECDH", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = p384.ECDH(priv, x, y)
+		}
+	})
+	b.Run("UnmarshalCompressed", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _, _ = p384.Unmarshal(enc)
+		}
+	})
+}
@@ -0,0 +1,126 @@
+package p384
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// ScalarSize is the length in bytes of scalars.
+const ScalarSize = sizeFp
+
+const (
+	// omega is the window size used in ScalarMult.
+	omega = uint(5)
+	// numDigits is the number of signed digits of the regular recoding.
+	numDigits = (8*ScalarSize+int(omega)-2)/int(omega-1) + 1
+)
+
+// scalar is a 384-bit integer stored as little-endian 64-bit words. An
+// extra word allows intermediate values larger than 2^384.
+type scalar [numWords + 1]uint64
+
+// orderN is the order of the generator point.
+var orderN = scalar{
+	0xecec196accc52973, 0x581a0db248b0a77a, 0xc7634d81f4372ddf,
+	0xffffffffffffffff, 0xffffffffffffffff, 0xffffffffffffffff,
+}
+
+// fromBytes sets s to the big-endian number k.
+func (s *scalar) fromBytes(k *[ScalarSize]byte) {
+	for i := 0; i < numWords; i++ {
+		s[i] = binary.BigEndian.Uint64(k[ScalarSize-8*(i+1):])
+	}
+	s[numWords] = 0
+}
+
+// reduce sets s = s mod N, assuming that s < 2N. Runs in constant-time.
+func (s *scalar) reduce() {
+	var t scalar
+	var borrow uint64
+	for i := range t {
+		t[i], borrow = bits.Sub64(s[i], orderN[i], borrow)
+	}
+	s.cmov(&t, int(1-borrow))
+}
+
+// reduceScalar returns the big-endian number k reduced modulo the order of
+// the curve. Its running time only depends on the length of k.
+func (c curve) reduceScalar(k []byte) *[ScalarSize]byte {
+	var s scalar
+	for _, b := range k {
+		for i := 7; i >= 0; i-- {
+			s.shiftAdd(uint64(b>>uint(i)) & 1)
+		}
+	}
+	out := new([ScalarSize]byte)
+	s.toBytes(out)
+	return out
+}
+
+// toBytes sets k to the big-endian encoding of s. Assumes s < 2^384.
+func (s *scalar) toBytes(k *[ScalarSize]byte) {
+	for i := 0; i < numWords; i++ {
+		binary.BigEndian.PutUint64(k[ScalarSize-8*(i+1):], s[i])
+	}
+}
+
+// shiftAdd sets s = 2*s+b mod N, where b is a bit. Assumes s < N. Runs in
+// constant-time.
+func (s *scalar) shiftAdd(b uint64) {
+	for i := range s {
+		s[i], b = s[i]<<1|b, s[i]>>63
+	}
+	s.reduce()
+}
+
+// cmov sets s to t if b=1.
+func (s *scalar) cmov(t *scalar, b int) {
+	mask := -uint64(b & 1)
+	for i := range s {
+		s[i] = (s[i] &^ mask) | (t[i] & mask)
+	}
+}
+
+// toOdd sets s = N-s if s is even, and returns 1 in such case. Assumes s < N.
+// Runs in constant-time.
+func (s *scalar) toOdd() int {
+	var t scalar
+	var borrow uint64
+	for i := range t {
+		t[i], borrow = bits.Sub64(orderN[i], s[i], borrow)
+	}
+	isEven := int(1 - s[0]&1)
+	s.cmov(&t, isEven)
+	return isEven
+}
+
+// signedDigits calculates the regular recoding of an odd scalar s, such that
+// s = sum_i d[i]*2^((omega-1)*i), and the digits are odd in the range
+// -2^(omega-1) < d[i] < 2^(omega-1). Runs in constant-time.
+func (s *scalar) signedDigits(d *[numDigits]int32) {
+	k := *s
+	const mask = (1 << omega) - 1
+	for i := 0; i < numDigits-1; i++ {
+		v := int32(k[0]&mask) - (1 << (omega - 1))
+		d[i] = v
+		// k = (k - v) >> (omega-1)
+		sub := uint64(int64(v))
+		ext := uint64(int64(v) >> 63)
+		var borrow uint64
+		k[0], borrow = bits.Sub64(k[0], sub, 0)
+		for j := 1; j < len(k); j++ {
+			k[j], borrow = bits.Sub64(k[j], ext, borrow)
+		}
+		for j := 0; j < len(k)-1; j++ {
+			k[j] = k[j]>>(omega-1) | k[j+1]<<(64-(omega-1))
+		}
+		k[len(k)-1] >>= omega - 1
+	}
+	d[numDigits-1] = int32(k[0])
+}
+
+// absolute returns always a positive value.
+func absolute(x int32) int32 {
+	mask := x >> 31
+	return (x + mask) ^ mask
+}
@@ -0,0 +1,28 @@
+package p384
+
+import "math/big"
+
+// Marshal returns the SEC1 uncompressed encoding of the point (x,y). The
+// identity point (0,0) is encoded as a single zero byte.
+func Marshal(x, y *big.Int) []byte {
+	return newPoint(x, y).AppendUncompressed(make([]byte, 0, UncompressedSize))
+}
+
+// MarshalCompressed returns the SEC1 compressed encoding of the point (x,y).
+// The identity point (0,0) is encoded as a single zero byte.
+func MarshalCompressed(x, y *big.Int) []byte {
+	return newPoint(x, y).AppendCompressed(make([]byte, 0, CompressedSize))
+}
+
+// Unmarshal converts a point, encoded in SEC1 compressed or uncompressed form,
+// into a pair (x,y). It returns an error if the encoding is invalid or if the
+// point is not on the curve. A single zero byte is decoded as the identity
+// point (0,0).
+func Unmarshal(data []byte) (x, y *big.Int, err error) {
+	var P Point
+	if err = P.Unmarshal(data); err != nil {
+		return nil, nil, err
+	}
+	x, y = P.toInt()
+	return x, y, nil
+}
@@ -0,0 +1,157 @@
+package p521
+
+import (
+	"math/big"
+	"math/bits"
+
+	"github.com/cloudflare/circl/internal/conv"
+)
+
+const (
+	// sizeFp is the length in bytes of field elements and scalars.
+	sizeFp = 66
+	// numWords is the number of 64-bit words of a field element.
+	numWords = 9
+)
+
+// fp521 is a prime field element stored as little-endian 64-bit words.
+type fp521 [numWords]uint64
+
+func (e fp521) BigInt() *big.Int { return conv.Uint64Le2BigInt(e[:]) }
+func (e fp521) String() string   { return "0x" + e.BigInt().Text(16) }
+
+func (e *fp521) SetBigInt(b *big.Int) {
+	if b.BitLen() > 521 || b.Sign() < 0 {
+		b = new(big.Int).Mod(b, p.BigInt())
+	}
+	conv.BigInt2Uint64Le(e[:], b)
+}
+
+func montEncode(c, a *fp521) { fp521Mul(c, a, &r2) }
+func montDecode(c, a *fp521) { fp521Mul(c, a, &fp521{1}) }
+func fp521Sqr(c, a *fp521)   { fp521Mul(c, a, a) }
+
+// fp521Inv calculates z = x^(p-2) using Fermat's little theorem. The exponent
+// is public, so the sequence of operations does not depend on x.
+func fp521Inv(z, x *fp521) {
+	t := &fp521{}
+	montEncode(t, &fp521{1})
+	for i := numWords*64 - 1; i >= 0; i-- {
+		fp521Sqr(t, t)
+		if (pMinus2[i/64]>>uint(i%64))&1 == 1 {
+			fp521Mul(t, t, x)
+		}
+	}
+	*z = *t
+}
+
+// fp521Cmov sets x to y if b != 0.
+func fp521Cmov(x, y *fp521, b int) {
+	mask := -(uint64(b|-b) >> 63)
+	for i := range x {
+		x[i] = (x[i] &^ mask) | (y[i] & mask)
+	}
+}
+
+func fp521Neg(c, a *fp521) { fp521Sub(c, &fp521{}, a) }
+
+func fp521Add(c, a, b *fp521) {
+	var t, z fp521
+	var carry, borrow uint64
+	for i := range t {
+		t[i], carry = bits.Add64(a[i], b[i], carry)
+	}
+	for i := range z {
+		z[i], borrow = bits.Sub64(t[i], p[i], borrow)
+	}
+	_, borrow = bits.Sub64(carry, 0, borrow)
+	fp521Cmov(&z, &t, int(borrow))
+	*c = z
+}
+
+func fp521Sub(c, a, b *fp521) {
+	var t fp521
+	var borrow, carry uint64
+	for i := range t {
+		t[i], borrow = bits.Sub64(a[i], b[i], borrow)
+	}
+	mask := -borrow
+	for i := range t {
+		t[i], carry = bits.Add64(t[i], p[i]&mask, carry)
+	}
+	*c = t
+}
+
+// fp521Mul calculates c = a*b/R mod p, where R = 2^576. As p = 2^521-1 is a
+// Mersenne prime, the product is reduced by folding its upper bits, and the
+// division by R becomes a rotation since 1/R = 2^466 mod p.
+func fp521Mul(c, a, b *fp521) {
+	var t [2 * numWords]uint64
+	var hi, lo, cc, carry uint64
+	for i := 0; i < numWords; i++ {
+		carry = 0
+		for j := 0; j < numWords; j++ {
+			hi, lo = bits.Mul64(a[j], b[i])
+			lo, cc = bits.Add64(lo, t[i+j], 0)
+			hi += cc
+			lo, cc = bits.Add64(lo, carry, 0)
+			hi += cc
+			t[i+j], carry = lo, hi
+		}
+		t[i+numWords] = carry
+	}
+
+	// t = tL + tH*2^521 = tL + tH mod p.
+	var tL, tH, z fp521
+	copy(tL[:], t[:numWords])
+	tL[numWords-1] &= 0x1ff
+	for i := 0; i < numWords; i++ {
+		tH[i] = t[i+numWords-1]>>9 | t[i+numWords]<<55
+	}
+	carry = 0
+	for i := range z {
+		z[i], carry = bits.Add64(tL[i], tH[i], carry)
+	}
+	// Fold the bit 521 of the sum, so the result is at most p.
+	carry = z[numWords-1] >> 9
+	z[numWords-1] &= 0x1ff
+	for i := range z {
+		z[i], carry = bits.Add64(z[i], 0, carry)
+	}
+	fp521Add(&z, &z, &fp521{})
+
+	// c = z*2^466 mod p, which is a rotation of 55 bits to the right.
+	for i := 0; i < numWords-1; i++ {
+		c[i] = z[i]>>55 | z[i+1]<<9
+	}
+	c[numWords-1] = z[numWords-1] >> 55
+	c[numWords-2] |= z[0] << 18
+	c[numWords-1] |= (z[1]<<18 | z[0]>>46) & 0x1ff
+}
+
+var (
+	// p is the order of the base field, represented as little-endian 64-bit words.
+	p = fp521{
+		0xffffffffffffffff, 0xffffffffffffffff, 0xffffffffffffffff, 0xffffffffffffffff,
+		0xffffffffffffffff, 0xffffffffffffffff, 0xffffffffffffffff, 0xffffffffffffffff,
+		0x00000000000001ff,
+	}
+	// pMinus2 is p-2, the exponent used for inversion.
+	pMinus2 = fp521{
+		0xfffffffffffffffd, 0xffffffffffffffff, 0xffffffffffffffff, 0xffffffffffffffff,
+		0xffffffffffffffff, 0xffffffffffffffff, 0xffffffffffffffff, 0xffffffffffffffff,
+		0x00000000000001ff,
+	}
+	// r2 is R^2 where R = 2^576 mod p.
+	r2 = fp521{
+		0x0000000000000000, 0x0000400000000000, 0x0000000000000000, 0x0000000000000000,
+		0x0000000000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+		0x0000000000000000,
+	}
+	// bb is the Montgomery encoding of the curve parameter B.
+	bb = fp521{
+		0x8014654fae586387, 0x78f7a28fea35a81f, 0x839ab9efc41e961a, 0xbd8b29605e9dd8df,
+		0xf0ab0c9ca8f63f49, 0xf9dc5a44c8c77884, 0x77516d392dccd98a, 0x0fc94d10d05b42a0,
+		0x000000000000004d,
+	}
+)
@@ -0,0 +1,286 @@
+package p521
+
+import (
+	"crypto/elliptic"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+)
+
+func randomFp() fp521 {
+	k, _ := rand.Int(rand.Reader, elliptic.P521().Params().P)
+	var x fp521
+	x.SetBigInt(k)
+	return x
+}
+
+func TestFpCmov(t *testing.T) {
+	var x, y, z fp521
+	for _, b := range []int{-2, -1, 1, 2} {
+		x = randomFp()
+		y = randomFp()
+		z = x
+		fp521Cmov(&z, &y, b)
+		got := z
+		want := y
+		if got != want {
+			test.ReportError(t, got, want, b, x, y)
+		}
+	}
+	x = randomFp()
+	y = randomFp()
+	z = x
+	fp521Cmov(&z, &y, 0)
+	got := z
+	want := x
+	if got != want {
+		test.ReportError(t, got, want, 0, x, y)
+	}
+}
+
+func TestFpNegZero(t *testing.T) {
+	zero, x := &fp521{}, &fp521{}
+	fp521Neg(x, zero)
+	got := x.BigInt()
+	want := zero.BigInt()
+	if got.Cmp(want) != 0 {
+		test.ReportError(t, got, want, x)
+	}
+}
+
+func TestFpSetBigInt(t *testing.T) {
+	P := elliptic.P521().Params().P
+
+	neg := big.NewInt(-0xFF)                       // negative
+	zero := big.NewInt(0)                          // zero
+	one := big.NewInt(1)                           // one
+	two96 := new(big.Int).Lsh(one, 96)             // 2^96
+	two521 := new(big.Int).Lsh(one, 521)           // 2^521
+	two521two96 := new(big.Int).Sub(two521, two96) // 2^521-2^96
+	two1042 := new(big.Int).Lsh(one, 1042)         // 2^1042
+
+	for id, b := range []*big.Int{
+		neg, zero, one, two96, two521, two521two96, two1042} {
+		var x fp521
+		x.SetBigInt(b)
+		got := x.BigInt()
+		if b.BitLen() > 521 || b.Sign() < 0 {
+			b.Mod(b, P)
+		}
+		want := b
+		if got.Cmp(want) != 0 {
+			test.ReportError(t, got, want, id)
+		}
+	}
+}
+
+func TestMulZero(t *testing.T) {
+	x, zero := &fp521{}, &fp521{}
+	*x = randomFp()
+
+	fp521Mul(x, x, zero)
+	got := x.BigInt()
+	want := zero.BigInt()
+
+	if got.Cmp(want) != 0 {
+		test.ReportError(t, got, want, x)
+	}
+}
+
+func TestFp(t *testing.T) {
+	P := elliptic.P521().Params().P
+	x, y, z := &fp521{}, &fp521{}, &fp521{}
+	testTimes := 1 << 12
+
+	var bigR, bigR2, bigRinv big.Int
+	one := big.NewInt(1)
+	bigR.Lsh(one, 576).Mod(&bigR, P)
+	bigR2.Lsh(one, 2*576).Mod(&bigR2, P)
+	bigRinv.ModInverse(&bigR, P)
+
+	t.Run("Encode", func(t *testing.T) {
+		for i := 0; i < testTimes; i++ {
+			*x = randomFp()
+			bigX := x.BigInt()
+
+			// fp521
+			montEncode(z, x)
+			got := z.BigInt()
+
+			// big.Int
+			want := bigX.Mul(bigX, &bigR).Mod(bigX, P)
+			if got.Cmp(want) != 0 {
+				test.ReportError(t, got, want, x)
+			}
+		}
+	})
+
+	t.Run("Decode", func(t *testing.T) {
+		for i := 0; i < testTimes; i++ {
+			*x = randomFp()
+			bigX := x.BigInt()
+
+			// fp521
+			montDecode(z, x)
+			got := z.BigInt()
+
+			// big.Int
+			want := bigX.Mul(bigX, new(big.Int).ModInverse(&bigR, P)).Mod(bigX, P)
+			if got.Cmp(want) != 0 {
+				test.ReportError(t, got, want, x)
+			}
+		}
+	})
+
+	t.Run("Neg", func(t *testing.T) {
+		for i := 0; i < testTimes; i++ {
+			*x = randomFp()
+			bigX := x.BigInt()
+
+			// fp521
+			fp521Neg(z, x)
+			got := z.BigInt()
+
+			// big.Int
+			want := bigX.Neg(bigX).Mod(bigX, P)
+			if got.Cmp(want) != 0 {
+				test.ReportError(t, got, want, x)
+			}
+		}
+	})
+
+	t.Run("Add", func(t *testing.T) {
+		for i := 0; i < testTimes; i++ {
+			*x = randomFp()
+			*y = randomFp()
+			bigX := x.BigInt()
+			bigY := y.BigInt()
+
+			// fp521
+			fp521Add(z, x, y)
+			got := z.BigInt()
+
+			// big.Int
+			want := bigX.Add(bigX, bigY)
+			want = want.Mod(want, P)
+			if got.Cmp(want) != 0 {
+				test.ReportError(t, got, want, x, y)
+			}
+		}
+	})
+
+	t.Run("Sub", func(t *testing.T) {
+		for i := 0; i < testTimes; i++ {
+			*x = randomFp()
+			*y = randomFp()
+			bigX := x.BigInt()
+			bigY := y.BigInt()
+
+			// fp521
+			fp521Sub(z, x, y)
+			got := z.BigInt()
+
+			// big.Int
+			want := bigX.Sub(bigX, bigY)
+			want = want.Mod(want, P)
+			if got.Cmp(want) != 0 {
+				test.ReportError(t, got, want, x, y)
+			}
+		}
+	})
+
+	t.Run("Mul", func(t *testing.T) {
+		for i := 0; i < testTimes; i++ {
+			*x = randomFp()
+			*y = randomFp()
+			bigX := x.BigInt()
+			bigY := y.BigInt()
+
+			// fp521
+			fp521Mul(z, x, y)
+			got := z.BigInt()
+
+			// big.Int
+			want := bigX.Mul(bigX, bigY).Mul(bigX, &bigRinv).Mod(bigX, P)
+			if got.Cmp(want) != 0 {
+				test.ReportError(t, got, want, x, y)
+			}
+		}
+	})
+
+	t.Run("MulEdge", func(t *testing.T) {
+		pMinusOne := new(big.Int).Sub(P, one)
+		two520 := new(big.Int).Lsh(one, 520)
+		edge := []*big.Int{big.NewInt(0), one, pMinusOne, two520}
+		for _, a := range edge {
+			for _, b := range edge {
+				x.SetBigInt(a)
+				y.SetBigInt(b)
+
+				// fp521
+				fp521Mul(z, x, y)
+				got := z.BigInt()
+
+				// big.Int
+				want := new(big.Int).Mul(a, b)
+				want.Mul(want, &bigRinv).Mod(want, P)
+				if got.Cmp(want) != 0 {
+					test.ReportError(t, got, want, a, b)
+				}
+			}
+		}
+	})
+
+	t.Run("Inv", func(t *testing.T) {
+		for i := 0; i < testTimes; i++ {
+			*x = randomFp()
+			bigX := x.BigInt()
+
+			// fp521
+			fp521Inv(z, x)
+			got := z.BigInt()
+
+			// big.Int
+			want := bigX.ModInverse(bigX, P).Mul(bigX, &bigR2).Mod(bigX, P)
+			if got.Cmp(want) != 0 {
+				test.ReportError(t, got, want, x)
+			}
+		}
+	})
+}
+
+func BenchmarkFp(b *testing.B) {
+	x, y, z := &fp521{}, &fp521{}, &fp521{}
+
+	b.Run("Add", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			fp521Add(z, x, y)
+		}
+	})
+
+	b.Run("Sub", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			fp521Sub(z, x, y)
+		}
+	})
+
+	b.Run("Mul", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			fp521Mul(z, x, y)
+		}
+	})
+
+	b.Run("Sqr", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			fp521Sqr(z, x)
+		}
+	})
+
+	b.Run("Inv", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			fp521Inv(z, x)
+		}
+	})
+}
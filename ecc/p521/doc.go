@@ -0,0 +1,10 @@
+// Package p521 provides elliptic curve operations on the P-521 curve.
+//
+// These are some improvements over crypto/elliptic package:
+//  - Field arithmetic written in portable Go using Montgomery multiplication.
+//  - ScalarMult is perfomed using a constant-time algorithm.
+//  - ScalarBaseMult fallbacks into ScalarMult.
+//  - A new method included for double-point multiplication.
+//
+// The package exposes the same extended Curve interface as ecc/p384.
+package p521
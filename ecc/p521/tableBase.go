@@ -0,0 +1,840 @@
+package p521
+
+const baseOmega = uint(7)
+
+// baseOddMultiples has [2*i+1] * G at position i.
+// Each coordinate has been mutiplied by R=2^576
+var baseOddMultiples = [1 << (baseOmega - 1)]affinePoint{
+	// 1P
+	{
+		x: fp521{
+			0xb331a16381adc101, 0x4dfcbf3f18e172de, 0x6f19a459e0c2b521, 0x947f0ee093d17fd4,
+			0xdd50a5af3bf7f3ac, 0x90fc1457b035a69e, 0x214e32409c829fda, 0xe6cf1f65b311cada,
+			0x0000000000000074,
+		},
+		y: fp521{
+			0x28460e4a5a9e268e, 0x20445f4a3b4fe8b3, 0xb09a9e3843513961, 0x2062a85c809fd683,
+			0x164bf7394caf7a13, 0x340bd7de8b939f33, 0xeccc7aa224abcda2, 0x022e452fda163e8d,
+			0x00000000000001e0,
+		},
+	},
+	// 3P
+	{
+		x: fp521{
+			0xbee9cf4d4910f78a, 0x02d2c8ce976f1bd6, 0x0dd75a4843161975, 0x028ed35e8b5acff1,
+			0xe8d69f8b251d2419, 0x5cf2d6bd0896bd46, 0x3cda95372d891ecd, 0xaeec8eb5325acaca,
+			0x000000000000008c,
+		},
+		y: fp521{
+			0x72cfa6c0ee5f7e98, 0x212fac4650f74360, 0x867882e4de49d2c8, 0xd816ad6768ef61e3,
+			0x761716ea67c6e2ba, 0x8be97c558fd1aae7, 0x7978aabfd4154e81, 0xeccbcfc363655c0a,
+			0x000000000000016e,
+		},
+	},
+	// 5P
+	{
+		x: fp521{
+			0x3c194afcf14a49e9, 0x9c6ad5a84b764798, 0xd194ebf0f36c498b, 0x11b8897f5789bf3c,
+			0x721c1e0636af180a, 0x926781ed5c78bbd6, 0x5fbd2cb77eda9f86, 0x639ede19c8e02758,
+			0x0000000000000019,
+		},
+		y: fp521{
+			0x65d6f9bbc6f75980, 0xfc0b9e61f46f5848, 0xbce8f80392b9aa7b, 0xba188aa0108e7aff,
+			0x43ddb44be4839679, 0x28f6ec0be4d01a38, 0x488e6c7f47439700, 0x764515b988a54089,
+			0x00000000000000eb,
+		},
+	},
+	// 7P
+	{
+		x: fp521{
+			0x6a15b574766756df, 0xcd00e756c4140b76, 0xe237ca9fa87ee130, 0x6c64d36f986e71dd,
+			0x2ec61846855fe34c, 0x14780c69617b88a6, 0x062f9170747aa419, 0xa3775b2fed05839d,
+			0x00000000000001b1,
+		},
+		y: fp521{
+			0x8d8f4b46df66eaa8, 0x3dae35c5e4829292, 0x2fcf3b38952eef7e, 0x15ca91d1a2c8e70d,
+			0x2ab5e87949e6f64f, 0x6eb8edecc51365ef, 0x3c5ae2c168141278, 0x8868ec18bd1ceb42,
+			0x0000000000000150,
+		},
+	},
+	// 9P
+	{
+		x: fp521{
+			0x03d614e278d67878, 0x330fa2b13cb3e5f1, 0xc7a7a85d5ec2e694, 0x1af9e2ab6fb92d18,
+			0x32ba14f09cb09a6d, 0x4c962558a2dc635b, 0x44769a2a0dcc43a3, 0x13517adf8ab8ab6a,
+			0x000000000000010c,
+		},
+		y: fp521{
+			0x270a8b986326a2bb, 0x9a1d5075435cd695, 0x3eb9b61574944407, 0x4207fab767a55fec,
+			0x3706b4f2bab02bd6, 0xdb6412dd131eeda2, 0xc71844532a770e75, 0xcf85aaaabd13d749,
+			0x00000000000000f9,
+		},
+	},
+	// 11P
+	{
+		x: fp521{
+			0xcd229d6104967f7b, 0x8176607016ed066d, 0x4280ae0127d264d4, 0x0de8cd8d75f18c88,
+			0x2979ede2999331ed, 0x4aa1f7962a794c8b, 0xe7f6aee3f6be0bc2, 0xaa378d1cab9da18a,
+			0x00000000000000ff,
+		},
+		y: fp521{
+			0x0425becc0ff2ee88, 0xaeac43a7c9672464, 0x9b6e564071fa40cd, 0x559c49198c8a54a9,
+			0x158de4548745a152, 0x49f6974aea705cdc, 0x149d6eab31085e82, 0xc24e86543b82a7d9,
+			0x0000000000000094,
+		},
+	},
+	// 13P
+	{
+		x: fp521{
+			0xd39f8fa63e6130e5, 0xfc0c43c246997de6, 0x74a5f61d80559c77, 0xb51aa852f3cd5c47,
+			0x3099622c84701e4c, 0x1c2776e95f57adc3, 0x0d49fb9b66f0da61, 0xce6bc32e95a49243,
+			0x0000000000000156,
+		},
+		y: fp521{
+			0xe4c23b963adb5e07, 0xfb948d00fd811538, 0xe1b0ccf557c88bf4, 0x1f936fee9a8e5fdd,
+			0x9560deaeac1c5e3b, 0xe34e3d33d72e0f10, 0x04676a851c36aa10, 0xd48d0c936d51f6ad,
+			0x00000000000001f6,
+		},
+	},
+	// 15P
+	{
+		x: fp521{
+			0xaa9adab626af2e49, 0x5ef4d7f19bde5c6d, 0x8ecdc6cb4c0f1fc9, 0xff3c3ade8e47e019,
+			0x08dc8e6713ede807, 0x296b4bda996f8947, 0x07dc7de6185a0504, 0xf820aac7e2a36a18,
+			0x0000000000000032,
+		},
+		y: fp521{
+			0x32ed1a3689c55c4e, 0xecab1a1a4050a3aa, 0xc9237ec86622355e, 0x4010a47111964b64,
+			0x6abf4831644ca385, 0x5d25b10834cba42f, 0xb1ef824b54dd6906, 0xb53e73269199f6df,
+			0x000000000000008e,
+		},
+	},
+	// 17P
+	{
+		x: fp521{
+			0x706c0376dc1fc4c3, 0x083b7c0bd4298885, 0x07fc6182157868d2, 0x43efa73ccdab409c,
+			0x2c3b0534f33a7f01, 0xda6b329279349284, 0x354cf74f134fd159, 0x09d05c3a322c91c8,
+			0x000000000000016d,
+		},
+		y: fp521{
+			0x23a15a0fc75f6c59, 0xfa9adce5be387323, 0x1073482aca615baa, 0xf8697b3abf8b56fa,
+			0x1efced2b277b6e85, 0x54c6b45615915099, 0x835bcca9a67b4c88, 0x6de088ea72576ee8,
+			0x00000000000000bb,
+		},
+	},
+	// 19P
+	{
+		x: fp521{
+			0xcda6637339219067, 0xf23c7f85920c6b50, 0x86ff67a18f392dde, 0xb3c9192abebd22cb,
+			0xf8fd1d984b1b1334, 0x3f7ff8566d9bc873, 0x3922dbbf03aaefaa, 0x43e07ca4616ad0d0,
+			0x000000000000001a,
+		},
+		y: fp521{
+			0xbacdf5768189a808, 0x2254848d34adfe82, 0xbbfad26c4f54fdff, 0x7cd8761ccc8b18e1,
+			0x93b9d6cb1ff975c6, 0xa566287771b2a2bb, 0x2eb3e9457715bde7, 0xae61ca124ba9ad92,
+			0x00000000000000f6,
+		},
+	},
+	// 21P
+	{
+		x: fp521{
+			0xf6e872ecb0469d0a, 0x96a1779ccb49e464, 0xad9bd64901c9d236, 0x84eca4bf576c13eb,
+			0xd4a312bf5726b151, 0x4c0ca91f3ace233f, 0x881345dcc61768af, 0x7d9e642a842cf69d,
+			0x00000000000001e9,
+		},
+		y: fp521{
+			0xfac688275f53e3ba, 0xb0f305e21e4e5d26, 0x62be4d878bb24e65, 0x4adf5a1d1b9631f7,
+			0xfbefba0d29ed2419, 0x360c0514b7b5d7d3, 0x31741e002ce28c9f, 0xa416096d3e2f2067,
+			0x000000000000019e,
+		},
+	},
+	// 23P
+	{
+		x: fp521{
+			0xae6b82c9d75ca405, 0x0350e352f67157a9, 0xf103f0f8d613572c, 0x57f4f1fc74d2f629,
+			0x880fb7c1fe4d7ad8, 0x41248691a6866ea2, 0xc7773d1cdd3c2fd9, 0xbb6d8e56762faa3d,
+			0x00000000000000c9,
+		},
+		y: fp521{
+			0x003cb25ea09794e1, 0x2b6c0ae1a9b7d068, 0x61109da22854691c, 0x7a1e13d93dd83ee8,
+			0x07084219b0f74bfe, 0x195a18b23c22616e, 0x8e3ebacffc48682c, 0x03345142c7e03423,
+			0x000000000000009c,
+		},
+	},
+	// 25P
+	{
+		x: fp521{
+			0x70553a131b5716a6, 0xd89fc612305fb856, 0x93f20f0758e12e9f, 0x26ab743f1553247f,
+			0xa5548c2b48be1b77, 0xd88e3b96462a4ccc, 0xe90039881328d883, 0x1a51ac3a537aeffc,
+			0x000000000000010c,
+		},
+		y: fp521{
+			0x183343f73a45c644, 0xb6a192de720263c1, 0x7af76f152a339735, 0xd068f11b85352cb9,
+			0x536f71aa1ab97de0, 0x63fb3614440a8b33, 0x9f8ad491f5801150, 0x9d7115147c05db06,
+			0x0000000000000023,
+		},
+	},
+	// 27P
+	{
+		x: fp521{
+			0x45d80dcfb7e0863e, 0xb41abb2180b8016b, 0x1e590ab022497622, 0x41a89a9d76d0698b,
+			0xa6c059091ed38cea, 0x1619f9a8c3f09ac2, 0x37c39ca9ae8727c3, 0x53b1526a75c44f32,
+			0x00000000000000db,
+		},
+		y: fp521{
+			0xa03c565c14005373, 0x59f9f357652ec85b, 0x97231fff384ea2d6, 0x2a09dc3a7a545dab,
+			0x008f7d248f6c975e, 0xdda52b7bc70d0d91, 0xfd4fe8c9e2e7a961, 0xe2f2c14200d60351,
+			0x00000000000001f0,
+		},
+	},
+	// 29P
+	{
+		x: fp521{
+			0xee5d319135b252da, 0xcb161c93b0c76d12, 0xcf799ec2caea8fb6, 0x48a001d5c7423ffd,
+			0x982e54de0343b6b9, 0xf6145f79c7bb3250, 0x2ffcfa2898f43132, 0x3ed9e0fdfe5b2d04,
+			0x0000000000000014,
+		},
+		y: fp521{
+			0x1846bce9f0be1fd0, 0xde8c3ddde2410d06, 0x1f833cc93e1375df, 0x85a8613996b83698,
+			0x5bdf0710ca96706c, 0xfe5ae7af5bcad69a, 0xc10119f7c7e5b220, 0x3402d664ada0dbdb,
+			0x00000000000000cf,
+		},
+	},
+	// 31P
+	{
+		x: fp521{
+			0x01b63a64833cc3c3, 0x09112be87060b544, 0x9106e7daf2447124, 0x14fe0a60d604e6b5,
+			0x366b2aa1d48264ea, 0x30b930541e52f3b9, 0xbedaba2c4b2be153, 0x0a80352c66db983d,
+			0x0000000000000033,
+		},
+		y: fp521{
+			0x8cc9ec08ed515299, 0xc565233be39cbc96, 0x31d50decbe3daa18, 0xc809cd4346572661,
+			0x187bb5c6192268a3, 0x030577db965f68d5, 0x7645aa0319478864, 0xc3ef84e09d10a518,
+			0x000000000000011c,
+		},
+	},
+	// 33P
+	{
+		x: fp521{
+			0x468a10657c25e3ee, 0x96af5170fe324f98, 0x6152f62c8c359768, 0x8d74d28cd2bd529d,
+			0xf855edf53f15bbf7, 0x09f9c0a10d3a11b6, 0xc7290436a415f493, 0xf3463b75a71e3b7a,
+			0x00000000000000b4,
+		},
+		y: fp521{
+			0x269f90fac23195b7, 0x79b5f4ad1ee9f08e, 0x9d4477feae1145ac, 0x5f805eb90b60b6f5,
+			0xf973b2b765f9e89c, 0x31cf00b3b4b0a562, 0x9d126a89d5f031e3, 0xb5bdbd1de43482b6,
+			0x0000000000000031,
+		},
+	},
+	// 35P
+	{
+		x: fp521{
+			0x8c3770c01d5d5258, 0x6c6e081ce4e66beb, 0x675497fb0a5aee64, 0x11f72530c3047b59,
+			0x95da4036427ed3a0, 0x5fe253c05ab7cb9c, 0xc95133d3215ac9f0, 0x3c1cc5b1034f05d1,
+			0x0000000000000185,
+		},
+		y: fp521{
+			0xfa306f4b41fdab1f, 0x269f58e9f7920f03, 0xcbef5d26da113205, 0xa12e1092917734f3,
+			0xa84d588bc5e96380, 0xe7a313cb2c8d18ed, 0xdfd28849b65aec2d, 0xe60dc1a18f86186d,
+			0x00000000000001e2,
+		},
+	},
+	// 37P
+	{
+		x: fp521{
+			0xb7a513d929b0860c, 0x39f8d626acdaabd1, 0xf9463273b5723c01, 0x02072c7137704c06,
+			0xa4dea22bb1d1442b, 0x2114f6857357ceab, 0x33e1ae2eab60eacf, 0x389cf3cc1a96f6ac,
+			0x000000000000015b,
+		},
+		y: fp521{
+			0x01d42dfb86c080a2, 0x4b1895fcc1ca7d95, 0xe198d25f202b4c41, 0xc0dc2e2b24cad02b,
+			0x82ee5ede1238e41f, 0x2c9318bed35bcc89, 0xc97f2737f90c32fc, 0xa08625cb12793658,
+			0x000000000000015b,
+		},
+	},
+	// 39P
+	{
+		x: fp521{
+			0xbe49282e3d047eeb, 0xb385b8208cf719db, 0xbcada7d0ea4041d7, 0xf15605fa1a72fd18,
+			0x5392b58662c31350, 0x391c6cfeb11ace37, 0x814f4b30d24dadc8, 0x3aa9320931ab524c,
+			0x00000000000000b0,
+		},
+		y: fp521{
+			0xd3040a585bb71ccd, 0x55ec6e6e309145b0, 0xb1790973a5b4c720, 0xd551e57920aca273,
+			0xa40c6e2cff5cb412, 0xa66e05892063486d, 0xce3449bdd543cb0a, 0x71b0154d2035de9c,
+			0x00000000000000ee,
+		},
+	},
+	// 41P
+	{
+		x: fp521{
+			0x0f5d68503b5e7a17, 0x7b4700674a93121c, 0xfd6efed0d2916615, 0x67260461eee414bd,
+			0x640b5c98f57a4ae6, 0x5342dc2f91c32530, 0x12a95291f25944cc, 0x53b1f5fece944184,
+			0x0000000000000112,
+		},
+		y: fp521{
+			0x67ec25a5b5c61578, 0xf675f5bb07608147, 0x5efbaeebac73f9d1, 0xed0295372a8fd147,
+			0x88d9cf0708f67993, 0x1911ef410d916410, 0xf3d5acf2c074f72b, 0x769b7909a1e51ccd,
+			0x000000000000016a,
+		},
+	},
+	// 43P
+	{
+		x: fp521{
+			0xbf63005228597b16, 0x90389f18419127a4, 0xe528f85b17ef703e, 0xeed1d3a73d4d9ec2,
+			0xcb7e3356bd76be9b, 0xd7c7787ca21c7f51, 0x503833c551760a0c, 0x7f6aa8116c82a26c,
+			0x0000000000000072,
+		},
+		y: fp521{
+			0xdfc2aeea87c2679e, 0x6944ac14833b41d6, 0xbd4a76dc97bb5b44, 0x78c996b015aa3e70,
+			0xf633feb04c43cd8e, 0x66bd181bc0cf8d05, 0x92133bf48ed8e8a2, 0x4d75fbd41e3d51df,
+			0x0000000000000043,
+		},
+	},
+	// 45P
+	{
+		x: fp521{
+			0x0d125a7461ab2827, 0x625196f63007e4ae, 0x87e14aa303db35b8, 0x6cee4750d672b81f,
+			0x33bc8c4dbefc50f9, 0x603d96faf1f8cb94, 0x103d2c7bf9460a3e, 0xdc3cacb5c7ff95e0,
+			0x0000000000000129,
+		},
+		y: fp521{
+			0xd4fa07b26d433634, 0x27ca4c53e6800481, 0x709a31faec859bab, 0x42f376cf43cdf194,
+			0x54dc6ad9401756d7, 0x209aac250dde6da0, 0x3d8ac31521193844, 0x3ea6b5610f98a4fe,
+			0x00000000000001c2,
+		},
+	},
+	// 47P
+	{
+		x: fp521{
+			0x88abf8c7e241c122, 0xb397ed31e4d5eace, 0xb3052b509868f65d, 0x564e773ad1d4e26e,
+			0x591c0c32505cf491, 0xfc4b919883638ee8, 0x1672727f138a019f, 0xafce7a841f661ac4,
+			0x000000000000017e,
+		},
+		y: fp521{
+			0xeb04be5772195c9d, 0x9de9832e978ec870, 0xf388e520accf8833, 0x5375dbb07a3c6c12,
+			0x4bc93b0405c824c1, 0xc476753f993b7832, 0x4d302f83c9a4904f, 0x4eda14865ddaa7fb,
+			0x00000000000000ff,
+		},
+	},
+	// 49P
+	{
+		x: fp521{
+			0x56a972e63e8f02e3, 0x6506465a2824f7e0, 0xd4392c6d58d62602, 0x2148ec6427171a2f,
+			0xf5b5e1516fa97b14, 0x1cfac0dc5888ef54, 0x69cea2e1a3d724cc, 0xabe5d20531ddd5d0,
+			0x000000000000000e,
+		},
+		y: fp521{
+			0x5a05772559502435, 0x8e85cdfa35164442, 0xda25cb1fdfd85c45, 0xe10608796775db95,
+			0xc1aeecbbaaa1eaba, 0xbf583961ceced45f, 0x8dba4f7233c3bf2d, 0xcb8a04d80dfb970a,
+			0x00000000000001bd,
+		},
+	},
+	// 51P
+	{
+		x: fp521{
+			0x825a0e56e94789e1, 0x6ed46c8b7ffde64a, 0xe07dd344f78ebdf1, 0x6f8990d70d882a65,
+			0x136176ff357839c8, 0xdd1dd18b65fcdf75, 0xe67efcfadef1a7f4, 0xd1012ec9db40a2f9,
+			0x000000000000000a,
+		},
+		y: fp521{
+			0x3d9c73043cf28106, 0x0c475ce22f6eae04, 0xd28300555f69d44f, 0x06424e1b04b71d75,
+			0x9e4bde15b45d6db0, 0x671272d8ba56f5cd, 0xbca96ec3c790e240, 0x2b1ceca98ca169fa,
+			0x0000000000000112,
+		},
+	},
+	// 53P
+	{
+		x: fp521{
+			0x8350fdb8924657b9, 0x0b3142b426665b4c, 0x95840a9ed4e4c404, 0xc769d7ac0fdd27f0,
+			0x6227108731b904d3, 0xdb93f417b7ca4080, 0x6ffc3445f48b44af, 0xfbbd9e87fe988183,
+			0x0000000000000149,
+		},
+		y: fp521{
+			0x30c14fa3f325863a, 0x357eb6ed6eea063c, 0xc602051efde255f7, 0xd987b53d4ef1da67,
+			0x58a7efb27a81e79d, 0xb49aa1bf4029e886, 0xc3bee39feef21610, 0x3a62b08d012ba884,
+			0x0000000000000195,
+		},
+	},
+	// 55P
+	{
+		x: fp521{
+			0xaf12d4bf12db4c43, 0x274256be675ea387, 0x4d3ff4d5a5ed4b56, 0xf35f0ba4358f05aa,
+			0xd0c66549c8be5693, 0xceb2396a014746d0, 0x54be581f4fece853, 0xe25aa8f20aae36d6,
+			0x0000000000000073,
+		},
+		y: fp521{
+			0x790d617953fa0518, 0x753d0cb6691851b7, 0xc37d01d11803504b, 0x266b4b04f1a2da9a,
+			0xaa885542c4ae2d04, 0x5b007d88a53edd70, 0x9730cfa2188d0b50, 0x109c2f53c83d0d3d,
+			0x00000000000000d7,
+		},
+	},
+	// 57P
+	{
+		x: fp521{
+			0xf13714f0f9ff3cf2, 0x281a3a9985274d09, 0x9041b9647ffcae42, 0x597b37eade325c52,
+			0xfc2579805def488c, 0xc2f79e36bbd290dc, 0xfd2d46f7f39736df, 0x702231ed3af7d92f,
+			0x0000000000000081,
+		},
+		y: fp521{
+			0x479d3a23f3353f6f, 0x9919d6bfbfece212, 0xb9d4a498c863152a, 0x35251fc102b5494f,
+			0xf51a412986869dec, 0xc4db173f59c86ff9, 0x7bd89e86e9657cec, 0x95b4039f73626a7c,
+			0x00000000000000cc,
+		},
+	},
+	// 59P
+	{
+		x: fp521{
+			0x358d6bad152b4c61, 0x4431df4513758b34, 0x4565d4292761a390, 0x348d481a16b21b9d,
+			0x9002ab49e4d89a26, 0xccd572e149a61114, 0x56e3911e2ecc480c, 0xb9831e301a16f94f,
+			0x00000000000001b3,
+		},
+		y: fp521{
+			0x17061fb2fb051f9f, 0x8d67a5501e29c0fd, 0x61fec161c67d7ff2, 0x5c64feafeeeb27ff,
+			0xa2dc67c6225e41e8, 0xfa71179779e5b77e, 0x3f8ad246d9b30481, 0xda6d85865057f8dd,
+			0x0000000000000121,
+		},
+	},
+	// 61P
+	{
+		x: fp521{
+			0x50c5551312804501, 0x20e5a9ec67e7e1bb, 0x6bca95d68b38aa1e, 0x9cd2f3fe72cd3d99,
+			0x8bb8c9e08d3bee05, 0x8d266b9258df458b, 0xc7c5b668beadb306, 0x2e49653e39950e3e,
+			0x00000000000001a1,
+		},
+		y: fp521{
+			0x25c54f7e71b02b19, 0x125c77fc66a50bb0, 0x95ab791774e1ff2f, 0xd4541a7fb01d7d01,
+			0x5001fbc6aa5bfaa9, 0x5ff604bd1d76ac63, 0x963cd7a42ff7a116, 0xd983ccb6951cce43,
+			0x0000000000000042,
+		},
+	},
+	// 63P
+	{
+		x: fp521{
+			0x72f04c9d4ed91903, 0x4687adf5b7e74444, 0x2f3ad7be812bb856, 0x2f631ccb423ac313,
+			0x229d74cb30984709, 0xe0f3faa7f26a8d84, 0x470e510dad7fd06e, 0xbff417bccd658537,
+			0x0000000000000022,
+		},
+		y: fp521{
+			0x0ad4d1f861065e81, 0x5674e8ad69501edd, 0x41d7b2bdadfb3251, 0x5e09fbc7c1780389,
+			0xc002e9edee760c4b, 0x032caf461a9d5111, 0xcb0d4f04994320a0, 0x0bb6c0d644d902eb,
+			0x000000000000009c,
+		},
+	},
+	// 65P
+	{
+		x: fp521{
+			0x042233c02158942d, 0xa25d103a151d0cc8, 0xabdbb28aabbc7c22, 0x06684054a94a5add,
+			0xf16bc5dd30d011c3, 0x88c71eac713d865f, 0xc64924fd53bde09c, 0xe8efce67ab77da65,
+			0x00000000000000e8,
+		},
+		y: fp521{
+			0xcb7d2a42ddeaf7b2, 0x19a5d2924bf564ab, 0xb444143012189fee, 0xca352791c5bbd257,
+			0xa67cef5fe2072daf, 0x4b69ccbf35ad267f, 0x44fde88b7de5a5c8, 0x47442aa83137ec7a,
+			0x000000000000015c,
+		},
+	},
+	// 67P
+	{
+		x: fp521{
+			0xb5225d112a3878fa, 0x1c8c1e2046f13484, 0x6eebb1836570e2cf, 0x888b5d1a3c66c805,
+			0xbdf08bdfdacb8399, 0x4891532bcfcd2461, 0x7f21cfb9a0b90fa3, 0x025000f1373e6880,
+			0x000000000000018b,
+		},
+		y: fp521{
+			0x8483d4cdce1d1a4f, 0x850cc8bbc82486c9, 0xc89a3e3e5b45f53a, 0xd9a388a3159bac36,
+			0x7d3ad40452a22a0f, 0x38c1bacf8d85e744, 0xb160a4d0bc98a3f6, 0x54586be153ebb43d,
+			0x00000000000001d1,
+		},
+	},
+	// 69P
+	{
+		x: fp521{
+			0xdb07329c2c787b92, 0x1048c42cd26fa954, 0x5e55a0e3dfc7b21d, 0x2ea998422d2fdfb6,
+			0xacadd9ce41ee035d, 0xa8c1d837a69d593b, 0x0b3850952f8d7fa4, 0x952e5f6ba52c14b7,
+			0x00000000000001c5,
+		},
+		y: fp521{
+			0x8bbebba2571559f4, 0xa4f7819f2217a18f, 0x6cf5c6f66a05cddf, 0xe06fefef6e1dc73e,
+			0x54ae9aef40197cbe, 0x5b9445c54be18080, 0x575fafe3740f3cec, 0xc45e8baaac03f12e,
+			0x000000000000008a,
+		},
+	},
+	// 71P
+	{
+		x: fp521{
+			0xbdc1236b44678805, 0xf67d24b7ce86a592, 0xfe20a496f88c707f, 0xc04458e2349791c4,
+			0x396f96291799db24, 0x7d54779b370316ae, 0x3a30148bb2f43868, 0xada9af16f2769e84,
+			0x0000000000000044,
+		},
+		y: fp521{
+			0xf54f08f0c6c34bee, 0x17ff4007dd479d24, 0xe622fce473569036, 0xa092cb50c470d9ce,
+			0x6ff3572bba2af49e, 0xb863c042db56e99d, 0x8ac9ae33c4f43c6e, 0xf3e3054bf48fa61e,
+			0x00000000000000fa,
+		},
+	},
+	// 73P
+	{
+		x: fp521{
+			0xef5d4e4399ba5cf2, 0xa25e0b5437438e75, 0x198bc5ffc39dd80d, 0xb1c1011886e7ccf9,
+			0x15a051c79c4ce8f1, 0x413eb453c56dcc49, 0x1b04d92592688449, 0xcffdb0299c8384ef,
+			0x00000000000000f7,
+		},
+		y: fp521{
+			0x63803addd5ec572e, 0x32cc9bf89ea60e3b, 0x2f92d35612aace27, 0xcf56810841802f27,
+			0xc257af5927879248, 0x5fec3f777d473399, 0x51da1f302ab4a11a, 0xbdc255f693a95e93,
+			0x0000000000000179,
+		},
+	},
+	// 75P
+	{
+		x: fp521{
+			0x1f40fafebe397928, 0x45f2c3258897ebe6, 0x27be145b299fdc8a, 0x5576f57c6248e8fb,
+			0x16ccbc6203bceaee, 0x4afa47a41545dfd3, 0x136f1edacf8b72fa, 0xa66932c9e405ed2f,
+			0x0000000000000031,
+		},
+		y: fp521{
+			0x5d93cb1bcd214986, 0x0216f48b614ac2c8, 0x06a727fd7805c64c, 0x1738201efca5dcfe,
+			0x14843e560e04d5b1, 0x39d88c38328c62fc, 0xf8550af004a0686d, 0x69df593afbe3e69c,
+			0x0000000000000047,
+		},
+	},
+	// 77P
+	{
+		x: fp521{
+			0x16ae8b519229be1e, 0x8c044fe6c9cac80b, 0xeb0dea00c233e104, 0x76cd20df44203f51,
+			0xb0929ab5c629db23, 0xe42c5360d4ddc86a, 0x05739e35037dfe67, 0x3f1dce476c4a0d06,
+			0x00000000000000d7,
+		},
+		y: fp521{
+			0xf54e66028c234b9a, 0xbcb955381aa30139, 0xd0a7b865bf16f99b, 0x0f4a3795c678d9c7,
+			0x863ddd42d0a3fde3, 0x4533e96120b752a9, 0x8f19262b4d5e553a, 0x2ae8f8454a01adaa,
+			0x0000000000000016,
+		},
+	},
+	// 79P
+	{
+		x: fp521{
+			0x8bc0ac4673de06e6, 0xe791360a47902906, 0xf7b42c0adc412d3e, 0x5ad23d7388f31013,
+			0x7454e2d5d910cb4f, 0x91a98f9a7455999a, 0x3c88d5d653d27f89, 0x4b687c1a4e4bfbf7,
+			0x00000000000000b2,
+		},
+		y: fp521{
+			0xd95e291594d2a30f, 0xb86a14775f752f6e, 0xedca41d8046ac1d8, 0x35822d0d0c950a54,
+			0x186f26ab0dbf4cfc, 0xf0cc6ba8041d1c41, 0xc3cc2ebb554e1cb5, 0xdb6363552cc57c70,
+			0x0000000000000141,
+		},
+	},
+	// 81P
+	{
+		x: fp521{
+			0x70746ee8e00a269d, 0x8f2d19ca0b14ce45, 0x392bad05814d3d92, 0x491abbfb5852398c,
+			0x4084fb38b8913dae, 0xde229c0feb95f3f7, 0xe64216f226933235, 0x5de6bebb6b6b1a34,
+			0x0000000000000193,
+		},
+		y: fp521{
+			0x80dc0ebb83deb609, 0x31770d90b065dc87, 0xf5c9d8644387bd33, 0x1d825a6aefe541a1,
+			0x457f601b430b70f1, 0xc8154c07498b975e, 0x9f2a0e4e7371a94d, 0x61adeb4f48e03387,
+			0x0000000000000026,
+		},
+	},
+	// 83P
+	{
+		x: fp521{
+			0x333b88cd22804861, 0xa1cff38030d522a8, 0x2ca1d35b61758b9e, 0xfccee51c23c41336,
+			0x56d93dd03311a8c4, 0xc27c9b2f33e0425e, 0x71c0777c54280a5a, 0x24c3d2e2390a497d,
+			0x000000000000006c,
+		},
+		y: fp521{
+			0xd486990f0ecfd907, 0xceae5e499d8fa3d9, 0x7791bd0554dfcc0a, 0xe209e2bf779728f8,
+			0x833b88fa0d4ca873, 0x195a86f71f1c9421, 0x22d7d3dcfdced5e8, 0x08f9e83a75660109,
+			0x00000000000000fa,
+		},
+	},
+	// 85P
+	{
+		x: fp521{
+			0xa1b605f6872b4a1b, 0x3ef03d68059dbe81, 0x507ad1f89ad632f0, 0xfb3bdfc460c762d3,
+			0xa797d978b8bb9adf, 0xc13ca409232782ab, 0xee0d6101b69b019a, 0xeea09dc76d894753,
+			0x000000000000016d,
+		},
+		y: fp521{
+			0xb1ed5864969f9847, 0x10e666f13836b0bd, 0x1e7608111f39116c, 0x295720dddfa909f7,
+			0x5e2cb9e1644dc581, 0xeba8c710773e9da8, 0xed8bb51a13a41793, 0xeef6434c976a7da9,
+			0x0000000000000094,
+		},
+	},
+	// 87P
+	{
+		x: fp521{
+			0x6a255a507a5eea47, 0x82a872c3674466d9, 0xc80fbbf0aedb7265, 0x2057bb7f46a78fe6,
+			0x3f539dfbee8686c0, 0xe5721e4d96207699, 0xe1a377b8df77b19d, 0x7c2c484641fde1fa,
+			0x00000000000001f8,
+		},
+		y: fp521{
+			0x7937c6568e561dbc, 0x9fa8594fd026daef, 0x8f3c15a33fdcdf49, 0x8c63c49b861ef05a,
+			0xb3d4f5551cead534, 0x905093cdcfba2eb6, 0x9a050c5fcb31abfa, 0x3330b4e8ba6d837f,
+			0x0000000000000155,
+		},
+	},
+	// 89P
+	{
+		x: fp521{
+			0x6c952cdf56feb873, 0x477c7526ad7ce29f, 0x3f3741d1a901aeb5, 0x8754f5d203d537f6,
+			0xd6def10af5040edf, 0x7b74bf8b026a092d, 0xe9f82ecfa63f6a57, 0xa2c8e97aeaa5337a,
+			0x00000000000000af,
+		},
+		y: fp521{
+			0xe890cd7e077ce96d, 0x3b38e949356dbefb, 0x47b593e4cbdd715e, 0x3d72786f1af48355,
+			0x72f8583f1dd271d0, 0x9307961a75d0b3d5, 0x6704df1e740d6411, 0xef50222f5bafdbd2,
+			0x0000000000000001,
+		},
+	},
+	// 91P
+	{
+		x: fp521{
+			0x20fd5a624ee55ae3, 0xade46cb972833f12, 0xe22d9b2d899d6ef5, 0x00d85bf48508498c,
+			0x23d4a1882af1b561, 0xeeb03b53daf2ffd8, 0x2b7a96cf62e628d8, 0xaf6a911e88acdba4,
+			0x00000000000000c0,
+		},
+		y: fp521{
+			0xb8196729b248e00c, 0xea3fc5f3a6954739, 0xfceb99afa027c7c1, 0x596d76cdd988b236,
+			0xefe302bd5ae007c4, 0x79f0b04441ae941c, 0x94d8111c93f83622, 0xdec37fac67afdd04,
+			0x0000000000000083,
+		},
+	},
+	// 93P
+	{
+		x: fp521{
+			0x03bc4d26f8870de0, 0xccf1713c973e22f3, 0xcc63bd7bc9d4ba7f, 0x3c46c70c837ee3a9,
+			0x6a947780623e1d2d, 0x33c331734baad535, 0x5e8a2593be66f30a, 0x4a69094b1e9e8673,
+			0x00000000000001f5,
+		},
+		y: fp521{
+			0x87419cd04c398638, 0x4b5647d85adb680f, 0x4bc7182d8dc9fad4, 0xa71e92cb626c6c55,
+			0x718deb7f4fc12c41, 0xcffafd8c6e33e331, 0xd1b6b0421bc44817, 0x662be5494d28d2d0,
+			0x0000000000000088,
+		},
+	},
+	// 95P
+	{
+		x: fp521{
+			0x54bf9575000232d6, 0xdc4a27e702398786, 0x10a68cec007e7fe6, 0x7702dc344c8bcc87,
+			0x7451070eb48fe087, 0x0e2a207d70cdad33, 0x048f9b59e6461ab2, 0x130ab98b9c6d8d21,
+			0x0000000000000181,
+		},
+		y: fp521{
+			0xf7bb811f6f543d1b, 0xac05d1d5f508a687, 0x87c7f3d0d1d49a62, 0xc4a741cfd68646bd,
+			0xc474dd24488a280a, 0x10344d702b761f50, 0x79cd4690eedca642, 0xd4da52d8102005d2,
+			0x00000000000000dc,
+		},
+	},
+	// 97P
+	{
+		x: fp521{
+			0x502e58b6f4915401, 0x48e083401fd215d2, 0xb1c0ec0e4b1af53c, 0xfafad6368a6a8feb,
+			0xdcd424389eb1d264, 0xfff0e89f0acff6b5, 0xc083d422b76f388d, 0xf84c734e104b92de,
+			0x0000000000000199,
+		},
+		y: fp521{
+			0x79b8852f0c30bb24, 0x5e161aa695ba425a, 0x4dcba5e3efdc88b3, 0x9b78e1274e38df2e,
+			0x8ce4107eed1be8b9, 0x3e2f2a8933a4c13b, 0xffe1d97ac5e89867, 0x205ceeb422eb9912,
+			0x000000000000011e,
+		},
+	},
+	// 99P
+	{
+		x: fp521{
+			0x8e62bda3014e2d02, 0x1bb9f6255db4228a, 0x485a56be7b5fb863, 0x8782028c37f92f4b,
+			0xac78d047109f6b66, 0xd4e5e143b4a8480b, 0xdd22b5a29e159538, 0x2ed4373b056c77d5,
+			0x000000000000000d,
+		},
+		y: fp521{
+			0x7c332fd464898fda, 0x5dc894a518d50f41, 0xef6026aee049ca80, 0xf093447dafa0182b,
+			0xe3a63bbb9654e1f2, 0x46288594a2f0fa18, 0x74a701308598f87f, 0xd91eae3c8ca17dd2,
+			0x0000000000000139,
+		},
+	},
+	// 101P
+	{
+		x: fp521{
+			0xfa5737e56dbb4308, 0x8686a37467924da7, 0x8cb8306bed9ebb4a, 0x9bc51f011b9eae23,
+			0xbdadef0fb5a82c64, 0x59dd010fd33e49ce, 0x29126a0a93136238, 0x5d1003d34ebb74b8,
+			0x0000000000000050,
+		},
+		y: fp521{
+			0x0fa51ce05a0fe5f2, 0xc75958b172c3232a, 0x0079a961edfbf205, 0x49f9abf23c8da790,
+			0x2c6b540b14071315, 0x7e6140c762d7fad1, 0x6bab3c5e9b7a0cc2, 0xbd03547d0a67f446,
+			0x000000000000013b,
+		},
+	},
+	// 103P
+	{
+		x: fp521{
+			0x730d747598a98137, 0x2d8e1db53857e022, 0x0269ebe3513cf86a, 0x5f794f469b624f61,
+			0x9d1d75467558b229, 0x528c6584a2fac0ed, 0x7b333bea22b62903, 0xd7bfab025eaa2744,
+			0x0000000000000018,
+		},
+		y: fp521{
+			0x8341dc87ba54e92a, 0x1327ffadbd802fb2, 0x607a45f5fde4552f, 0xe648e5340010d7ca,
+			0x91e511c51b3a7392, 0x586fcd54673545f5, 0x8374470d8d7bc3f1, 0x1ac1b406c386aaee,
+			0x00000000000001f3,
+		},
+	},
+	// 105P
+	{
+		x: fp521{
+			0xfab7760507869109, 0x1c6a958f8bcaf842, 0xd8f9171d4c2c091a, 0x0f862fafced4814b,
+			0xd4eea08331c13d90, 0xa4f594b2da6852d1, 0xeae46de6be4c070f, 0x67939ec6cdf9094a,
+			0x000000000000018a,
+		},
+		y: fp521{
+			0x4b1fdb97e24b82a1, 0x063473c923b37015, 0x51d44fae1f6f7188, 0x78faa6f07c942daf,
+			0x580dedf9e72db8a4, 0x929e8ecc88046ccb, 0xcdcffdbd938b0aa0, 0xeca085b262704215,
+			0x0000000000000035,
+		},
+	},
+	// 107P
+	{
+		x: fp521{
+			0xc76b0ae385379df2, 0x0bdc565d38b602cb, 0x1d9c8b406cae08d5, 0x001932dee68d92c7,
+			0x5ef7144280ab8616, 0x7107b22ec12df877, 0x17d2c31c693cecb2, 0x1b80a8dec246acd3,
+			0x0000000000000038,
+		},
+		y: fp521{
+			0xf67837551915d5c2, 0x840d3a6a6c57c891, 0x2083b14da61f70f1, 0x0ba9bf8edb20713b,
+			0x298fdc02c2381eb9, 0xebb3a2f51271e025, 0xae0b703e7ea06276, 0x8f26867258d55325,
+			0x000000000000008f,
+		},
+	},
+	// 109P
+	{
+		x: fp521{
+			0x768163f9d8b9c88a, 0xf5ab7d35e0b21cc9, 0x44bf63ebf5bebbdb, 0x424fe24f026b133c,
+			0x89d9f9afef0ae52f, 0xbd3fd3f546449952, 0x9c23b202013858e2, 0x0e60372bf62d63fe,
+			0x0000000000000020,
+		},
+		y: fp521{
+			0x163f6a7ff98fd34a, 0xd2a444ab9b88ffe0, 0x4594b1c98c148792, 0x255f50d51c8fe658,
+			0xaade7b2a28b5df41, 0x47a9676b21f363e1, 0x93417b60fe77bb5e, 0xc592f50134ee3508,
+			0x00000000000001c8,
+		},
+	},
+	// 111P
+	{
+		x: fp521{
+			0x2b0655b7284ebb60, 0xf75f675744f37464, 0x8ffccada2f55ae3e, 0xae7822c09d4644cb,
+			0x3acfb4342a63c848, 0x1f4dbb6adf8b2a0e, 0xda7cf288b28a42a9, 0xffaef75caf33f69d,
+			0x00000000000000c0,
+		},
+		y: fp521{
+			0x30a60ecc1d58f9d6, 0xbcb4bd451569cf81, 0xab1b890ce068bff7, 0x85a7f6e41ef08969,
+			0x90c3254a14a32f48, 0xde77a876d838adf9, 0x9a68da6817dd43a0, 0x209d760a31e3e551,
+			0x0000000000000088,
+		},
+	},
+	// 113P
+	{
+		x: fp521{
+			0x7a2ddfc049c3d8f5, 0x123d7dcd2e1cb03e, 0x54e2e1dc06531a64, 0x68a493512c8500e6,
+			0x0f56979dc7c8a05e, 0xb1b6522c8c177391, 0xd8bc60774598aa7c, 0xa32e62f09052599e,
+			0x00000000000000df,
+		},
+		y: fp521{
+			0x1fa0ebca215d48f5, 0x7c0731f88de0f9ca, 0x3ee6b68394186f90, 0x8e2bcdbb698d247a,
+			0x967a1c1bb89ce1e2, 0xa81e37a011cf04bd, 0x3ec2c82322a4aced, 0x91ff25734f34f057,
+			0x0000000000000189,
+		},
+	},
+	// 115P
+	{
+		x: fp521{
+			0x24365e9b467b6ad9, 0xe109d10762b74831, 0x7fca899302f1876d, 0xedd7dd771ec412ad,
+			0xecdf141f9628f4e6, 0xd4d2a972d85a41db, 0xd2ab70e42f07f4e9, 0xcc410080e651162b,
+			0x0000000000000068,
+		},
+		y: fp521{
+			0xdd3ad3228f74d1b6, 0xf79cbc62d2f18c83, 0x562e06885ef7bb6d, 0x6107bac8afde0ead,
+			0x5c7a2eae3f95a53b, 0xc3c2377826075f9e, 0x43eafbacf9f3036f, 0x9f9e6d03e8296401,
+			0x000000000000018f,
+		},
+	},
+	// 117P
+	{
+		x: fp521{
+			0x9d8b1b6f99166f1c, 0x3ea009676f040516, 0x95258976bd6dc981, 0xb198818569619a61,
+			0x64bddc9e01706717, 0x92a26ef7243a0530, 0x33dc536aa8387319, 0x4c96707ffe6cff70,
+			0x00000000000001de,
+		},
+		y: fp521{
+			0x2f3347dc4c42977d, 0xf39580a61ddb6a96, 0xccd98b28c87f24ec, 0x8940ffe8720b55a1,
+			0xf7ca5f6d02e11e55, 0xb6240d6b23a7c61f, 0xac5f1f3ada9a52dc, 0x0c3c6814c9c6f628,
+			0x0000000000000164,
+		},
+	},
+	// 119P
+	{
+		x: fp521{
+			0x1ede7655b9032705, 0xfc7673472ce3c9a7, 0x5b27fca791986576, 0x096cda427064e980,
+			0x88239db1f43aa2fa, 0x69fee80c7ab215be, 0x36c1ce29ba0160a4, 0x11b5e2f535b49598,
+			0x0000000000000125,
+		},
+		y: fp521{
+			0x4c1e70967a7a402b, 0xa39f6f32eb55d02c, 0x1681124e2a0f13b1, 0x31f50dcdae666465,
+			0x61f9519ed005dd90, 0xf41ed8d160808722, 0x2faad6687207dfa3, 0x8b44f3f7f0f530ea,
+			0x00000000000001b9,
+		},
+	},
+	// 121P
+	{
+		x: fp521{
+			0xa8a8d9f431b7ee88, 0x774cba32f901df93, 0xbcbd6727a76d81a4, 0x6eba9b05fdede908,
+			0xd49456fb0615e7ee, 0xc901652c24349864, 0xd6ae472e8843e7bb, 0xfd3716bc2a463ca1,
+			0x0000000000000000,
+		},
+		y: fp521{
+			0xc9306ded04b3dd64, 0xca358b2674bfefc0, 0xc6bf054c7dcd6d8d, 0x61d199e552666066,
+			0xf2548b31ed1045a5, 0xc177679c62a50454, 0xe348c4d9a8330c4f, 0x3705d4e0696bdda5,
+			0x0000000000000134,
+		},
+	},
+	// 123P
+	{
+		x: fp521{
+			0xe1811ede536dc3f6, 0x37176c1a6407b790, 0x724ca7bba1d39ad4, 0x887a331fb70fb82e,
+			0x205426f9ceae5b3c, 0x5d1b63c574bfb554, 0x26c5b4c40dc76625, 0xd592f28a76e42af3,
+			0x00000000000000e1,
+		},
+		y: fp521{
+			0x8fdd41853381eea3, 0x1de43e1dcb30c7d6, 0xebd791dfd18fec1c, 0xaf475b42084847d0,
+			0xf4a3dbd87730c70e, 0xf35faab9f67e542c, 0x8de4d5a61be141fa, 0x58d8ea5c4fe6bc81,
+			0x000000000000004e,
+		},
+	},
+	// 125P
+	{
+		x: fp521{
+			0xb7c64531f821fe97, 0x0e9faa4b18172a0c, 0x1aeb91db0755d023, 0x297e7d53154b9317,
+			0xcf256768b9a8b9c3, 0x9b31ae0e69ba51ad, 0x474e7f19cfbb1def, 0xd6b930ecc5caeb1b,
+			0x000000000000006a,
+		},
+		y: fp521{
+			0xf8db03332c6c52fe, 0x71e64c46dfb932c6, 0xfc9ad05c008b973a, 0xbdc963dbe6cb2a22,
+			0xc2a47e5fd7477bcf, 0x8aa4e2f44856e5b1, 0x124e5523b67439a8, 0x9698212a6b6e9f9c,
+			0x000000000000003e,
+		},
+	},
+	// 127P
+	{
+		x: fp521{
+			0xc4d12623f14d63aa, 0x802b2a9f90817a5e, 0x1bb41937684c1430, 0xbb1b1aa510ded25e,
+			0xe59353725e47f338, 0xf72cfa6148b82132, 0x6e1bc0d5cfec5527, 0xae1d33cadf677b55,
+			0x0000000000000055,
+		},
+		y: fp521{
+			0xcf9003e72e984492, 0xdaca24e4cf0e3720, 0x129fd08ab24476ad, 0x46ffb5b86a9a763f,
+			0x2b6fcb9840d7a281, 0x45fd3877e9afcde9, 0x4300c8a98295dec3, 0x14cd5af3e34839f6,
+			0x0000000000000124,
+		},
+	},
+}
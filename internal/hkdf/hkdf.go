@@ -0,0 +1,35 @@
+// Package hkdf implements the HMAC-based key derivation function (HKDF) as
+// specified in RFC-5869.
+package hkdf
+
+import (
+	"crypto/hmac"
+	"hash"
+)
+
+// Extract returns the pseudorandom key HKDF-Extract(salt, secret) using the
+// hash function h. A nil salt is equivalent to a string of zeros of the size
+// of the hash output.
+func Extract(h func() hash.Hash, secret, salt []byte) []byte {
+	mac := hmac.New(h, salt)
+	_, _ = mac.Write(secret)
+	return mac.Sum(nil)
+}
+
+// Expand returns length bytes of HKDF-Expand(prk, info) using the hash
+// function h. The length must not exceed 255 times the size of the hash
+// output.
+func Expand(h func() hash.Hash, prk, info []byte, length int) []byte {
+	mac := hmac.New(h, prk)
+	out := make([]byte, 0, length+mac.Size())
+	var t []byte
+	for i := byte(1); len(out) < length; i++ {
+		mac.Reset()
+		_, _ = mac.Write(t)
+		_, _ = mac.Write(info)
+		_, _ = mac.Write([]byte{i})
+		t = mac.Sum(t[:0])
+		out = append(out, t...)
+	}
+	return out[:length]
+}
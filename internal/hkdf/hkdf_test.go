@@ -0,0 +1,58 @@
+package hkdf
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+)
+
+func TestHKDF(t *testing.T) {
+	hexDecode := func(s string) []byte { b, _ := hex.DecodeString(s); return b }
+	seq := func(start byte, n int) []byte {
+		b := make([]byte, n)
+		for i := range b {
+			b[i] = start + byte(i)
+		}
+		return b
+	}
+
+	// RFC-5869 (Appendix A.1 to A.3).
+	for _, v := range []struct {
+		ikm, salt, info []byte
+		prk, okm        string
+	}{
+		{
+			ikm:  bytes.Repeat([]byte{0x0b}, 22),
+			salt: seq(0x00, 13),
+			info: seq(0xf0, 10),
+			prk:  "077709362c2e32df0ddc3f0dc47bba6390b6c73bb50f9c3122ec844ad7c2b3e5",
+			okm:  "3cb25f25faacd57a90434f64d0362f2a2d2d0a90cf1a5a4c5db02d56ecc4c5bf34007208d5b887185865",
+		},
+		{
+			ikm:  seq(0x00, 80),
+			salt: seq(0x60, 80),
+			info: seq(0xb0, 80),
+			prk:  "06a6b88c5853361a06104c9ceb35b45cef760014904671014a193f40c15fc244",
+			okm: "b11e398dc80327a1c8e7f78c596a49344f012eda2d4efad8a050cc4c19afa97c" +
+				"59045a99cac7827271cb41c65e590e09da3275600c2f09b8367793a9aca3db71" +
+				"cc30c58179ec3e87c14c01d5c1f3434f1d87",
+		},
+		{
+			ikm: bytes.Repeat([]byte{0x0b}, 22),
+			prk: "19ef24a32c717b167f33a91d6f648bdf96596776afdb6377ac434c1c293ccb04",
+			okm: "8da4e775a563c18f715f802a063c5a31b8a11f5c5ee1879ec3454e5f3c738d2d9d201395faa4b61a96c8",
+		},
+	} {
+		prk := Extract(sha256.New, v.ikm, v.salt)
+		if want := hexDecode(v.prk); !bytes.Equal(prk, want) {
+			test.ReportError(t, prk, want, v.ikm, v.salt)
+		}
+		okm := Expand(sha256.New, prk, v.info, len(v.okm)/2)
+		if want := hexDecode(v.okm); !bytes.Equal(okm, want) {
+			test.ReportError(t, okm, want, v.ikm, v.salt, v.info)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+// Package nist implements the AES-256 CTR_DRBG used by NIST's PQC submission
+// harness to generate Known Answer Tests (KATs).
+//
+// The generator mirrors randombytes_init and randombytes from the rng.c file
+// distributed with PQCgenKAT.c, hence, a scheme that reads its randomness
+// from a DRBG in the same order and in the same chunks as the reference
+// implementation reproduces the official .rsp files.
+//
+// It is meant for testing only and must not be used as a source of
+// randomness in production.
+package nist
+
+import (
+	"crypto/aes"
+)
+
+// DRBG is the AES-256 CTR_DRBG of NIST's rng.c, without prediction resistance
+// nor reseeding.
+type DRBG struct {
+	key [32]byte
+	v   [16]byte
+}
+
+// incV increments the counter V as a 128-bit big-endian integer.
+func (g *DRBG) incV() {
+	for j := 15; j >= 0; j-- {
+		if g.v[j] == 255 {
+			g.v[j] = 0
+		} else {
+			g.v[j]++
+			break
+		}
+	}
+}
+
+// update implements AES256_CTR_DRBG_Update(pd, key, V).
+func (g *DRBG) update(pd *[48]byte) {
+	var buf [48]byte
+	b, _ := aes.NewCipher(g.key[:])
+	for i := 0; i < 3; i++ {
+		g.incV()
+		b.Encrypt(buf[i*16:(i+1)*16], g.v[:])
+	}
+	if pd != nil {
+		for i := 0; i < 48; i++ {
+			buf[i] ^= pd[i]
+		}
+	}
+	copy(g.key[:], buf[:32])
+	copy(g.v[:], buf[32:])
+}
+
+// NewDRBG returns a DRBG seeded as randombytes_init(seed, NULL, 256) does.
+func NewDRBG(seed *[48]byte) (g DRBG) {
+	g.update(seed)
+	return
+}
+
+// Fill fills x with random bytes as randombytes(x, len(x)) does.
+//
+// Note the state is updated after each call, so the output depends on how
+// requests are split, e.g. filling 32 bytes differs from filling 16 bytes
+// twice.
+func (g *DRBG) Fill(x []byte) {
+	var block [16]byte
+
+	b, _ := aes.NewCipher(g.key[:])
+	for len(x) > 0 {
+		g.incV()
+		b.Encrypt(block[:], g.v[:])
+		if len(x) < 16 {
+			copy(x, block[:len(x)])
+			break
+		}
+		copy(x, block[:])
+		x = x[16:]
+	}
+	g.update(nil)
+}
+
+// Read implements io.Reader. Each call is a single call to Fill, so it never
+// fails and always fills p entirely.
+func (g *DRBG) Read(p []byte) (int, error) {
+	g.Fill(p)
+	return len(p), nil
+}
@@ -0,0 +1,52 @@
+package nist
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+)
+
+// First seeds produced by PQCgenKAT.c, which are drawn from a DRBG seeded
+// with 0, 1, ..., 47.
+var katSeeds = []string{
+	"061550234D158C5EC95595FE04EF7A25767F2E24CC2BC479D09D86DC9ABCFDE7056A8C266F9EF97ED08541DBD2E1FFA1",
+	"D81C4D8D734FCBFBEADE3D3F8A039FAA2A2C9957E835AD55B22E75BF57BB556AC81ADDE6AEEB4A5A875C3BFCADFA958F",
+}
+
+func TestDRBG(t *testing.T) {
+	var seed [48]byte
+	for i := range seed {
+		seed[i] = byte(i)
+	}
+	g := NewDRBG(&seed)
+	for i, s := range katSeeds {
+		want, _ := hex.DecodeString(s)
+		var got [48]byte
+		g.Fill(got[:])
+		if !bytes.Equal(got[:], want) {
+			test.ReportError(t, hex.EncodeToString(got[:]), s, i)
+		}
+	}
+
+	// Read is the same as Fill.
+	g1, g2 := NewDRBG(&seed), NewDRBG(&seed)
+	var a, b [33]byte
+	g1.Fill(a[:])
+	n, err := io.ReadFull(&g2, b[:])
+	test.CheckNoErr(t, err, "read failed")
+	if n != len(b) || a != b {
+		test.ReportError(t, b, a)
+	}
+
+	// The output depends on how requests are split.
+	g1, g2 = NewDRBG(&seed), NewDRBG(&seed)
+	g1.Fill(a[:32])
+	g2.Fill(b[:16])
+	g2.Fill(b[16:32])
+	if bytes.Equal(a[:32], b[:32]) {
+		t.Fatal("split requests should differ")
+	}
+}
@@ -0,0 +1,245 @@
+// Package dhkem implements the Diffie-Hellman based KEM of HPKE, with the
+// interface of the kem package.
+//
+// Two instances are provided, DHKEM(X25519, HKDF-SHA256) and DHKEM(X448,
+// HKDF-SHA512). Keys are encoded as specified for X25519 and X448, and the
+// shared secret is derived with HKDF from the Diffie-Hellman value and the
+// encodings of both the ephemeral and recipient public keys.
+//
+// References:
+//   - RFC9180 https://rfc-editor.org/rfc/rfc9180.txt
+//   - RFC7748 https://rfc-editor.org/rfc/rfc7748.txt
+package dhkem
+
+import (
+	"bytes"
+	"crypto"
+	cryptoRand "crypto/rand"
+	_ "crypto/sha256" // Hash function of DHKEM(X25519, HKDF-SHA256).
+	_ "crypto/sha512" // Hash function of DHKEM(X448, HKDF-SHA512).
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"github.com/cloudflare/circl/dh/x25519"
+	"github.com/cloudflare/circl/dh/x448"
+	"github.com/cloudflare/circl/internal/hkdf"
+	"github.com/cloudflare/circl/kem"
+)
+
+// ErrSharedSecret is returned when the Diffie-Hellman value is zero, that is,
+// when a public key or ciphertext is a low-order point.
+var ErrSharedSecret = errors.New("dhkem: invalid shared secret")
+
+const versionLabel = "HPKE-v1"
+
+type scheme struct {
+	id   uint16
+	name string
+	hash crypto.Hash
+	size int
+}
+
+var (
+	x25519HkdfSha256 kem.Scheme = &scheme{0x0020, "HPKE_KEM_X25519_HKDF_SHA256", crypto.SHA256, x25519.Size}
+	x448HkdfSha512   kem.Scheme = &scheme{0x0021, "HPKE_KEM_X448_HKDF_SHA512", crypto.SHA512, x448.Size}
+)
+
+// X25519HKDFSHA256 returns DHKEM(X25519, HKDF-SHA256).
+func X25519HKDFSHA256() kem.Scheme { return x25519HkdfSha256 }
+
+// X448HKDFSHA512 returns DHKEM(X448, HKDF-SHA512).
+func X448HKDFSHA512() kem.Scheme { return x448HkdfSha512 }
+
+func (s *scheme) Name() string               { return s.name }
+func (s *scheme) PublicKeySize() int         { return s.size }
+func (s *scheme) PrivateKeySize() int        { return s.size }
+func (s *scheme) CiphertextSize() int        { return s.size }
+func (s *scheme) SharedKeySize() int         { return s.hash.Size() }
+func (s *scheme) SeedSize() int              { return s.size }
+func (s *scheme) EncapsulationSeedSize() int { return s.size }
+
+type publicKey struct {
+	scheme *scheme
+	pub    []byte
+}
+
+type privateKey struct {
+	scheme *scheme
+	priv   []byte
+	pub    *publicKey
+}
+
+func (k *publicKey) Scheme() kem.Scheme { return k.scheme }
+func (k *publicKey) MarshalBinary() ([]byte, error) {
+	return append(make([]byte, 0, len(k.pub)), k.pub...), nil
+}
+func (k *publicKey) Equal(other kem.PublicKey) bool {
+	k1, ok := other.(*publicKey)
+	return ok && k.scheme == k1.scheme && bytes.Equal(k.pub, k1.pub)
+}
+
+func (k *privateKey) Scheme() kem.Scheme    { return k.scheme }
+func (k *privateKey) Public() kem.PublicKey { return k.pub }
+func (k *privateKey) MarshalBinary() ([]byte, error) {
+	return append(make([]byte, 0, len(k.priv)), k.priv...), nil
+}
+func (k *privateKey) Equal(other kem.PrivateKey) bool {
+	k1, ok := other.(*privateKey)
+	return ok && k.scheme == k1.scheme && subtle.ConstantTimeCompare(k.priv, k1.priv) == 1
+}
+
+// newPrivateKey returns the private key priv together with its public key.
+func (s *scheme) newPrivateKey(priv []byte) *privateKey {
+	pub := make([]byte, s.size)
+	switch s.size {
+	case x25519.Size:
+		var sk, pk x25519.Key
+		copy(sk[:], priv)
+		x25519.KeyGen(&pk, &sk)
+		copy(pub, pk[:])
+	case x448.Size:
+		var sk, pk x448.Key
+		copy(sk[:], priv)
+		x448.KeyGen(&pk, &sk)
+		copy(pub, pk[:])
+	}
+	return &privateKey{s, priv, &publicKey{s, pub}}
+}
+
+// calcDH sets dh to the Diffie-Hellman value of sk and pk.
+func (s *scheme) calcDH(dh []byte, sk *privateKey, pk *publicKey) error {
+	var ok bool
+	switch s.size {
+	case x25519.Size:
+		var ss, sKey, pKey x25519.Key
+		copy(sKey[:], sk.priv)
+		copy(pKey[:], pk.pub)
+		ok = x25519.Shared(&ss, &sKey, &pKey)
+		copy(dh, ss[:])
+	case x448.Size:
+		var ss, sKey, pKey x448.Key
+		copy(sKey[:], sk.priv)
+		copy(pKey[:], pk.pub)
+		ok = x448.Shared(&ss, &sKey, &pKey)
+		copy(dh, ss[:])
+	}
+	if !ok {
+		return ErrSharedSecret
+	}
+	return nil
+}
+
+func (s *scheme) GenerateKeyPair() (kem.PublicKey, kem.PrivateKey, error) {
+	priv := make([]byte, s.size)
+	if _, err := io.ReadFull(cryptoRand.Reader, priv); err != nil {
+		return nil, nil, err
+	}
+	sk := s.newPrivateKey(priv)
+	return sk.pub, sk, nil
+}
+
+// DeriveKeyPair derives a key pair from seed as specified in Section 7.1.3 of
+// RFC9180. Panics if the length of seed is not SeedSize.
+func (s *scheme) DeriveKeyPair(seed []byte) (kem.PublicKey, kem.PrivateKey) {
+	if len(seed) != s.SeedSize() {
+		panic(kem.ErrSeedSize)
+	}
+	sk := s.deriveKeyPair(seed)
+	return sk.pub, sk
+}
+
+func (s *scheme) deriveKeyPair(seed []byte) *privateKey {
+	dkpPrk := s.labeledExtract(nil, []byte("dkp_prk"), seed)
+	priv := s.labeledExpand(dkpPrk, []byte("sk"), nil, s.size)
+	return s.newPrivateKey(priv)
+}
+
+func (s *scheme) Encapsulate(pk kem.PublicKey) (ct, ss []byte, err error) {
+	seed := make([]byte, s.EncapsulationSeedSize())
+	if _, err = io.ReadFull(cryptoRand.Reader, seed); err != nil {
+		return nil, nil, err
+	}
+	return s.EncapsulateDeterministically(pk, seed)
+}
+
+func (s *scheme) EncapsulateDeterministically(pk kem.PublicKey, seed []byte) (ct, ss []byte, err error) {
+	if len(seed) != s.EncapsulationSeedSize() {
+		return nil, nil, kem.ErrSeedSize
+	}
+	pkR, ok := pk.(*publicKey)
+	if !ok || pkR.scheme != s {
+		return nil, nil, kem.ErrTypeMismatch
+	}
+	skE := s.deriveKeyPair(seed)
+	dh := make([]byte, s.size)
+	if err = s.calcDH(dh, skE, pkR); err != nil {
+		return nil, nil, err
+	}
+	ct = append(make([]byte, 0, s.size), skE.pub.pub...)
+	return ct, s.extractAndExpand(dh, ct, pkR.pub), nil
+}
+
+func (s *scheme) Decapsulate(sk kem.PrivateKey, ct []byte) ([]byte, error) {
+	if len(ct) != s.CiphertextSize() {
+		return nil, kem.ErrCiphertextSize
+	}
+	skR, ok := sk.(*privateKey)
+	if !ok || skR.scheme != s {
+		return nil, kem.ErrTypeMismatch
+	}
+	dh := make([]byte, s.size)
+	if err := s.calcDH(dh, skR, &publicKey{s, ct}); err != nil {
+		return nil, err
+	}
+	return s.extractAndExpand(dh, ct, skR.pub.pub), nil
+}
+
+func (s *scheme) UnmarshalBinaryPublicKey(b []byte) (kem.PublicKey, error) {
+	if len(b) != s.PublicKeySize() {
+		return nil, kem.ErrPubKeySize
+	}
+	return &publicKey{s, append(make([]byte, 0, s.size), b...)}, nil
+}
+
+func (s *scheme) UnmarshalBinaryPrivateKey(b []byte) (kem.PrivateKey, error) {
+	if len(b) != s.PrivateKeySize() {
+		return nil, kem.ErrPrivKeySize
+	}
+	return s.newPrivateKey(append(make([]byte, 0, s.size), b...)), nil
+}
+
+// extractAndExpand derives the shared secret from the Diffie-Hellman value
+// and the encodings of the ephemeral and recipient public keys.
+func (s *scheme) extractAndExpand(dh, enc, pkR []byte) []byte {
+	kemContext := append(append(make([]byte, 0, len(enc)+len(pkR)), enc...), pkR...)
+	eaePrk := s.labeledExtract(nil, []byte("eae_prk"), dh)
+	return s.labeledExpand(eaePrk, []byte("shared_secret"), kemContext, s.SharedKeySize())
+}
+
+func (s *scheme) suiteID() []byte {
+	return []byte{'K', 'E', 'M', byte(s.id >> 8), byte(s.id)}
+}
+
+func (s *scheme) labeledExtract(salt, label, ikm []byte) []byte {
+	suiteID := s.suiteID()
+	labeledIKM := make([]byte, 0, len(versionLabel)+len(suiteID)+len(label)+len(ikm))
+	labeledIKM = append(labeledIKM, versionLabel...)
+	labeledIKM = append(labeledIKM, suiteID...)
+	labeledIKM = append(labeledIKM, label...)
+	labeledIKM = append(labeledIKM, ikm...)
+	return hkdf.Extract(s.hash.New, labeledIKM, salt)
+}
+
+func (s *scheme) labeledExpand(prk, label, info []byte, length int) []byte {
+	suiteID := s.suiteID()
+	labeledInfo := make([]byte, 2, 2+len(versionLabel)+len(suiteID)+len(label)+len(info))
+	binary.BigEndian.PutUint16(labeledInfo, uint16(length))
+	labeledInfo = append(labeledInfo, versionLabel...)
+	labeledInfo = append(labeledInfo, suiteID...)
+	labeledInfo = append(labeledInfo, label...)
+	labeledInfo = append(labeledInfo, info...)
+
+	return hkdf.Expand(s.hash.New, prk, labeledInfo, length)
+}
@@ -0,0 +1,99 @@
+package dhkem_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/kem"
+	"github.com/cloudflare/circl/kem/dhkem"
+)
+
+type katVector struct {
+	KemID        uint16 `json:"kem_id"`
+	IkmR         string `json:"ikmR"`
+	IkmE         string `json:"ikmE"`
+	SkRm         string `json:"skRm"`
+	PkRm         string `json:"pkRm"`
+	Enc          string `json:"enc"`
+	SharedSecret string `json:"shared_secret"`
+}
+
+func hexStr(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	test.CheckNoErr(t, err, "bad hex string")
+	return b
+}
+
+func TestRFC9180(t *testing.T) {
+	const nameFile = "testdata/rfc9180_kat_test.json"
+	jsonFile, err := os.Open(nameFile)
+	if err != nil {
+		t.Fatalf("File %v can not be opened. Error: %v", nameFile, err)
+	}
+	defer jsonFile.Close()
+	input, _ := ioutil.ReadAll(jsonFile)
+	var kat []katVector
+	err = json.Unmarshal(input, &kat)
+	if err != nil {
+		t.Fatalf("File %v can not be loaded. Error: %v", nameFile, err)
+	}
+
+	schemes := map[uint16]kem.Scheme{
+		0x20: dhkem.X25519HKDFSHA256(),
+		0x21: dhkem.X448HKDFSHA512(),
+	}
+	for i, v := range kat {
+		s := schemes[v.KemID]
+		pk, sk := s.DeriveKeyPair(hexStr(t, v.IkmR))
+		got, _ := sk.MarshalBinary()
+		want := hexStr(t, v.SkRm)
+		if !bytes.Equal(got, want) {
+			test.ReportError(t, got, want, i)
+		}
+		got, _ = pk.MarshalBinary()
+		want = hexStr(t, v.PkRm)
+		if !bytes.Equal(got, want) {
+			test.ReportError(t, got, want, i)
+		}
+
+		ct, ss, err := s.EncapsulateDeterministically(pk, hexStr(t, v.IkmE))
+		test.CheckNoErr(t, err, "encapsulation failed")
+		want = hexStr(t, v.Enc)
+		if !bytes.Equal(ct, want) {
+			test.ReportError(t, ct, want, i)
+		}
+		want = hexStr(t, v.SharedSecret)
+		if !bytes.Equal(ss, want) {
+			test.ReportError(t, ss, want, i)
+		}
+		got, err = s.Decapsulate(sk, ct)
+		test.CheckNoErr(t, err, "decapsulation failed")
+		if !bytes.Equal(got, want) {
+			test.ReportError(t, got, want, i)
+		}
+	}
+}
+
+func TestLowOrder(t *testing.T) {
+	for _, s := range []kem.Scheme{dhkem.X25519HKDFSHA256(), dhkem.X448HKDFSHA512()} {
+		zero := make([]byte, s.PublicKeySize())
+		pk, err := s.UnmarshalBinaryPublicKey(zero)
+		test.CheckNoErr(t, err, "public key decoding failed")
+		_, _, err = s.Encapsulate(pk)
+		if err != dhkem.ErrSharedSecret {
+			test.ReportError(t, err, dhkem.ErrSharedSecret, s.Name())
+		}
+
+		_, sk, err := s.GenerateKeyPair()
+		test.CheckNoErr(t, err, "key generation failed")
+		_, err = s.Decapsulate(sk, zero)
+		if err != dhkem.ErrSharedSecret {
+			test.ReportError(t, err, dhkem.ErrSharedSecret, s.Name())
+		}
+	}
+}
@@ -0,0 +1,27 @@
+//go:generate go run gen.go
+
+// Package frodo implements the FrodoKEM IND-CCA2 secure key encapsulation
+// mechanism (KEM) as submitted to round 3 of the NIST PQC competition and
+// described in
+//
+//	https://frodokem.org/files/FrodoKEM-specification-20210604.pdf
+//
+// FrodoKEM is based on the hardness of the learning with errors problem on
+// unstructured lattices. The public matrix A is generated row by row from a
+// seed, either with SHAKE128 or with AES128, and the secret and error
+// matrices are sampled in constant time from a discrete approximation of a
+// Gaussian distribution.
+//
+// The instances are generated from a template, one package for each
+// parameter set. Besides the interface of the kem package, each of them
+// provides functions working on fixed-size arrays.
+//
+//	| Scheme              | Public Key Size | Private Key Size | Ciphertext Size | Shared Key Size |
+//	|---------------------|-----------------|------------------|-----------------|-----------------|
+//	| FrodoKEM-640-SHAKE  |            9616 |            19888 |            9720 |              16 |
+//	| FrodoKEM-640-AES    |            9616 |            19888 |            9720 |              16 |
+//	| FrodoKEM-976-SHAKE  |           15632 |            31296 |           15744 |              24 |
+//	| FrodoKEM-976-AES    |           15632 |            31296 |           15744 |              24 |
+//	| FrodoKEM-1344-SHAKE |           21520 |            43088 |           21632 |              32 |
+//	| FrodoKEM-1344-AES   |           21520 |            43088 |           21632 |              32 |
+package frodo
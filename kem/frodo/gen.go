@@ -0,0 +1,83 @@
+// +build ignore
+
+// Autogenerates wrappers from templates to prevent too much duplicated code
+// between the code for different parameter sets.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"text/template"
+)
+
+type Instance struct {
+	Name      string
+	N         int
+	LogQ      int
+	B         int
+	Size      int // Shared key size in bytes.
+	ShakeBits int
+	AES       bool
+	CDFTable  []int
+}
+
+func (m Instance) Pkg() string {
+	return strings.ToLower(strings.Replace(
+		strings.Replace(m.Name, "FrodoKEM-", "frodo", 1), "-", "", -1))
+}
+
+func (m Instance) CDF() string {
+	s := make([]string, len(m.CDFTable))
+	for i, v := range m.CDFTable {
+		s[i] = fmt.Sprint(v)
+	}
+	return strings.Join(s, ", ")
+}
+
+var (
+	cdf640  = []int{4643, 13363, 20579, 25843, 29227, 31145, 32103, 32525, 32689, 32745, 32762, 32766, 32767}
+	cdf976  = []int{5638, 15915, 23689, 28571, 31116, 32217, 32613, 32731, 32760, 32766, 32767}
+	cdf1344 = []int{9142, 23462, 30338, 32361, 32725, 32765, 32767}
+
+	Instances = []Instance{
+		{Name: "FrodoKEM-640-SHAKE", N: 640, LogQ: 15, B: 2, Size: 16, ShakeBits: 128, CDFTable: cdf640},
+		{Name: "FrodoKEM-640-AES", N: 640, LogQ: 15, B: 2, Size: 16, ShakeBits: 128, AES: true, CDFTable: cdf640},
+		{Name: "FrodoKEM-976-SHAKE", N: 976, LogQ: 16, B: 3, Size: 24, ShakeBits: 256, CDFTable: cdf976},
+		{Name: "FrodoKEM-976-AES", N: 976, LogQ: 16, B: 3, Size: 24, ShakeBits: 256, AES: true, CDFTable: cdf976},
+		{Name: "FrodoKEM-1344-SHAKE", N: 1344, LogQ: 16, B: 4, Size: 32, ShakeBits: 256, CDFTable: cdf1344},
+		{Name: "FrodoKEM-1344-AES", N: 1344, LogQ: 16, B: 4, Size: 32, ShakeBits: 256, AES: true, CDFTable: cdf1344},
+	}
+	TemplateWarning = "// Code generated from"
+)
+
+func main() {
+	generatePackageFiles()
+}
+
+// Generates instance/frodo.go from templates/pkg.templ.go
+func generatePackageFiles() {
+	tl, err := template.ParseFiles("templates/pkg.templ.go")
+	if err != nil {
+		panic(err)
+	}
+
+	for _, mode := range Instances {
+		buf := new(bytes.Buffer)
+		err := tl.Execute(buf, mode)
+		if err != nil {
+			panic(err)
+		}
+
+		res := buf.String()
+		offset := strings.Index(res, TemplateWarning)
+		if offset == -1 {
+			panic("Missing template warning in pkg.templ.go")
+		}
+		err = ioutil.WriteFile(mode.Pkg()+"/frodo.go", []byte(res[offset:]), 0644)
+		if err != nil {
+			panic(err)
+		}
+	}
+}
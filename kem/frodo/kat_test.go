@@ -0,0 +1,79 @@
+package frodo
+
+// Code to generate the NIST "PQCkemKAT" test vectors.
+// See PQCgenKAT_kem.c and rng.c in the reference implementation.
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/nist"
+	"github.com/cloudflare/circl/kem/schemes"
+)
+
+func TestPQCgenKATKem(t *testing.T) {
+	kats := []struct {
+		name string
+		want string
+	}{
+		// SHA-256 of the .rsp file of the round 3 reference implementation:
+		// https://github.com/microsoft/PQCrypto-LWEKE/blob/66fc7744c3aae6acfc5fcc587ec7f2cdec48d216/KAT/PQCkemKAT_19888_shake.rsp
+		{"FrodoKEM-640-SHAKE", "604a10cfc871dfaed9cb5b057c644ab03b16852cea7f39bc7f9831513b5b1cfa"},
+		// SHA-256 of the .rsp files generated by this package. They have not
+		// been checked against the ones of the reference implementation.
+		{"FrodoKEM-640-AES", "d1e69503e9042f9484b6e01a466865baa607471c63d7e45d2409f639ba161206"},
+		{"FrodoKEM-976-SHAKE", "32b0ad60047273fb52696f0516acac7ed083e31f5478b416d579ae5e8d8e734c"},
+		{"FrodoKEM-976-AES", "32ed6b1622c845b487c3170ce6878df7baae07e90bd2819a19e5960ce04a55f7"},
+		{"FrodoKEM-1344-SHAKE", "591adc09a718afbc0ac36e1f57a191e557fe4eec7899e078104b9706b75e2f96"},
+		{"FrodoKEM-1344-AES", "9756f7c8cc88d7048ff6e81fa66425bb1392e35c1d30016c190dba17de15221a"},
+	}
+	for _, kat := range kats {
+		kat := kat
+		t.Run(kat.name, func(t *testing.T) {
+			testPQCgenKATKem(t, kat.name, kat.want)
+		})
+	}
+}
+
+func testPQCgenKATKem(t *testing.T, name, expected string) {
+	scheme := schemes.ByName(name)
+	if scheme == nil {
+		t.Fatal()
+	}
+
+	var seed [48]byte
+	kseed := make([]byte, scheme.SeedSize())
+	eseed := make([]byte, scheme.EncapsulationSeedSize())
+	for i := 0; i < 48; i++ {
+		seed[i] = byte(i)
+	}
+	f := sha256.New()
+	g := nist.NewDRBG(&seed)
+	fmt.Fprintf(f, "# %s\n\n", name)
+	for i := 0; i < 100; i++ {
+		g.Fill(seed[:])
+		fmt.Fprintf(f, "count = %d\n", i)
+		fmt.Fprintf(f, "seed = %X\n", seed)
+		g2 := nist.NewDRBG(&seed)
+
+		g2.Fill(kseed)
+		g2.Fill(eseed)
+		pk, sk := scheme.DeriveKeyPair(kseed)
+		ppk, _ := pk.MarshalBinary()
+		psk, _ := sk.MarshalBinary()
+		ct, ss, _ := scheme.EncapsulateDeterministically(pk, eseed)
+		ss2, _ := scheme.Decapsulate(sk, ct)
+		if !bytes.Equal(ss, ss2) {
+			t.Fatal()
+		}
+		fmt.Fprintf(f, "pk = %X\n", ppk)
+		fmt.Fprintf(f, "sk = %X\n", psk)
+		fmt.Fprintf(f, "ct = %X\n", ct)
+		fmt.Fprintf(f, "ss = %X\n\n", ss)
+	}
+	if got := fmt.Sprintf("%x", f.Sum(nil)); got != expected {
+		t.Fatalf("%s: got %s, want %s", name, got, expected)
+	}
+}
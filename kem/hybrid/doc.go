@@ -0,0 +1,33 @@
+// Package hybrid implements hybrid key encapsulation mechanisms combining
+// X25519 with SIKE, for post-quantum experiments in TLS.
+//
+// The schemes follow the concatenation approach of the TLS hybrid design
+// draft. Public keys and ciphertexts are the concatenation of the ones of
+// X25519 and SIKE, in this order, so they can be used directly as the
+// key_exchange field of a TLS 1.3 KeyShareEntry. The shared secret is derived
+// from the concatenation of the X25519 and SIKE shared secrets using
+// HKDF-Extract with an all-zero salt, with SHA-256 for SIKEp503 and SHA-384
+// for SIKEp751. This keeps the result secure as long as one of the two
+// components is.
+//
+// The draft leaves the assignment of NamedGroup values to IANA, so the
+// codepoints returned by CodePoint are taken from the private use range of
+// TLS 1.3 (0xFE00-0xFEFF, RFC 8446, Section 4.2.7): 0xFEE0 for
+// X25519-SIKEp503 and 0xFEE1 for X25519-SIKEp751. They stay clear of
+// 0xFE30-0xFE32, used by the CECPQ2 experiments, where 0xFE32 is CECPQ2b
+// (X25519 with SIKEp434). They are not interoperable with other
+// implementations, and are meant for experiments between peers using this
+// package only.
+//
+//	| Scheme          | Public Key Size | Ciphertext Size | Shared Secret Size |
+//	|-----------------|-----------------|-----------------|--------------------|
+//	| X25519-SIKEp503 |          410    |        434      |         32         |
+//	| X25519-SIKEp751 |          596    |        628      |         48         |
+//
+// References:
+//   - TLS hybrid design https://tools.ietf.org/html/draft-ietf-tls-hybrid-design-00
+//   - RFC7748 https://rfc-editor.org/rfc/rfc7748.txt
+//   - RFC5869 https://rfc-editor.org/rfc/rfc5869.txt
+//   - RFC8446 https://rfc-editor.org/rfc/rfc8446.txt
+//   - SIKE http://www.sike.org/files/SIDH-spec.pdf
+package hybrid
@@ -0,0 +1,235 @@
+package hybrid
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"hash"
+	"io"
+
+	"github.com/cloudflare/circl/dh/sidh"
+	"github.com/cloudflare/circl/dh/x25519"
+	"github.com/cloudflare/circl/internal/hkdf"
+)
+
+// Scheme is a hybrid KEM combining X25519 with a SIKE parameter set.
+type Scheme struct {
+	name      string
+	codePoint uint16
+	id        uint8
+	hash      func() hash.Hash
+}
+
+// The draft does not assign NamedGroup values, so these codepoints are taken
+// from the private use range 0xFE00-0xFEFF of RFC 8446, Section 4.2.7. They
+// avoid 0xFE30-0xFE32, which the CECPQ2 experiments already use, with 0xFE32
+// being CECPQ2b (X25519+SIKEp434).
+var (
+	x25519SIKEp503 = &Scheme{"X25519-SIKEp503", 0xFEE0, sidh.Fp503, sha256.New}
+	x25519SIKEp751 = &Scheme{"X25519-SIKEp751", 0xFEE1, sidh.Fp751, sha512.New384}
+)
+
+// X25519SIKEp503 returns the hybrid of X25519 and SIKE/p503.
+func X25519SIKEp503() *Scheme { return x25519SIKEp503 }
+
+// X25519SIKEp751 returns the hybrid of X25519 and SIKE/p751.
+func X25519SIKEp751() *Scheme { return x25519SIKEp751 }
+
+var (
+	// ErrDecapsulation is returned when a ciphertext cannot be decapsulated.
+	ErrDecapsulation = errors.New("hybrid: decapsulation failed")
+
+	errPublicKey  = errors.New("hybrid: invalid public key")
+	errPrivateKey = errors.New("hybrid: invalid private key")
+	errScheme     = errors.New("hybrid: key belongs to another scheme")
+)
+
+// PublicKey is a public key of a hybrid scheme.
+type PublicKey struct {
+	scheme *Scheme
+	x      x25519.Key
+	sike   *sidh.PublicKey
+}
+
+// PrivateKey is a private key of a hybrid scheme. It also holds the public
+// key, which is needed for decapsulation.
+type PrivateKey struct {
+	scheme *Scheme
+	x      x25519.Key
+	sike   *sidh.PrivateKey
+	pub    PublicKey
+}
+
+// Name returns the name of the scheme.
+func (s *Scheme) Name() string { return s.name }
+
+// CodePoint returns the TLS NamedGroup value used by the scheme. It is a
+// private use value, only meaningful between peers using this package.
+func (s *Scheme) CodePoint() uint16 { return s.codePoint }
+
+// PublicKeySize returns the size of an encoded public key.
+func (s *Scheme) PublicKeySize() int {
+	return x25519.Size + sidh.NewPublicKey(s.id, sidh.KeyVariantSike).Size()
+}
+
+// PrivateKeySize returns the size of an encoded private key.
+func (s *Scheme) PrivateKeySize() int {
+	return x25519.Size + sidh.NewPrivateKey(s.id, sidh.KeyVariantSike).Size() +
+		sidh.NewPublicKey(s.id, sidh.KeyVariantSike).Size()
+}
+
+// CiphertextSize returns the size of a ciphertext.
+func (s *Scheme) CiphertextSize() int {
+	var kem sidh.KEM
+	kem.Allocate(s.id, nil)
+	return x25519.Size + kem.CiphertextSize()
+}
+
+// SharedSecretSize returns the size of a shared secret.
+func (s *Scheme) SharedSecretSize() int { return s.hash().Size() }
+
+// combine derives the shared secret from the X25519 and SIKE shared secrets,
+// as HKDF-Extract with an all-zero salt applied to their concatenation.
+func (s *Scheme) combine(xSs *x25519.Key, sikeSs []byte) []byte {
+	ikm := append(append(make([]byte, 0, len(xSs)+len(sikeSs)), xSs[:]...), sikeSs...)
+	return hkdf.Extract(s.hash, ikm, nil)
+}
+
+// GenerateKeyPair generates a key pair using rand as source of randomness.
+func (s *Scheme) GenerateKeyPair(rand io.Reader) (*PublicKey, *PrivateKey, error) {
+	sk := &PrivateKey{
+		scheme: s,
+		sike:   sidh.NewPrivateKey(s.id, sidh.KeyVariantSike),
+		pub: PublicKey{
+			scheme: s,
+			sike:   sidh.NewPublicKey(s.id, sidh.KeyVariantSike),
+		},
+	}
+	if _, err := io.ReadFull(rand, sk.x[:]); err != nil {
+		return nil, nil, err
+	}
+	if err := sk.sike.Generate(rand); err != nil {
+		return nil, nil, err
+	}
+	x25519.KeyGen(&sk.pub.x, &sk.x)
+	if err := sk.sike.GeneratePublicKey(sk.pub.sike); err != nil {
+		return nil, nil, err
+	}
+	return &sk.pub, sk, nil
+}
+
+// Encapsulate generates a shared secret and the ciphertext encapsulating it
+// for the public key pk, using rand as source of randomness. The ciphertext is
+// the concatenation of an ephemeral X25519 public key and a SIKE ciphertext.
+func (s *Scheme) Encapsulate(rand io.Reader, pk *PublicKey) (ct, ss []byte, err error) {
+	if pk == nil || pk.scheme != s {
+		return nil, nil, errScheme
+	}
+	var ephSecret, ephPublic, shared x25519.Key
+	if _, err = io.ReadFull(rand, ephSecret[:]); err != nil {
+		return nil, nil, err
+	}
+	if !x25519.Shared(&shared, &ephSecret, &pk.x) {
+		return nil, nil, errPublicKey
+	}
+	x25519.KeyGen(&ephPublic, &ephSecret)
+
+	sikeCt, sikeSs, err := sidh.Encapsulate(rand, pk.sike)
+	if err != nil {
+		return nil, nil, err
+	}
+	ct = append(append(make([]byte, 0, s.CiphertextSize()), ephPublic[:]...), sikeCt...)
+	return ct, s.combine(&shared, sikeSs), nil
+}
+
+// Decapsulate returns the shared secret encapsulated in ct using the private
+// key sk. It returns ErrDecapsulation if ct has a wrong size or its X25519
+// component is a low-order point. Following SIKE, a ciphertext with an invalid
+// SIKE component yields an unpredictable shared secret instead of an error.
+func (s *Scheme) Decapsulate(sk *PrivateKey, ct []byte) ([]byte, error) {
+	if sk == nil || sk.scheme != s {
+		return nil, errScheme
+	}
+	if len(ct) != s.CiphertextSize() {
+		return nil, ErrDecapsulation
+	}
+	var ephPublic, shared x25519.Key
+	copy(ephPublic[:], ct)
+	if !x25519.Shared(&shared, &sk.x, &ephPublic) {
+		return nil, ErrDecapsulation
+	}
+	sikeSs, err := sidh.Decapsulate(sk.sike, sk.pub.sike, ct[x25519.Size:])
+	if err != nil {
+		return nil, ErrDecapsulation
+	}
+	return s.combine(&shared, sikeSs), nil
+}
+
+// UnmarshalPublicKey decodes a public key, given as the concatenation of the
+// X25519 and SIKE public keys.
+func (s *Scheme) UnmarshalPublicKey(b []byte) (*PublicKey, error) {
+	if len(b) != s.PublicKeySize() {
+		return nil, errPublicKey
+	}
+	pk := &PublicKey{scheme: s, sike: sidh.NewPublicKey(s.id, sidh.KeyVariantSike)}
+	copy(pk.x[:], b)
+	if err := pk.sike.Import(b[x25519.Size:]); err != nil {
+		return nil, errPublicKey
+	}
+	return pk, nil
+}
+
+// UnmarshalPrivateKey decodes a private key, given as the concatenation of the
+// X25519 private key, the SIKE private key and the SIKE public key.
+func (s *Scheme) UnmarshalPrivateKey(b []byte) (*PrivateKey, error) {
+	if len(b) != s.PrivateKeySize() {
+		return nil, errPrivateKey
+	}
+	sk := &PrivateKey{
+		scheme: s,
+		sike:   sidh.NewPrivateKey(s.id, sidh.KeyVariantSike),
+		pub: PublicKey{
+			scheme: s,
+			sike:   sidh.NewPublicKey(s.id, sidh.KeyVariantSike),
+		},
+	}
+	copy(sk.x[:], b)
+	b = b[x25519.Size:]
+	if err := sk.sike.Import(b[:sk.sike.Size()]); err != nil {
+		return nil, errPrivateKey
+	}
+	if err := sk.pub.sike.Import(b[sk.sike.Size():]); err != nil {
+		return nil, errPrivateKey
+	}
+	x25519.KeyGen(&sk.pub.x, &sk.x)
+	return sk, nil
+}
+
+// Scheme returns the scheme of the public key.
+func (pk *PublicKey) Scheme() *Scheme { return pk.scheme }
+
+// Marshal returns the encoding of the public key, that is, the concatenation
+// of the X25519 and SIKE public keys.
+func (pk *PublicKey) Marshal() []byte {
+	b := make([]byte, pk.scheme.PublicKeySize())
+	copy(b, pk.x[:])
+	pk.sike.Export(b[x25519.Size:])
+	return b
+}
+
+// Scheme returns the scheme of the private key.
+func (sk *PrivateKey) Scheme() *Scheme { return sk.scheme }
+
+// Public returns the public key corresponding to sk.
+func (sk *PrivateKey) Public() *PublicKey { return &sk.pub }
+
+// Marshal returns the encoding of the private key, that is, the concatenation
+// of the X25519 private key, the SIKE private key and the SIKE public key.
+func (sk *PrivateKey) Marshal() []byte {
+	b := make([]byte, sk.scheme.PrivateKeySize())
+	copy(b, sk.x[:])
+	n := x25519.Size + sk.sike.Size()
+	sk.sike.Export(b[x25519.Size:n])
+	sk.pub.sike.Export(b[n:])
+	return b
+}
@@ -0,0 +1,199 @@
+package hybrid_test
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"hash"
+	"testing"
+
+	"github.com/cloudflare/circl/dh/sidh"
+	"github.com/cloudflare/circl/dh/x25519"
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/kem/hybrid"
+)
+
+var schemes = []*hybrid.Scheme{hybrid.X25519SIKEp503(), hybrid.X25519SIKEp751()}
+
+func TestSizes(t *testing.T) {
+	sizes := map[string][3]int{
+		"X25519-SIKEp503": {410, 434, 32},
+		"X25519-SIKEp751": {596, 628, 48},
+	}
+	for _, s := range schemes {
+		got := [3]int{s.PublicKeySize(), s.CiphertextSize(), s.SharedSecretSize()}
+		if want := sizes[s.Name()]; got != want {
+			test.ReportError(t, got, want, s.Name())
+		}
+
+		pk, sk, err := s.GenerateKeyPair(rand.Reader)
+		test.CheckNoErr(t, err, "key generation failed")
+		ct, ss, err := s.Encapsulate(rand.Reader, pk)
+		test.CheckNoErr(t, err, "encapsulation failed")
+
+		for _, v := range []struct{ got, want int }{
+			{len(pk.Marshal()), s.PublicKeySize()},
+			{len(sk.Marshal()), s.PrivateKeySize()},
+			{len(ct), s.CiphertextSize()},
+			{len(ss), s.SharedSecretSize()},
+		} {
+			if v.got != v.want {
+				test.ReportError(t, v.got, v.want, s.Name())
+			}
+		}
+	}
+}
+
+func TestEncapsulateDecapsulate(t *testing.T) {
+	for _, s := range schemes {
+		name := s.Name()
+		pk, sk, err := s.GenerateKeyPair(rand.Reader)
+		test.CheckNoErr(t, err, "key generation failed")
+		ct, ss, err := s.Encapsulate(rand.Reader, pk)
+		test.CheckNoErr(t, err, "encapsulation failed")
+		got, err := s.Decapsulate(sk, ct)
+		test.CheckNoErr(t, err, "decapsulation failed")
+		if !bytes.Equal(got, ss) {
+			test.ReportError(t, got, ss, name)
+		}
+
+		// Keys survive encoding.
+		pk2, err := s.UnmarshalPublicKey(pk.Marshal())
+		test.CheckNoErr(t, err, "public key decoding failed")
+		sk2, err := s.UnmarshalPrivateKey(sk.Marshal())
+		test.CheckNoErr(t, err, "private key decoding failed")
+		if !bytes.Equal(pk2.Marshal(), pk.Marshal()) {
+			test.ReportError(t, pk2.Marshal(), pk.Marshal(), name)
+		}
+		if !bytes.Equal(sk2.Public().Marshal(), pk.Marshal()) {
+			test.ReportError(t, sk2.Public().Marshal(), pk.Marshal(), name)
+		}
+		ct, ss, err = s.Encapsulate(rand.Reader, pk2)
+		test.CheckNoErr(t, err, "encapsulation failed")
+		got, err = s.Decapsulate(sk2, ct)
+		test.CheckNoErr(t, err, "decapsulation failed")
+		if !bytes.Equal(got, ss) {
+			test.ReportError(t, got, ss, name)
+		}
+
+		// An invalid SIKE ciphertext yields a different shared secret.
+		bad := append([]byte{}, ct...)
+		bad[len(bad)-1] ^= 1
+		got, err = s.Decapsulate(sk, bad)
+		test.CheckNoErr(t, err, "decapsulation failed")
+		if bytes.Equal(got, ss) {
+			test.ReportError(t, got, "another secret", name)
+		}
+	}
+}
+
+// Checks that keys and ciphertexts are the concatenation of the X25519 and
+// SIKE ones, and that the shared secret is derived from both shared secrets.
+func TestWireFormat(t *testing.T) {
+	for i, s := range schemes {
+		name := s.Name()
+		id := []uint8{sidh.Fp503, sidh.Fp751}[i]
+		hashFn := []func() hash.Hash{sha256.New, sha512.New384}[i]
+		pk, sk, err := s.GenerateKeyPair(rand.Reader)
+		test.CheckNoErr(t, err, "key generation failed")
+		ct, ss, err := s.Encapsulate(rand.Reader, pk)
+		test.CheckNoErr(t, err, "encapsulation failed")
+
+		skBytes := sk.Marshal()
+		var xSecret, xPublic, xShared x25519.Key
+		copy(xSecret[:], skBytes)
+		x25519.KeyGen(&xPublic, &xSecret)
+		if !bytes.Equal(xPublic[:], pk.Marshal()[:x25519.Size]) {
+			test.ReportError(t, xPublic, pk.Marshal()[:x25519.Size], name)
+		}
+		copy(xPublic[:], ct)
+		x25519.Shared(&xShared, &xSecret, &xPublic)
+
+		sikePub := sidh.NewPublicKey(id, sidh.KeyVariantSike)
+		sikePrv := sidh.NewPrivateKey(id, sidh.KeyVariantSike)
+		test.CheckNoErr(t, sikePub.Import(pk.Marshal()[x25519.Size:]), "SIKE public key import failed")
+		test.CheckNoErr(t, sikePrv.Import(skBytes[x25519.Size:x25519.Size+sikePrv.Size()]), "SIKE private key import failed")
+		sikeSs, err := sidh.Decapsulate(sikePrv, sikePub, ct[x25519.Size:])
+		test.CheckNoErr(t, err, "SIKE decapsulation failed")
+
+		mac := hmac.New(hashFn, make([]byte, hashFn().Size()))
+		_, _ = mac.Write(xShared[:])
+		_, _ = mac.Write(sikeSs)
+		if want := mac.Sum(nil); !bytes.Equal(ss, want) {
+			test.ReportError(t, ss, want, name)
+		}
+	}
+
+	for _, v := range []struct {
+		got, want uint16
+	}{
+		{hybrid.X25519SIKEp503().CodePoint(), 0xFEE0},
+		{hybrid.X25519SIKEp751().CodePoint(), 0xFEE1},
+	} {
+		if v.got != v.want {
+			test.ReportError(t, v.got, v.want)
+		}
+	}
+}
+
+func TestInvalidInputs(t *testing.T) {
+	s := hybrid.X25519SIKEp503()
+	other := hybrid.X25519SIKEp751()
+	pk, sk, _ := s.GenerateKeyPair(rand.Reader)
+	ct, _, _ := s.Encapsulate(rand.Reader, pk)
+
+	_, _, err := other.Encapsulate(rand.Reader, pk)
+	test.CheckIsErr(t, err, "should fail with key of another scheme")
+	_, err = other.Decapsulate(sk, ct)
+	test.CheckIsErr(t, err, "should fail with key of another scheme")
+
+	for _, c := range [][]byte{nil, ct[:len(ct)-1], append(ct, 0)} {
+		_, err = s.Decapsulate(sk, c)
+		if err != hybrid.ErrDecapsulation {
+			test.ReportError(t, err, hybrid.ErrDecapsulation, len(c))
+		}
+	}
+
+	// Low-order X25519 points are rejected.
+	lowOrder := append([]byte{}, ct...)
+	copy(lowOrder, make([]byte, x25519.Size))
+	_, err = s.Decapsulate(sk, lowOrder)
+	if err != hybrid.ErrDecapsulation {
+		test.ReportError(t, err, hybrid.ErrDecapsulation)
+	}
+	pkBytes := pk.Marshal()
+	copy(pkBytes, make([]byte, x25519.Size))
+	pkLow, err := s.UnmarshalPublicKey(pkBytes)
+	test.CheckNoErr(t, err, "public key decoding failed")
+	_, _, err = s.Encapsulate(rand.Reader, pkLow)
+	test.CheckIsErr(t, err, "should fail with low-order public key")
+
+	_, err = s.UnmarshalPublicKey(pkBytes[1:])
+	test.CheckIsErr(t, err, "should fail with short public key")
+	_, err = s.UnmarshalPrivateKey(sk.Marshal()[1:])
+	test.CheckIsErr(t, err, "should fail with short private key")
+}
+
+func BenchmarkHybrid(b *testing.B) {
+	for _, s := range schemes {
+		pk, sk, _ := s.GenerateKeyPair(rand.Reader)
+		ct, _, _ := s.Encapsulate(rand.Reader, pk)
+		b.Run(s.Name()+"/KeyGen", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _, _ = s.GenerateKeyPair(rand.Reader)
+			}
+		})
+		b.Run(s.Name()+"/Encapsulate", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _, _ = s.Encapsulate(rand.Reader, pk)
+			}
+		})
+		b.Run(s.Name()+"/Decapsulate", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _ = s.Decapsulate(sk, ct)
+			}
+		})
+	}
+}
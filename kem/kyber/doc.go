@@ -0,0 +1,22 @@
+//go:generate go run gen.go
+
+// Package kyber implements the CRYSTALS-Kyber.CCAKEM IND-CCA2 secure
+// key encapsulation mechanism (KEM) as submitted to round 3 of the NIST PQC
+// competition and described in
+//
+//	https://pq-crystals.org/kyber/data/kyber-specification-round3.pdf
+//
+// The instances are generated from a template, one package for each
+// parameter set: kyber512, kyber768 and kyber1024. Besides the interface of
+// the kem package, each of them provides functions working on fixed-size
+// arrays.
+//
+//	| Scheme    | Public Key Size | Private Key Size | Ciphertext Size | Shared Key Size |
+//	|-----------|-----------------|------------------|-----------------|-----------------|
+//	| Kyber512  |             800 |             1632 |             768 |              32 |
+//	| Kyber768  |            1184 |             2400 |            1088 |              32 |
+//	| Kyber1024 |            1568 |             3168 |            1568 |              32 |
+//
+// The related public key encryption scheme CRYSTALS-Kyber.CPAPKE can be
+// found in the package github.com/cloudflare/circl/pke/kyber.
+package kyber
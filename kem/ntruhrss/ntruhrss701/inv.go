@@ -0,0 +1,139 @@
+package ntruhrss701
+
+// Inversion in R/2, R/3 and R/q, where R = Z[x]/Φ_n, using the
+// constant-time extended gcd of Bernstein and Yang, see
+//
+//	https://eprint.iacr.org/2019/266
+//
+// Both Φ_n mod 2 and Φ_n mod 3 are irreducible for n = 701, so every
+// non-zero element of R/2 and R/3 is invertible.
+
+// bothNegativeMask returns 0xffff if x < 0 and y < 0, and 0 otherwise.
+func bothNegativeMask(x, y int16) int16 { return (x & y) >> 15 }
+
+// r2Inv sets r to the inverse of a mod (2, Φ_n).
+func (r *poly) r2Inv(a *poly) {
+	var f, g, v, w poly
+
+	w[0] = 1
+	for i := range f {
+		f[i] = 1
+	}
+	for i := 0; i < n-1; i++ {
+		g[n-2-i] = (a[i] ^ a[n-1]) & 1
+	}
+	g[n-1] = 0
+
+	delta := int16(1)
+	for loop := 0; loop < 2*(n-1)-1; loop++ {
+		for i := n - 1; i > 0; i-- {
+			v[i] = v[i-1]
+		}
+		v[0] = 0
+
+		sign := g[0] & f[0]
+		swap := uint16(bothNegativeMask(-delta, -int16(g[0])))
+		delta ^= int16(swap) & (delta ^ -delta)
+		delta++
+
+		for i := range f {
+			t := swap & (f[i] ^ g[i])
+			f[i] ^= t
+			g[i] ^= t
+			t = swap & (v[i] ^ w[i])
+			v[i] ^= t
+			w[i] ^= t
+		}
+
+		for i := range g {
+			g[i] ^= sign & f[i]
+		}
+		for i := range w {
+			w[i] ^= sign & v[i]
+		}
+		for i := 0; i < n-1; i++ {
+			g[i] = g[i+1]
+		}
+		g[n-1] = 0
+	}
+
+	for i := 0; i < n-1; i++ {
+		r[i] = v[n-2-i]
+	}
+	r[n-1] = 0
+}
+
+// s3Inv sets r to the inverse of a mod (3, Φ_n).
+func (r *poly) s3Inv(a *poly) {
+	var f, g, v, w poly
+
+	w[0] = 1
+	for i := range f {
+		f[i] = 1
+	}
+	for i := 0; i < n-1; i++ {
+		g[n-2-i] = mod3((a[i] & 3) + 2*(a[n-1]&3))
+	}
+	g[n-1] = 0
+
+	delta := int16(1)
+	for loop := 0; loop < 2*(n-1)-1; loop++ {
+		for i := n - 1; i > 0; i-- {
+			v[i] = v[i-1]
+		}
+		v[0] = 0
+
+		// sign = -g[0]/f[0] mod 3, where 1/f[0] = f[0] as f[0] ∈ {1, 2}.
+		sign := mod3(2 * g[0] * f[0])
+		swap := uint16(bothNegativeMask(-delta, -int16(g[0])))
+		delta ^= int16(swap) & (delta ^ -delta)
+		delta++
+
+		for i := range f {
+			t := swap & (f[i] ^ g[i])
+			f[i] ^= t
+			g[i] ^= t
+			t = swap & (v[i] ^ w[i])
+			v[i] ^= t
+			w[i] ^= t
+		}
+
+		for i := range g {
+			g[i] = mod3(g[i] + sign*f[i])
+		}
+		for i := range w {
+			w[i] = mod3(w[i] + sign*v[i])
+		}
+		for i := 0; i < n-1; i++ {
+			g[i] = g[i+1]
+		}
+		g[n-1] = 0
+	}
+
+	sign := f[0]
+	for i := 0; i < n-1; i++ {
+		r[i] = mod3(sign * v[n-2-i])
+	}
+	r[n-1] = 0
+}
+
+// rqInv sets r to an inverse of a mod (q, Φ_n): the inverse mod 2 is
+// lifted to an inverse mod q using Newton iteration.  The result is not
+// reduced mod Φ_n.
+func (r *poly) rqInv(a *poly) {
+	var ai, b, c poly
+	ai.r2Inv(a)
+
+	for i := range b {
+		b[i] = -a[i]
+	}
+
+	// Each iteration ai = ai*(2 - a*ai) doubles the number of correct
+	// bits, 1 → 2 → 4 → 8 → 16 ≥ logQ.
+	for k := 0; k < 4; k++ {
+		c.rqMul(&ai, &b)
+		c[0] += 2
+		r.rqMul(&c, &ai)
+		ai = *r
+	}
+}
@@ -0,0 +1,274 @@
+// Package ntruhrss701 implements the IND-CCA2 secure key encapsulation
+// mechanism ntruhrss701, the NTRU-HRSS parameter set of NTRU as submitted
+// to round 3 of the NIST PQC competition and described in
+//
+//	https://ntru.org/
+//
+// The KEM is obtained from a deterministic OW-CPA secure encryption scheme
+// with the SXY transform using implicit rejection.  Polynomial arithmetic
+// and inversion run in constant time.
+package ntruhrss701
+
+import (
+	cryptoRand "crypto/rand"
+	"crypto/subtle"
+	"io"
+
+	"github.com/cloudflare/circl/kem"
+	"github.com/cloudflare/circl/sha3"
+)
+
+const (
+	// Size of seed for NewKeyFromSeed.
+	KeySeedSize = sampleFgSize + prfKeySize
+
+	// Size of seed for EncapsulateTo.
+	EncapsulationSeedSize = sampleRmSize
+
+	// Size of the established shared key.
+	SharedKeySize = 32
+
+	// Size of the encapsulated shared key.
+	CiphertextSize = owcpaCiphertextSize
+
+	// Size of a packed public key.
+	PublicKeySize = owcpaPublicKeySize
+
+	// Size of a packed private key.
+	PrivateKeySize = owcpaPrivateKeySize + prfKeySize
+)
+
+// PublicKey is the type of ntruhrss701 public key.
+type PublicKey struct {
+	pk [PublicKeySize]byte
+}
+
+// PrivateKey is the type of ntruhrss701 private key.
+type PrivateKey struct {
+	sk [PrivateKeySize]byte
+	pk PublicKey
+}
+
+// NewKeyFromSeed derives a public/private keypair deterministically
+// from the given seed.
+func NewKeyFromSeed(seed *[KeySeedSize]byte) (*PublicKey, *PrivateKey) {
+	var sk PrivateKey
+	var owcpaSk [owcpaPrivateKeySize]byte
+	var fgSeed [sampleFgSize]byte
+
+	copy(fgSeed[:], seed[:sampleFgSize])
+	owcpaKeyPair(&sk.pk.pk, &owcpaSk, &fgSeed)
+	copy(sk.sk[:], owcpaSk[:])
+	copy(sk.sk[owcpaPrivateKeySize:], seed[sampleFgSize:])
+
+	pk := sk.pk
+	return &pk, &sk
+}
+
+// GenerateKeyPair generates public and private keys using entropy from rand.
+// If rand is nil, crypto/rand.Reader will be used.
+func GenerateKeyPair(rand io.Reader) (*PublicKey, *PrivateKey, error) {
+	var seed [KeySeedSize]byte
+	if rand == nil {
+		rand = cryptoRand.Reader
+	}
+	_, err := io.ReadFull(rand, seed[:])
+	if err != nil {
+		return nil, nil, err
+	}
+	pk, sk := NewKeyFromSeed(&seed)
+	return pk, sk, nil
+}
+
+// EncapsulateTo generates a shared key and ciphertext that contains it
+// for the public key using randomness from seed and writes the shared key
+// to ss and ciphertext to ct.
+func (pk *PublicKey) EncapsulateTo(ct *[CiphertextSize]byte, ss *[SharedKeySize]byte,
+	seed *[EncapsulationSeedSize]byte) {
+	var r, m poly
+	var rm [owcpaMsgSize]byte
+
+	sampleRm(&r, &m, seed)
+	r.s3ToBytes(rm[:])
+	m.s3ToBytes(rm[packTrinarySize:])
+
+	// K = H(r ‖ m)
+	*ss = sha3.Sum256(rm[:])
+
+	r.z3ToZq()
+	owcpaEnc(ct, &r, &m, &pk.pk)
+}
+
+// DecapsulateTo computes the shared key which is encapsulated in ct
+// for the private key.
+func (sk *PrivateKey) DecapsulateTo(ss *[SharedKeySize]byte, ct *[CiphertextSize]byte) {
+	var rm [owcpaMsgSize]byte
+	var owcpaSk [owcpaPrivateKeySize]byte
+
+	copy(owcpaSk[:], sk.sk[:])
+	fail := owcpaDec(&rm, ct, &owcpaSk)
+
+	// K = H(r ‖ m)
+	*ss = sha3.Sum256(rm[:])
+
+	// Replace K by H(s ‖ c) if decryption failed.
+	var buf [prfKeySize + CiphertextSize]byte
+	copy(buf[:], sk.sk[owcpaPrivateKeySize:])
+	copy(buf[prfKeySize:], ct[:])
+	k := sha3.Sum256(buf[:])
+	subtle.ConstantTimeCopy(fail, ss[:], k[:])
+}
+
+// Pack packs sk to buf.
+func (sk *PrivateKey) Pack(buf *[PrivateKeySize]byte) {
+	*buf = sk.sk
+}
+
+// Unpack unpacks sk from buf.  The public key is recomputed from the
+// private key, which is relatively expensive.
+func (sk *PrivateKey) Unpack(buf *[PrivateKeySize]byte) {
+	var owcpaSk [owcpaPrivateKeySize]byte
+	sk.sk = *buf
+	copy(owcpaSk[:], sk.sk[:])
+	owcpaPublicKey(&sk.pk.pk, &owcpaSk)
+}
+
+// Pack packs pk to buf.
+func (pk *PublicKey) Pack(buf *[PublicKeySize]byte) {
+	*buf = pk.pk
+}
+
+// Unpack unpacks pk from buf.
+func (pk *PublicKey) Unpack(buf *[PublicKeySize]byte) {
+	pk.pk = *buf
+}
+
+// Boilerplate down below for the KEM scheme API.
+
+type scheme struct{}
+
+var sch kem.Scheme = &scheme{}
+
+// Scheme returns a KEM interface.
+func Scheme() kem.Scheme { return sch }
+
+func (*scheme) Name() string               { return "ntruhrss701" }
+func (*scheme) PublicKeySize() int         { return PublicKeySize }
+func (*scheme) PrivateKeySize() int        { return PrivateKeySize }
+func (*scheme) SeedSize() int              { return KeySeedSize }
+func (*scheme) SharedKeySize() int         { return SharedKeySize }
+func (*scheme) CiphertextSize() int        { return CiphertextSize }
+func (*scheme) EncapsulationSeedSize() int { return EncapsulationSeedSize }
+
+func (sk *PrivateKey) Scheme() kem.Scheme { return sch }
+func (pk *PublicKey) Scheme() kem.Scheme  { return sch }
+
+func (sk *PrivateKey) MarshalBinary() ([]byte, error) {
+	var ret [PrivateKeySize]byte
+	sk.Pack(&ret)
+	return ret[:], nil
+}
+
+func (sk *PrivateKey) Equal(other kem.PrivateKey) bool {
+	oth, ok := other.(*PrivateKey)
+	if !ok {
+		return false
+	}
+	return subtle.ConstantTimeCompare(sk.sk[:], oth.sk[:]) == 1
+}
+
+func (pk *PublicKey) Equal(other kem.PublicKey) bool {
+	oth, ok := other.(*PublicKey)
+	if !ok {
+		return false
+	}
+	return pk.pk == oth.pk
+}
+
+func (sk *PrivateKey) Public() kem.PublicKey {
+	pk := sk.pk
+	return &pk
+}
+
+func (pk *PublicKey) MarshalBinary() ([]byte, error) {
+	var ret [PublicKeySize]byte
+	pk.Pack(&ret)
+	return ret[:], nil
+}
+
+func (*scheme) GenerateKeyPair() (kem.PublicKey, kem.PrivateKey, error) {
+	return GenerateKeyPair(cryptoRand.Reader)
+}
+
+func (*scheme) DeriveKeyPair(seed []byte) (kem.PublicKey, kem.PrivateKey) {
+	if len(seed) != KeySeedSize {
+		panic(kem.ErrSeedSize)
+	}
+	var s [KeySeedSize]byte
+	copy(s[:], seed)
+	return NewKeyFromSeed(&s)
+}
+
+func (sch *scheme) Encapsulate(pk kem.PublicKey) (ct, ss []byte, err error) {
+	var seed [EncapsulationSeedSize]byte
+	if _, err = io.ReadFull(cryptoRand.Reader, seed[:]); err != nil {
+		return nil, nil, err
+	}
+	return sch.EncapsulateDeterministically(pk, seed[:])
+}
+
+func (*scheme) EncapsulateDeterministically(pk kem.PublicKey, seed []byte) (
+	ct, ss []byte, err error) {
+	if len(seed) != EncapsulationSeedSize {
+		return nil, nil, kem.ErrSeedSize
+	}
+	pub, ok := pk.(*PublicKey)
+	if !ok {
+		return nil, nil, kem.ErrTypeMismatch
+	}
+
+	var s [EncapsulationSeedSize]byte
+	var c [CiphertextSize]byte
+	var k [SharedKeySize]byte
+	copy(s[:], seed)
+	pub.EncapsulateTo(&c, &k, &s)
+	return c[:], k[:], nil
+}
+
+func (*scheme) Decapsulate(sk kem.PrivateKey, ct []byte) ([]byte, error) {
+	if len(ct) != CiphertextSize {
+		return nil, kem.ErrCiphertextSize
+	}
+	priv, ok := sk.(*PrivateKey)
+	if !ok {
+		return nil, kem.ErrTypeMismatch
+	}
+
+	var c [CiphertextSize]byte
+	var k [SharedKeySize]byte
+	copy(c[:], ct)
+	priv.DecapsulateTo(&k, &c)
+	return k[:], nil
+}
+
+func (*scheme) UnmarshalBinaryPublicKey(buf []byte) (kem.PublicKey, error) {
+	if len(buf) != PublicKeySize {
+		return nil, kem.ErrPubKeySize
+	}
+	var b [PublicKeySize]byte
+	var ret PublicKey
+	copy(b[:], buf)
+	ret.Unpack(&b)
+	return &ret, nil
+}
+
+func (*scheme) UnmarshalBinaryPrivateKey(buf []byte) (kem.PrivateKey, error) {
+	if len(buf) != PrivateKeySize {
+		return nil, kem.ErrPrivKeySize
+	}
+	var b [PrivateKeySize]byte
+	var ret PrivateKey
+	copy(b[:], buf)
+	ret.Unpack(&b)
+	return &ret, nil
+}
@@ -0,0 +1,137 @@
+package ntruhrss701
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/nist"
+)
+
+func TestEncapsulateDecapsulate(t *testing.T) {
+	var ct [CiphertextSize]byte
+	var ss, ss2 [SharedKeySize]byte
+	var eseed [EncapsulationSeedSize]byte
+
+	for i := 0; i < 10; i++ {
+		pk, sk, err := GenerateKeyPair(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = rand.Read(eseed[:])
+		pk.EncapsulateTo(&ct, &ss, &eseed)
+		sk.DecapsulateTo(&ss2, &ct)
+		if ss != ss2 {
+			t.Fatalf("shared keys differ")
+		}
+
+		// Decapsulating a modified ciphertext gives a pseudorandom key.
+		ct[i] ^= 0x01
+		sk.DecapsulateTo(&ss2, &ct)
+		if ss == ss2 {
+			t.Fatalf("modified ciphertext not rejected")
+		}
+		ct[i] ^= 0x01
+
+		// As do non-zero padding bits in the last byte.
+		ct[CiphertextSize-1] ^= 0x80
+		sk.DecapsulateTo(&ss2, &ct)
+		if ss == ss2 {
+			t.Fatalf("non-zero padding not rejected")
+		}
+	}
+}
+
+func TestUnpackPrivateKey(t *testing.T) {
+	var psk [PrivateKeySize]byte
+	var sk2 PrivateKey
+
+	pk, sk, err := GenerateKeyPair(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sk.Pack(&psk)
+	sk2.Unpack(&psk)
+	if !sk.Equal(&sk2) || !pk.Equal(sk2.Public()) {
+		t.Fatalf("public key not recovered from private key")
+	}
+}
+
+// TestPQCgenKATKem generates the test vectors as done by PQCgenKAT_kem.c,
+// and checks the SHA-256 of the resulting PQCkemKAT_1450.rsp file.
+func TestPQCgenKATKem(t *testing.T) {
+	// SHA-256 of the .rsp file generated by this package. It has not been
+	// checked against the one of the reference implementation.
+	const want = "1e7c8e02f7dc1a9796332d60d1b08995fff5dfe81f2ae7394ec2f4816dedf4b6"
+
+	f := sha256.New()
+	var seed [48]byte
+	var kseed [KeySeedSize]byte
+	var eseed [EncapsulationSeedSize]byte
+	var ct [CiphertextSize]byte
+	var ss, ss2 [SharedKeySize]byte
+	var ppk [PublicKeySize]byte
+	var psk [PrivateKeySize]byte
+	for i := 0; i < 48; i++ {
+		seed[i] = byte(i)
+	}
+	g := nist.NewDRBG(&seed)
+	fmt.Fprintf(f, "# ntruhrss701\n\n")
+	for i := 0; i < 100; i++ {
+		g.Fill(seed[:])
+		fmt.Fprintf(f, "count = %d\n", i)
+		fmt.Fprintf(f, "seed = %X\n", seed)
+		g2 := nist.NewDRBG(&seed)
+
+		// The reference implementation calls randombytes once for f and g,
+		// and once for the PRF key.
+		g2.Fill(kseed[:sampleFgSize])
+		g2.Fill(kseed[sampleFgSize:])
+		g2.Fill(eseed[:])
+
+		pk, sk := NewKeyFromSeed(&kseed)
+		pk.Pack(&ppk)
+		sk.Pack(&psk)
+		pk.EncapsulateTo(&ct, &ss, &eseed)
+		sk.DecapsulateTo(&ss2, &ct)
+		if ss != ss2 {
+			t.Fatal()
+		}
+		fmt.Fprintf(f, "pk = %X\n", ppk)
+		fmt.Fprintf(f, "sk = %X\n", psk)
+		fmt.Fprintf(f, "ct = %X\n", ct)
+		fmt.Fprintf(f, "ss = %X\n\n", ss)
+	}
+	if got := fmt.Sprintf("%x", f.Sum(nil)); got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+func BenchmarkKeyGen(b *testing.B) {
+	var seed [KeySeedSize]byte
+	for i := 0; i < b.N; i++ {
+		_, _ = NewKeyFromSeed(&seed)
+	}
+}
+
+func BenchmarkEncapsulate(b *testing.B) {
+	var ct [CiphertextSize]byte
+	var ss [SharedKeySize]byte
+	var eseed [EncapsulationSeedSize]byte
+	pk, _, _ := GenerateKeyPair(rand.Reader)
+	for i := 0; i < b.N; i++ {
+		pk.EncapsulateTo(&ct, &ss, &eseed)
+	}
+}
+
+func BenchmarkDecapsulate(b *testing.B) {
+	var ct [CiphertextSize]byte
+	var ss [SharedKeySize]byte
+	var eseed [EncapsulationSeedSize]byte
+	pk, sk, _ := GenerateKeyPair(rand.Reader)
+	pk.EncapsulateTo(&ct, &ss, &eseed)
+	for i := 0; i < b.N; i++ {
+		sk.DecapsulateTo(&ss, &ct)
+	}
+}
@@ -0,0 +1,152 @@
+package ntruhrss701
+
+// The one-way CPA-secure encryption scheme underlying NTRU-HRSS-KEM, as
+// described in Section 1.10 of the specification.
+
+// owcpaKeyPair derives a key pair from seed.  The private key consists of
+// f and 1/f mod (3, Φ_n) packed as polynomials mod 3, followed by 1/h mod
+// (q, Φ_n).
+func owcpaKeyPair(pk *[owcpaPublicKeySize]byte,
+	sk *[owcpaPrivateKeySize]byte, seed *[sampleFgSize]byte) {
+	var f, g, invf3, gf, invgf, tmp, invh, h poly
+
+	sampleFg(&f, &g, seed)
+
+	invf3.s3Inv(&f)
+	f.s3ToBytes(sk[:])
+	invf3.s3ToBytes(sk[packTrinarySize:])
+
+	// Lift the coefficients of f and g from Z/3 to Z/q.
+	f.z3ToZq()
+	g.z3ToZq()
+
+	// g = 3*(x-1)*g
+	for i := n - 1; i > 0; i-- {
+		g[i] = 3 * (g[i-1] - g[i])
+	}
+	g[0] = -(3 * g[0])
+
+	gf.rqMul(&g, &f)
+	invgf.rqInv(&gf)
+
+	tmp.rqMul(&invgf, &f)
+	invh.sqMul(&tmp, &f)
+	invh.sqToBytes(sk[2*packTrinarySize:])
+
+	tmp.rqMul(&invgf, &g)
+	h.rqMul(&tmp, &g)
+	h.rqSumZeroToBytes(pk[:])
+}
+
+// owcpaPublicKey recomputes the public key h from the private key sk.
+func owcpaPublicKey(pk *[owcpaPublicKeySize]byte,
+	sk *[owcpaPrivateKeySize]byte) {
+	var invh, h poly
+
+	invh.sqFromBytes(sk[2*packTrinarySize:])
+	h.rqInv(&invh)
+	h.modQPhiN()
+
+	// h is determined by its residue mod Φ_n and h(1) = 0, as x - 1 and
+	// Φ_n are coprime mod q.  Add c*Φ_n with c = -h(1)/n.
+	var s uint16
+	for i := range h {
+		s += h[i]
+	}
+	c := -s * invN
+	for i := range h {
+		h[i] += c
+	}
+	h.rqSumZeroToBytes(pk[:])
+}
+
+// invN is the inverse of n mod 2^16.
+var invN = func() uint16 {
+	// Newton iteration: the first guess is correct to three bits.
+	x := uint16(n)
+	for i := 0; i < 4; i++ {
+		x *= 2 - n*x
+	}
+	return x
+}()
+
+// owcpaEnc encrypts m using r as randomness.  The coefficients of r must
+// be in {0, 1, q-1} and those of m in {0, 1, 2}.
+func owcpaEnc(ct *[owcpaCiphertextSize]byte, r, m *poly,
+	pk *[owcpaPublicKeySize]byte) {
+	var h, c, liftm poly
+
+	h.rqSumZeroFromBytes(pk[:])
+	c.rqMul(r, &h)
+	liftm.lift(m)
+	for i := range c {
+		c[i] += liftm[i]
+	}
+	c.rqSumZeroToBytes(ct[:])
+}
+
+// owcpaDec decrypts ct and writes the packed (r, m) to rm.  Returns 0 if
+// re-encryption of (r, m) yields ct and 1 otherwise.  Runs in constant
+// time.
+func owcpaDec(rm *[owcpaMsgSize]byte, ct *[owcpaCiphertextSize]byte,
+	sk *[owcpaPrivateKeySize]byte) int {
+	var c, f, cf, mf, finv3, m, liftm, invh, b, r poly
+
+	c.rqSumZeroFromBytes(ct[:])
+	f.s3FromBytes(sk[:])
+	f.z3ToZq()
+
+	cf.rqMul(&c, &f)
+	mf.rqToS3(&cf)
+
+	finv3.s3FromBytes(sk[packTrinarySize:])
+	m.s3Mul(&mf, &finv3)
+	m.s3ToBytes(rm[packTrinarySize:])
+
+	fail := checkCiphertext(ct)
+
+	// For the IND-CCA2 KEM we must ensure that c = Enc(h, (r, m)).  We
+	// avoid recomputing r*h + Lift(m) by checking that r, defined as
+	// b/h mod (q, Φ_n), is in the message space.  Any m in S3 is valid.
+
+	// b = c - Lift(m) mod (q, x^n - 1)
+	liftm.lift(&m)
+	for i := range b {
+		b[i] = c[i] - liftm[i]
+	}
+
+	// r = b/h mod (q, Φ_n)
+	invh.sqFromBytes(sk[2*packTrinarySize:])
+	r.sqMul(&b, &invh)
+
+	// By Proposition 1 of https://eprint.iacr.org/2018/1174, re-encryption
+	// with (r, m) yields c if and only if r is trinary.  We have c(1) = 0
+	// due to the packing of c.
+	fail |= checkR(&r)
+
+	r.trinaryZqToZ3()
+	r.s3ToBytes(rm[:])
+
+	return fail
+}
+
+// checkCiphertext returns 1 if the unused bits of the last byte of ct are
+// not zero, and 0 otherwise.
+func checkCiphertext(ct *[owcpaCiphertextSize]byte) int {
+	t := uint16(ct[owcpaCiphertextSize-1])
+	t &= 0xff << (8 - (7 & (logQ * packDeg)))
+	return int(1 & ((^t + 1) >> 15))
+}
+
+// checkR returns 0 if the coefficients of r are in {0, 1, q-1} mod q and
+// r[n-1] = 0, and 1 otherwise.
+func checkR(r *poly) int {
+	var t uint32
+	for i := 0; i < n-1; i++ {
+		c := uint32(r[i])
+		t |= (c + 1) & (q - 4) // 0 iff c is in {-1, 0, 1, 2}
+		t |= (c + 2) & 4       // 1 if c = 2, 0 if c is in {-1, 0, 1}
+	}
+	t |= uint32(r[n-1])
+	return int(1 & ((^t + 1) >> 31))
+}
@@ -0,0 +1,39 @@
+package ntruhrss701
+
+const (
+	// n is the degree of the polynomial x^n - 1 defining the ring R.
+	n = 701
+
+	// logQ is the base-2 logarithm of the modulus q.
+	logQ = 13
+
+	// q is the modulus for the coefficients of the polynomials in R/q.
+	q = 1 << logQ
+
+	// packDeg is the number of coefficients which are packed: polynomials
+	// are either of degree at most n-2, or have coefficients summing to
+	// zero, so the last one is implicit.
+	packDeg = n - 1
+
+	// Size of a polynomial mod 3 packed as five coefficients per byte.
+	packTrinarySize = (packDeg + 4) / 5
+
+	// Size of a polynomial mod q packed with logQ bits per coefficient.
+	packSqSize = (logQ*packDeg + 7) / 8
+
+	// Size of the bytes needed to sample a polynomial with sampleIid.
+	sampleIidSize = n - 1
+
+	// Size of the bytes needed to sample f and g, or r and m.
+	sampleFgSize = 2 * sampleIidSize
+	sampleRmSize = 2 * sampleIidSize
+
+	// Sizes of the underlying one-way CPA-secure encryption scheme.
+	owcpaMsgSize        = 2 * packTrinarySize
+	owcpaPublicKeySize  = packSqSize
+	owcpaPrivateKeySize = 2*packTrinarySize + packSqSize
+	owcpaCiphertextSize = packSqSize
+
+	// Size of the key of the PRF used for implicit rejection.
+	prfKeySize = 32
+)
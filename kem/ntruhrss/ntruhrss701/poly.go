@@ -0,0 +1,224 @@
+package ntruhrss701
+
+// poly is an element of Z[x]/(x^n - 1), with coefficients stored modulo
+// 2^16.  As q divides 2^16, arithmetic in R/q can be done with plain uint16
+// operations, reducing modulo q only when needed.  Polynomials mod 3 store
+// coefficients in {0, 1, 2}.
+type poly [n]uint16
+
+// mod3 returns a mod 3 in constant time.
+func mod3(a uint16) uint16 {
+	r := (a >> 8) + (a & 0xff) // r mod 255 == a mod 255
+	r = (r >> 4) + (r & 0xf)   // r' mod 15 == r mod 15
+	r = (r >> 2) + (r & 0x3)   // r' mod 3 == r mod 3
+	r = (r >> 2) + (r & 0x3)   // r' mod 3 == r mod 3
+	t := int16(r) - 3
+	c := t >> 15
+	return uint16((c & int16(r)) ^ (^c & t))
+}
+
+// modQ returns a mod q.
+func modQ(a uint16) uint16 { return a & (q - 1) }
+
+// rqMul sets r to a*b mod (x^n - 1).  Runs in constant time.  r may alias
+// neither a nor b.
+func (r *poly) rqMul(a, b *poly) {
+	for k := 0; k < n; k++ {
+		var s uint16
+		for i := 1; i < n-k; i++ {
+			s += a[k+i] * b[n-i]
+		}
+		for i := 0; i < k+1; i++ {
+			s += a[k-i] * b[i]
+		}
+		r[k] = s
+	}
+}
+
+// sqMul sets r to a*b mod (q, Φ_n), where Φ_n = (x^n - 1)/(x - 1).
+func (r *poly) sqMul(a, b *poly) {
+	r.rqMul(a, b)
+	r.modQPhiN()
+}
+
+// s3Mul sets r to a*b mod (3, Φ_n).  Coefficients of a and b must be in
+// {0, 1, 2}.
+func (r *poly) s3Mul(a, b *poly) {
+	// Products are at most 4n < q, so there is no overflow mod q.
+	r.rqMul(a, b)
+	for i := range r {
+		r[i] = modQ(r[i])
+	}
+	r.mod3PhiN()
+}
+
+// mod3PhiN reduces r mod (3, Φ_n), so that r[n-1] = 0.
+func (r *poly) mod3PhiN() {
+	for i := range r {
+		r[i] = mod3(r[i] + 2*r[n-1])
+	}
+}
+
+// modQPhiN reduces r mod Φ_n, so that r[n-1] = 0.
+func (r *poly) modQPhiN() {
+	for i := range r {
+		r[i] -= r[n-1]
+	}
+}
+
+// z3ToZq maps coefficients from {0, 1, 2} to {0, 1, q-1}.
+func (r *poly) z3ToZq() {
+	for i := range r {
+		r[i] = r[i] | ((-(r[i] >> 1)) & (q - 1))
+	}
+}
+
+// trinaryZqToZ3 maps coefficients from {0, 1, q-1} (mod q) to {0, 1, 2}.
+func (r *poly) trinaryZqToZ3() {
+	for i := range r {
+		r[i] = modQ(r[i])
+		r[i] = 3 & (r[i] ^ (r[i] >> (logQ - 1)))
+	}
+}
+
+// rqToS3 sets r to a mod (3, Φ_n), taking representatives of the
+// coefficients of a in [-q/2, q/2).
+func (r *poly) rqToS3(a *poly) {
+	for i := range a {
+		r[i] = modQ(a[i])
+		// Add (-q) mod 3 = 1 if r[i] ≥ q/2.
+		r[i] += r[i] >> (logQ - 1)
+	}
+	r.mod3PhiN()
+}
+
+// lift sets r to the unique polynomial with coefficients in {0, 1, q-1}
+// which is divisible by (x - 1) and equal to a mod (3, Φ_n).  That is,
+// r = (x - 1) * (a/(x - 1) mod (3, Φ_n)).  Coefficients of a must be in
+// {0, 1, 2}.
+func (r *poly) lift(a *poly) {
+	var b poly
+
+	// Define z by <z*x^i, x-1> = δ_{i,0} mod 3:
+	//   t    = -1/n mod 3 = -n mod 3
+	//   z[0] = 2 - t mod 3
+	//   z[1] = 0 mod 3
+	//   z[j] = z[j-1] + t mod 3
+	// Then b = a/(x-1) mod (3, Φ_n) is computed using
+	//   b[0] = <z, a>, b[1] = <z*x, a>, b[2] = <z*x², a>
+	//   b[i] = b[i-3] - (a[i] + a[i-1] + a[i-2]).
+	t := uint16(3 - (n % 3))
+	b[0] = a[0]*(2-t) + a[1]*0 + a[2]*t
+	b[1] = a[1]*(2-t) + a[2]*0
+	b[2] = a[2] * (2 - t)
+
+	zj := uint16(0) // z[1]
+	for i := 3; i < n; i++ {
+		b[0] += a[i] * (zj + 2*t)
+		b[1] += a[i] * (zj + t)
+		b[2] += a[i] * zj
+		zj = (zj + t) % 3
+	}
+	b[1] += a[0] * (zj + t)
+	b[2] += a[0] * zj
+	b[2] += a[1] * (zj + t)
+
+	b[0] = mod3(b[0])
+	b[1] = mod3(b[1])
+	b[2] = mod3(b[2])
+
+	for i := 3; i < n; i++ {
+		b[i] = b[i-3] + 2*(a[i]+a[i-1]+a[i-2])
+	}
+
+	// Finish the reduction mod Φ_n by subtracting Φ_n * b[n-1].
+	b.mod3PhiN()
+
+	// Switch from {0, 1, 2} to {0, 1, q-1} coefficient representation.
+	b.z3ToZq()
+
+	// Multiply by (x - 1).
+	r[0] = -b[0]
+	for i := 0; i < n-1; i++ {
+		r[i+1] = b[i] - b[i+1]
+	}
+}
+
+// s3ToBytes packs the first packDeg coefficients of a, which must be in
+// {0, 1, 2}, five per byte.
+func (a *poly) s3ToBytes(buf []byte) {
+	for i := 0; i < packDeg/5; i++ {
+		c := a[5*i+4] & 255
+		c = (3*c + a[5*i+3]) & 255
+		c = (3*c + a[5*i+2]) & 255
+		c = (3*c + a[5*i+1]) & 255
+		c = (3*c + a[5*i+0]) & 255
+		buf[i] = byte(c)
+	}
+}
+
+// s3FromBytes unpacks r from buf and reduces it mod (3, Φ_n).
+func (r *poly) s3FromBytes(buf []byte) {
+	for i := 0; i < packDeg/5; i++ {
+		c := uint16(buf[i])
+		r[5*i+0] = c
+		r[5*i+1] = c * 171 >> 9 // this is division by 3
+		r[5*i+2] = c * 57 >> 9  // division by 3^2
+		r[5*i+3] = c * 19 >> 9  // division by 3^3
+		r[5*i+4] = c * 203 >> 14
+	}
+	r[n-1] = 0
+	r.mod3PhiN()
+}
+
+// sqToBytes packs the first packDeg coefficients of a mod q, logQ bits
+// each in little-endian order.
+func (a *poly) sqToBytes(buf []byte) {
+	var acc uint32
+	var bits uint
+	j := 0
+	for i := 0; i < packDeg; i++ {
+		acc |= uint32(modQ(a[i])) << bits
+		bits += logQ
+		for bits >= 8 {
+			buf[j] = byte(acc)
+			j++
+			acc >>= 8
+			bits -= 8
+		}
+	}
+	if bits > 0 {
+		buf[j] = byte(acc)
+	}
+}
+
+// sqFromBytes unpacks the first packDeg coefficients of r from buf and sets
+// r[n-1] to zero.
+func (r *poly) sqFromBytes(buf []byte) {
+	var acc uint32
+	var bits uint
+	j := 0
+	for i := 0; i < packDeg; i++ {
+		for bits < logQ {
+			acc |= uint32(buf[j]) << bits
+			j++
+			bits += 8
+		}
+		r[i] = uint16(acc) & (q - 1)
+		acc >>= logQ
+		bits -= logQ
+	}
+	r[n-1] = 0
+}
+
+// rqSumZeroToBytes packs a, whose coefficients must sum to zero mod q.
+func (a *poly) rqSumZeroToBytes(buf []byte) { a.sqToBytes(buf) }
+
+// rqSumZeroFromBytes unpacks r from buf, setting r[n-1] so that the sum of
+// the coefficients of r is zero mod q.
+func (r *poly) rqSumZeroFromBytes(buf []byte) {
+	r.sqFromBytes(buf)
+	for i := 0; i < packDeg; i++ {
+		r[n-1] -= r[i]
+	}
+}
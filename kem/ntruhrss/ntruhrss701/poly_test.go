@@ -0,0 +1,207 @@
+package ntruhrss701
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+)
+
+func randS3(p *poly) {
+	for i := 0; i < n-1; i++ {
+		p[i] = uint16(rand.Intn(3))
+	}
+	p[n-1] = 0
+}
+
+func randRq(p *poly) {
+	for i := range p {
+		p[i] = uint16(rand.Intn(q))
+	}
+}
+
+// isOneModQPhiN returns whether a is one mod (q, Φ_n).
+func isOneModQPhiN(a *poly) bool {
+	b := *a
+	b.modQPhiN()
+	for i := range b {
+		b[i] = modQ(b[i])
+	}
+	if b[0] != 1 {
+		return false
+	}
+	for i := 1; i < n; i++ {
+		if b[i] != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestMod3(t *testing.T) {
+	for x := 0; x < 1<<16; x++ {
+		got := mod3(uint16(x))
+		want := uint16(x % 3)
+		if got != want {
+			test.ReportError(t, got, want, x)
+		}
+	}
+}
+
+func TestS3Inv(t *testing.T) {
+	var a, ai, p poly
+	for i := 0; i < 10; i++ {
+		randS3(&a)
+		ai.s3Inv(&a)
+		p.s3Mul(&a, &ai)
+		if !isOneModQPhiN(&p) {
+			t.Fatalf("a*(1/a) != 1 mod (3, Φ_n)")
+		}
+	}
+}
+
+func TestR2Inv(t *testing.T) {
+	var a, ai, p poly
+	for i := 0; i < 10; i++ {
+		for j := range a {
+			a[j] = uint16(rand.Intn(2))
+		}
+		ai.r2Inv(&a)
+		p.rqMul(&a, &ai)
+		p.modQPhiN()
+		for j := range p {
+			p[j] &= 1
+		}
+		p[0] ^= 1
+		if p != (poly{}) {
+			t.Fatalf("a*(1/a) != 1 mod (2, Φ_n)")
+		}
+	}
+}
+
+func TestRqInv(t *testing.T) {
+	var a, ai, p poly
+	for i := 0; i < 10; i++ {
+		randRq(&a)
+		ai.rqInv(&a)
+		p.rqMul(&a, &ai)
+		if !isOneModQPhiN(&p) {
+			t.Fatalf("a*(1/a) != 1 mod (q, Φ_n)")
+		}
+	}
+}
+
+func TestLift(t *testing.T) {
+	var m, l, s, xm1, b, p poly
+	xm1[0], xm1[1] = 2, 1 // x - 1 mod 3
+	var invXm1 poly
+	invXm1.s3Inv(&xm1)
+
+	for i := 0; i < 10; i++ {
+		randS3(&m)
+		l.lift(&m)
+
+		// The lift is divisible by x - 1, and equal to m mod (3, Φ_n).
+		var sum uint16
+		for j := range l {
+			sum += l[j]
+		}
+		if modQ(sum) != 0 {
+			t.Fatalf("lift(m)(1) != 0 mod q")
+		}
+		s.rqToS3(&l)
+		if s != m {
+			t.Fatalf("lift(m) != m mod (3, Φ_n)")
+		}
+
+		// The lift is (x-1) * (m/(x-1) mod (3, Φ_n)).
+		b.s3Mul(&m, &invXm1)
+		b.z3ToZq()
+		p[0] = -b[0]
+		for j := 0; j < n-1; j++ {
+			p[j+1] = b[j] - b[j+1]
+		}
+		for j := range p {
+			if modQ(p[j]) != modQ(l[j]) {
+				t.Fatalf("lift(m) != (x-1) * (m/(x-1))")
+			}
+		}
+	}
+}
+
+func TestPacking(t *testing.T) {
+	var a, b poly
+	var buf3 [packTrinarySize]byte
+	var bufq [packSqSize]byte
+
+	for i := 0; i < 10; i++ {
+		randS3(&a)
+		a.s3ToBytes(buf3[:])
+		b.s3FromBytes(buf3[:])
+		if a != b {
+			t.Fatalf("s3FromBytes(s3ToBytes(a)) != a")
+		}
+
+		randRq(&a)
+		a[n-1] = 0
+		a.sqToBytes(bufq[:])
+		b.sqFromBytes(bufq[:])
+		if a != b {
+			t.Fatalf("sqFromBytes(sqToBytes(a)) != a")
+		}
+
+		var s uint16
+		for j := 0; j < n-1; j++ {
+			s += a[j]
+		}
+		a[n-1] = modQ(-s)
+		a.rqSumZeroToBytes(bufq[:])
+		b.rqSumZeroFromBytes(bufq[:])
+		b[n-1] = modQ(b[n-1])
+		if a != b {
+			t.Fatalf("rqSumZeroFromBytes(rqSumZeroToBytes(a)) != a")
+		}
+	}
+}
+
+func TestSampleIidPlus(t *testing.T) {
+	var r poly
+	var buf [sampleIidSize]byte
+	for i := 0; i < 100; i++ {
+		_, _ = rand.Read(buf[:])
+		r.sampleIidPlus(buf[:])
+
+		// <x*r, r> ≥ 0 with r taking values in {-1, 0, 1}.
+		s := 0
+		for j := 0; j < n-1; j++ {
+			s += centered(r[j+1]) * centered(r[j])
+		}
+		if s < 0 || r[n-1] != 0 {
+			t.Fatalf("<x*r, r> = %d < 0", s)
+		}
+	}
+}
+
+func centered(c uint16) int {
+	if c == 2 {
+		return -1
+	}
+	return int(c)
+}
+
+func BenchmarkRqMul(b *testing.B) {
+	var a, c, r poly
+	randRq(&a)
+	randRq(&c)
+	for i := 0; i < b.N; i++ {
+		r.rqMul(&a, &c)
+	}
+}
+
+func BenchmarkS3Inv(b *testing.B) {
+	var a, r poly
+	randS3(&a)
+	for i := 0; i < b.N; i++ {
+		r.s3Inv(&a)
+	}
+}
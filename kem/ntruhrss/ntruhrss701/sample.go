@@ -0,0 +1,52 @@
+package ntruhrss701
+
+// sampleIid sets r to a polynomial of degree at most n-2 with coefficients
+// in {0, 1, 2} taken from buf.  The distribution of each coefficient is
+// close to uniform: Pr[0] = 86/256, Pr[1] = Pr[2] = 85/256.
+func (r *poly) sampleIid(buf []byte) {
+	for i := 0; i < n-1; i++ {
+		r[i] = mod3(uint16(buf[i]))
+	}
+	r[n-1] = 0
+}
+
+// sampleIidPlus is as sampleIid, but conditionally flips the signs of the
+// even index coefficients of r so that <x*r, r> ≥ 0.
+func (r *poly) sampleIidPlus(buf []byte) {
+	r.sampleIid(buf)
+
+	// Map {0, 1, 2} → {0, 1, 2^16 - 1}.
+	for i := 0; i < n-1; i++ {
+		r[i] = r[i] | (-(r[i] >> 1))
+	}
+
+	// s = <x*r, r>, recall r[n-1] = 0.
+	var s uint16
+	for i := 0; i < n-1; i++ {
+		s += r[i+1] * r[i]
+	}
+
+	// Extract the sign of s, with sign(0) = 1.
+	s = 1 | (-(s >> 15))
+
+	for i := 0; i < n; i += 2 {
+		r[i] = s * r[i]
+	}
+
+	// Map {0, 1, 2^16 - 1} → {0, 1, 2}.
+	for i := range r {
+		r[i] = 3 & (r[i] ^ (r[i] >> 15))
+	}
+}
+
+// sampleFg samples the private polynomials f and g from buf.
+func sampleFg(f, g *poly, buf *[sampleFgSize]byte) {
+	f.sampleIidPlus(buf[:sampleIidSize])
+	g.sampleIidPlus(buf[sampleIidSize:])
+}
+
+// sampleRm samples the message polynomials r and m from buf.
+func sampleRm(r, m *poly, buf *[sampleRmSize]byte) {
+	r.sampleIid(buf[:sampleIidSize])
+	m.sampleIid(buf[sampleIidSize:])
+}
@@ -0,0 +1,72 @@
+// Package schemes contains a register of KEM schemes.
+//
+// Schemes Implemented
+//
+// Based on standard Diffie-Hellman functions:
+//
+//	HPKE_KEM_X25519_HKDF_SHA256, HPKE_KEM_X448_HKDF_SHA512
+//
+// Post-quantum KEMs:
+//
+//	SIKEp434, SIKEp503, SIKEp751, Kyber512, Kyber768, Kyber1024,
+//	ntruhrss701, FrodoKEM-640-SHAKE, FrodoKEM-640-AES, FrodoKEM-976-SHAKE,
+//	FrodoKEM-976-AES, FrodoKEM-1344-SHAKE, FrodoKEM-1344-AES
+package schemes
+
+import (
+	"strings"
+
+	"github.com/cloudflare/circl/kem"
+	"github.com/cloudflare/circl/kem/dhkem"
+	"github.com/cloudflare/circl/kem/frodo/frodo1344aes"
+	"github.com/cloudflare/circl/kem/frodo/frodo1344shake"
+	"github.com/cloudflare/circl/kem/frodo/frodo640aes"
+	"github.com/cloudflare/circl/kem/frodo/frodo640shake"
+	"github.com/cloudflare/circl/kem/frodo/frodo976aes"
+	"github.com/cloudflare/circl/kem/frodo/frodo976shake"
+	"github.com/cloudflare/circl/kem/kyber/kyber1024"
+	"github.com/cloudflare/circl/kem/kyber/kyber512"
+	"github.com/cloudflare/circl/kem/kyber/kyber768"
+	"github.com/cloudflare/circl/kem/ntruhrss/ntruhrss701"
+	"github.com/cloudflare/circl/kem/sike/sikep434"
+	"github.com/cloudflare/circl/kem/sike/sikep503"
+	"github.com/cloudflare/circl/kem/sike/sikep751"
+)
+
+var allSchemes = [...]kem.Scheme{
+	dhkem.X25519HKDFSHA256(),
+	dhkem.X448HKDFSHA512(),
+	sikep434.Scheme(),
+	sikep503.Scheme(),
+	sikep751.Scheme(),
+	kyber512.Scheme(),
+	kyber768.Scheme(),
+	kyber1024.Scheme(),
+	ntruhrss701.Scheme(),
+	frodo640shake.Scheme(),
+	frodo640aes.Scheme(),
+	frodo976shake.Scheme(),
+	frodo976aes.Scheme(),
+	frodo1344shake.Scheme(),
+	frodo1344aes.Scheme(),
+}
+
+var allSchemeNames map[string]kem.Scheme
+
+func init() {
+	allSchemeNames = make(map[string]kem.Scheme)
+	for _, scheme := range allSchemes {
+		allSchemeNames[strings.ToLower(scheme.Name())] = scheme
+	}
+}
+
+// ByName returns the scheme with the given name and nil if it is not
+// supported.
+//
+// Names are case insensitive.
+func ByName(name string) kem.Scheme {
+	return allSchemeNames[strings.ToLower(name)]
+}
+
+// All returns all KEM schemes supported.
+func All() []kem.Scheme { a := allSchemes; return a[:] }
@@ -0,0 +1,195 @@
+package schemes_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/kem/schemes"
+)
+
+func TestCaseSensitivity(t *testing.T) {
+	if schemes.ByName("sikep503") != schemes.ByName("SIKEp503") {
+		t.Fatal()
+	}
+	if schemes.ByName("unknown") != nil {
+		t.Fatal()
+	}
+}
+
+func TestApi(t *testing.T) {
+	for _, scheme := range schemes.All() {
+		scheme := scheme
+		t.Run(scheme.Name(), func(t *testing.T) {
+			if schemes.ByName(scheme.Name()) != scheme {
+				t.Fatal()
+			}
+
+			pk, sk, err := scheme.GenerateKeyPair()
+			test.CheckNoErr(t, err, "key generation failed")
+
+			packedPk, err := pk.MarshalBinary()
+			test.CheckNoErr(t, err, "public key encoding failed")
+			if len(packedPk) != scheme.PublicKeySize() {
+				test.ReportError(t, len(packedPk), scheme.PublicKeySize())
+			}
+			packedSk, err := sk.MarshalBinary()
+			test.CheckNoErr(t, err, "private key encoding failed")
+			if len(packedSk) != scheme.PrivateKeySize() {
+				test.ReportError(t, len(packedSk), scheme.PrivateKeySize())
+			}
+
+			pk2, err := scheme.UnmarshalBinaryPublicKey(packedPk)
+			test.CheckNoErr(t, err, "public key decoding failed")
+			sk2, err := scheme.UnmarshalBinaryPrivateKey(packedSk)
+			test.CheckNoErr(t, err, "private key decoding failed")
+			if !sk.Equal(sk2) || !pk.Equal(pk2) || !pk.Equal(sk2.Public()) {
+				t.Fatal("decoded keys differ")
+			}
+			if pk.Scheme() != scheme || sk.Scheme() != scheme {
+				t.Fatal("wrong scheme")
+			}
+
+			_, err = scheme.UnmarshalBinaryPublicKey(packedPk[1:])
+			test.CheckIsErr(t, err, "should fail with short public key")
+			_, err = scheme.UnmarshalBinaryPrivateKey(packedSk[1:])
+			test.CheckIsErr(t, err, "should fail with short private key")
+
+			ct, ss, err := scheme.Encapsulate(pk2)
+			test.CheckNoErr(t, err, "encapsulation failed")
+			if len(ct) != scheme.CiphertextSize() {
+				test.ReportError(t, len(ct), scheme.CiphertextSize())
+			}
+			if len(ss) != scheme.SharedKeySize() {
+				test.ReportError(t, len(ss), scheme.SharedKeySize())
+			}
+
+			ct3, ss3, err := scheme.Encapsulate(pk2)
+			test.CheckNoErr(t, err, "encapsulation failed")
+			if bytes.Equal(ss3, ss) || bytes.Equal(ct3, ct) {
+				t.Fatal("encapsulation is not randomized")
+			}
+
+			ss2, err := scheme.Decapsulate(sk2, ct)
+			test.CheckNoErr(t, err, "decapsulation failed")
+			if !bytes.Equal(ss, ss2) {
+				test.ReportError(t, ss2, ss)
+			}
+			_, err = scheme.Decapsulate(sk2, ct[1:])
+			test.CheckIsErr(t, err, "should fail with short ciphertext")
+		})
+	}
+}
+
+func TestDeterministic(t *testing.T) {
+	for _, scheme := range schemes.All() {
+		scheme := scheme
+		t.Run(scheme.Name(), func(t *testing.T) {
+			seed := make([]byte, scheme.SeedSize())
+			for i := range seed {
+				seed[i] = byte(i)
+			}
+			pk, sk := scheme.DeriveKeyPair(seed)
+			pk2, sk2 := scheme.DeriveKeyPair(seed)
+			if !sk.Equal(sk2) || !pk.Equal(pk2) {
+				t.Fatal("key derivation is not deterministic")
+			}
+			seed[0] ^= 1
+			_, sk3 := scheme.DeriveKeyPair(seed)
+			if sk.Equal(sk3) {
+				t.Fatal("different seeds give the same key")
+			}
+			err := test.CheckPanic(func() { scheme.DeriveKeyPair(seed[1:]) })
+			test.CheckNoErr(t, err, "should panic with short seed")
+
+			eseed := make([]byte, scheme.EncapsulationSeedSize())
+			ct, ss, err := scheme.EncapsulateDeterministically(pk, eseed)
+			test.CheckNoErr(t, err, "encapsulation failed")
+			ct2, ss2, err := scheme.EncapsulateDeterministically(pk, eseed)
+			test.CheckNoErr(t, err, "encapsulation failed")
+			if !bytes.Equal(ct, ct2) || !bytes.Equal(ss, ss2) {
+				t.Fatal("encapsulation is not deterministic")
+			}
+			got, err := scheme.Decapsulate(sk, ct)
+			test.CheckNoErr(t, err, "decapsulation failed")
+			if !bytes.Equal(got, ss) {
+				test.ReportError(t, got, ss)
+			}
+			_, _, err = scheme.EncapsulateDeterministically(pk, eseed[1:])
+			test.CheckIsErr(t, err, "should fail with short seed")
+		})
+	}
+}
+
+func TestTypeMismatch(t *testing.T) {
+	all := schemes.All()
+	for i, scheme := range all {
+		other := all[(i+1)%len(all)]
+		pk, sk, _ := other.GenerateKeyPair()
+		_, _, err := scheme.Encapsulate(pk)
+		test.CheckIsErr(t, err, "should fail with key of another scheme")
+		_, err = scheme.Decapsulate(sk, make([]byte, scheme.CiphertextSize()))
+		test.CheckIsErr(t, err, "should fail with key of another scheme")
+	}
+}
+
+func BenchmarkGenerateKeyPair(b *testing.B) {
+	for _, scheme := range schemes.All() {
+		scheme := scheme
+		b.Run(scheme.Name(), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _, _ = scheme.GenerateKeyPair()
+			}
+		})
+	}
+}
+
+func BenchmarkEncapsulate(b *testing.B) {
+	for _, scheme := range schemes.All() {
+		scheme := scheme
+		pk, _, _ := scheme.GenerateKeyPair()
+		b.Run(scheme.Name(), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _, _ = scheme.Encapsulate(pk)
+			}
+		})
+	}
+}
+
+func BenchmarkDecapsulate(b *testing.B) {
+	for _, scheme := range schemes.All() {
+		scheme := scheme
+		pk, sk, _ := scheme.GenerateKeyPair()
+		ct, _, _ := scheme.Encapsulate(pk)
+		b.Run(scheme.Name(), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _ = scheme.Decapsulate(sk, ct)
+			}
+		})
+	}
+}
+
+func Example_schemes() {
+	// import "github.com/cloudflare/circl/kem/schemes"
+
+	for _, sch := range schemes.All() {
+		fmt.Println(sch.Name())
+	}
+	// Output:
+	// HPKE_KEM_X25519_HKDF_SHA256
+	// HPKE_KEM_X448_HKDF_SHA512
+	// SIKEp434
+	// SIKEp503
+	// SIKEp751
+	// Kyber512
+	// Kyber768
+	// Kyber1024
+	// ntruhrss701
+	// FrodoKEM-640-SHAKE
+	// FrodoKEM-640-AES
+	// FrodoKEM-976-SHAKE
+	// FrodoKEM-976-AES
+	// FrodoKEM-1344-SHAKE
+	// FrodoKEM-1344-AES
+}
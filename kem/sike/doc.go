@@ -0,0 +1,8 @@
+//go:generate go run gen.go
+
+// Package sike provides the key encapsulation mechanism SIKE with the
+// interface of the kem package.
+//
+// The instances are generated from a template, one package for each
+// parameter set: sikep434, sikep503 and sikep751.
+package sike
@@ -0,0 +1,2 @@
+// Package pke provides a variety of public key encryption mechanisms.
+package pke
@@ -0,0 +1,21 @@
+// Package ecies implements the Elliptic Curve Integrated Encryption Scheme
+// over the P-384 and X25519 groups.
+//
+// Two variants are supported. SEC1 ECIES derives the encryption key from the
+// shared secret only, while ISO 18033-2 ECIES-KEM also includes the encoding
+// of the ephemeral public key in the input of the key derivation function.
+// The key is derived with either KDF2 (equivalent to ANSI X9.63 KDF) or HKDF,
+// and the message is encrypted with AES-256-GCM. The optional SharedInfo1 is
+// input to the key derivation function, and SharedInfo2 is authenticated as
+// additional data of the AEAD.
+//
+// For P-384, ephemeral public keys can be encoded in compressed or
+// uncompressed form, and both forms are accepted on decryption. Decryption
+// returns the same error for any failure, so that no information about the
+// cause is revealed to the sender of the ciphertext.
+//
+// References:
+//   - SEC1 https://www.secg.org/sec1-v2.pdf
+//   - ISO/IEC 18033-2:2006 https://www.shoup.net/iso/std6.pdf
+//   - RFC5869 https://rfc-editor.org/rfc/rfc5869.txt
+package ecies
@@ -0,0 +1,294 @@
+package ecies
+
+import (
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	_ "crypto/sha256" // Default hash function for X25519.
+	_ "crypto/sha512" // Default hash function for P-384.
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/cloudflare/circl/dh/x25519"
+	"github.com/cloudflare/circl/ecc/p384"
+)
+
+// Group identifies the group used for the key agreement.
+type Group int
+
+const (
+	// P384 is the group of points of the P-384 curve. Public keys are SEC1
+	// encoded points and private keys are 48-byte big-endian integers.
+	P384 Group = iota
+	// X25519 is the group of X25519 (RFC-7748). Public and private keys are
+	// 32-byte strings.
+	X25519
+)
+
+// Mode identifies the variant of the scheme.
+type Mode int
+
+const (
+	// SEC1 derives the key from the shared secret only (SEC1, Section 5.1).
+	SEC1 Mode = iota
+	// ISO18033 derives the key from the encoding of the ephemeral public key
+	// and the shared secret, as in ECIES-KEM (ISO 18033-2, Section 10.2).
+	ISO18033
+)
+
+// KDF identifies the key derivation function.
+type KDF int
+
+const (
+	// KDF2 is the key derivation function of ISO 18033-2, which is the same
+	// as the one of ANSI X9.63 used by SEC1.
+	KDF2 KDF = iota
+	// HKDF is the key derivation function of RFC-5869 with an empty salt.
+	HKDF
+)
+
+// KeySize is the length in bytes of the AES-256-GCM key.
+const KeySize = 32
+
+// Params specifies an instance of the scheme. Sender and recipient must use
+// the same parameters.
+type Params struct {
+	Group Group
+	Mode  Mode
+	KDF   KDF
+	// Hash is the hash function used by the KDF. If zero, SHA-384 is used for
+	// P384 and SHA-256 for X25519.
+	Hash crypto.Hash
+	// Compressed selects the compressed encoding for ephemeral and generated
+	// public keys. Only used with P384.
+	Compressed bool
+	// SharedInfo1 is optional data input to the KDF.
+	SharedInfo1 []byte
+	// SharedInfo2 is optional data authenticated by the AEAD.
+	SharedInfo2 []byte
+}
+
+var (
+	// ErrDecryption is returned when a ciphertext cannot be decrypted. The
+	// cause of the failure is deliberately not reported.
+	ErrDecryption = errors.New("ecies: decryption failed")
+
+	errParams     = errors.New("ecies: invalid parameters")
+	errPublicKey  = errors.New("ecies: invalid public key")
+	errPrivateKey = errors.New("ecies: invalid private key")
+)
+
+// GenerateKey generates a key pair for the group of p using the random source
+// rand.
+func (p *Params) GenerateKey(rand io.Reader) (pk, sk []byte, err error) {
+	switch p.Group {
+	case P384:
+		var k [p384.ScalarSize]byte
+		if err = randomScalar(rand, &k); err != nil {
+			return nil, nil, err
+		}
+		var P p384.Point
+		P.ScalarBaseMult(&k)
+		return p.appendPoint(nil, &P), k[:], nil
+	case X25519:
+		var public, secret x25519.Key
+		if _, err = io.ReadFull(rand, secret[:]); err != nil {
+			return nil, nil, err
+		}
+		x25519.KeyGen(&public, &secret)
+		return public[:], secret[:], nil
+	default:
+		return nil, nil, errParams
+	}
+}
+
+// Encrypt encrypts msg to the public key pk. The ciphertext consists of the
+// encoding of an ephemeral public key, followed by the AES-256-GCM encryption
+// of msg.
+func (p *Params) Encrypt(rand io.Reader, pk, msg []byte) ([]byte, error) {
+	h, err := p.hash()
+	if err != nil {
+		return nil, err
+	}
+
+	var ct, z []byte
+	switch p.Group {
+	case P384:
+		var Q, E, S p384.Point
+		if Q.Unmarshal(pk) != nil || Q.IsIdentity() {
+			return nil, errPublicKey
+		}
+		var k [p384.ScalarSize]byte
+		if err = randomScalar(rand, &k); err != nil {
+			return nil, err
+		}
+		E.ScalarBaseMult(&k)
+		S.ScalarMult(&k, &Q)
+		ct = p.appendPoint(nil, &E)
+		z = sharedX(&S)
+	case X25519:
+		var public, secret, ephemeral, shared x25519.Key
+		if len(pk) != x25519.Size {
+			return nil, errPublicKey
+		}
+		copy(public[:], pk)
+		if _, err = io.ReadFull(rand, secret[:]); err != nil {
+			return nil, err
+		}
+		if !x25519.Shared(&shared, &secret, &public) {
+			return nil, errPublicKey
+		}
+		x25519.KeyGen(&ephemeral, &secret)
+		ct = ephemeral[:]
+		z = shared[:]
+	default:
+		return nil, errParams
+	}
+
+	aead := p.newAEAD(h, ct, z)
+	nonce := make([]byte, aead.NonceSize())
+	return aead.Seal(ct, nonce, msg, p.SharedInfo2), nil
+}
+
+// Decrypt decrypts the ciphertext ct using the private key sk. It returns
+// ErrDecryption if the ciphertext is not valid.
+func (p *Params) Decrypt(sk, ct []byte) ([]byte, error) {
+	h, err := p.hash()
+	if err != nil {
+		return nil, err
+	}
+
+	var enc, z []byte
+	switch p.Group {
+	case P384:
+		var k [p384.ScalarSize]byte
+		if !setScalar(&k, sk) {
+			return nil, errPrivateKey
+		}
+		n := 0
+		if len(ct) > 0 {
+			switch ct[0] {
+			case 0x02, 0x03:
+				n = p384.CompressedSize
+			case 0x04:
+				n = p384.UncompressedSize
+			}
+		}
+		if n == 0 || len(ct) < n {
+			return nil, ErrDecryption
+		}
+		var E, S p384.Point
+		if E.Unmarshal(ct[:n]) != nil {
+			return nil, ErrDecryption
+		}
+		S.ScalarMult(&k, &E)
+		enc, z = ct[:n], sharedX(&S)
+	case X25519:
+		var public, secret, shared x25519.Key
+		if len(sk) != x25519.Size {
+			return nil, errPrivateKey
+		}
+		if len(ct) < x25519.Size {
+			return nil, ErrDecryption
+		}
+		copy(secret[:], sk)
+		copy(public[:], ct)
+		if !x25519.Shared(&shared, &secret, &public) {
+			return nil, ErrDecryption
+		}
+		enc, z = ct[:x25519.Size], shared[:]
+	default:
+		return nil, errParams
+	}
+
+	aead := p.newAEAD(h, enc, z)
+	nonce := make([]byte, aead.NonceSize())
+	msg, err := aead.Open(nil, nonce, ct[len(enc):], p.SharedInfo2)
+	if err != nil {
+		return nil, ErrDecryption
+	}
+	return msg, nil
+}
+
+// hash returns the hash function of the KDF.
+func (p *Params) hash() (crypto.Hash, error) {
+	h := p.Hash
+	if h == 0 {
+		h = crypto.SHA256
+		if p.Group == P384 {
+			h = crypto.SHA384
+		}
+	}
+	if !h.Available() || (p.Mode != SEC1 && p.Mode != ISO18033) ||
+		(p.KDF != KDF2 && p.KDF != HKDF) {
+		return 0, errParams
+	}
+	return h, nil
+}
+
+// newAEAD derives the key from the ephemeral public key enc and the shared
+// secret z, and returns an AES-256-GCM instance using it. Since every key is
+// used to encrypt a single message, a fixed nonce is used.
+func (p *Params) newAEAD(h crypto.Hash, enc, z []byte) cipher.AEAD {
+	secret := z
+	if p.Mode == ISO18033 {
+		secret = append(append(make([]byte, 0, len(enc)+len(z)), enc...), z...)
+	}
+	var key []byte
+	if p.KDF == HKDF {
+		key = deriveHKDF(h, secret, nil, p.SharedInfo1, KeySize)
+	} else {
+		key = kdf2(h, secret, p.SharedInfo1, KeySize)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		panic(err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		panic(err)
+	}
+	return aead
+}
+
+// appendPoint appends the encoding of P, as selected by p.Compressed.
+func (p *Params) appendPoint(b []byte, P *p384.Point) []byte {
+	if p.Compressed {
+		return P.AppendCompressed(b)
+	}
+	return P.AppendUncompressed(b)
+}
+
+// sharedX returns the x-coordinate of P, which is never the identity point
+// as the scalars and points used are never zero and the group has prime
+// order.
+func sharedX(P *p384.Point) []byte {
+	return P.AppendUncompressed(nil)[1 : 1+p384.ScalarSize]
+}
+
+// randomScalar sets k to a uniformly random integer in [1, N-1].
+func randomScalar(rand io.Reader, k *[p384.ScalarSize]byte) error {
+	for {
+		if _, err := io.ReadFull(rand, k[:]); err != nil {
+			return err
+		}
+		if setScalar(k, k[:]) {
+			return nil
+		}
+	}
+}
+
+// setScalar copies b into k and reports whether it is an integer in [1, N-1].
+func setScalar(k *[p384.ScalarSize]byte, b []byte) bool {
+	if len(b) != p384.ScalarSize {
+		return false
+	}
+	var n big.Int
+	n.SetBytes(b)
+	if n.Sign() == 0 || n.Cmp(p384.P384().Params().N) >= 0 {
+		return false
+	}
+	copy(k[:], b)
+	return true
+}
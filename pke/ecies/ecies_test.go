@@ -0,0 +1,189 @@
+package ecies_test
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"fmt"
+	"testing"
+
+	"github.com/cloudflare/circl/dh/x25519"
+	"github.com/cloudflare/circl/ecc/p384"
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/pke/ecies"
+)
+
+func allParams() []*ecies.Params {
+	var all []*ecies.Params
+	for _, g := range []ecies.Group{ecies.P384, ecies.X25519} {
+		for _, m := range []ecies.Mode{ecies.SEC1, ecies.ISO18033} {
+			for _, k := range []ecies.KDF{ecies.KDF2, ecies.HKDF} {
+				for _, c := range []bool{false, true} {
+					if g == ecies.X25519 && c {
+						continue
+					}
+					all = append(all, &ecies.Params{
+						Group: g, Mode: m, KDF: k, Compressed: c,
+						SharedInfo1: []byte("info1"),
+						SharedInfo2: []byte("info2"),
+					})
+				}
+			}
+		}
+	}
+	return all
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	msg := []byte("a small payload")
+	for _, p := range allParams() {
+		name := fmt.Sprintf("%+v", *p)
+		pk, sk, err := p.GenerateKey(rand.Reader)
+		test.CheckNoErr(t, err, "key generation failed")
+
+		ct, err := p.Encrypt(rand.Reader, pk, msg)
+		test.CheckNoErr(t, err, "encryption failed")
+		got, err := p.Decrypt(sk, ct)
+		test.CheckNoErr(t, err, "decryption failed")
+		if !bytes.Equal(got, msg) {
+			test.ReportError(t, got, msg, name)
+		}
+
+		// The ephemeral key has the same encoding as generated public keys.
+		gotLen := len(ct) - len(msg) - 16
+		wantLen := len(pk)
+		if gotLen != wantLen {
+			test.ReportError(t, gotLen, wantLen, name)
+		}
+
+		// Tampered ciphertexts.
+		for _, i := range []int{0, 1, len(pk), len(ct) - 1} {
+			bad := append([]byte{}, ct...)
+			bad[i] ^= 0x80
+			_, err = p.Decrypt(sk, bad)
+			if err != ecies.ErrDecryption {
+				test.ReportError(t, err, ecies.ErrDecryption, name, i)
+			}
+		}
+		_, err = p.Decrypt(sk, ct[:len(pk)-1])
+		if err != ecies.ErrDecryption {
+			test.ReportError(t, err, ecies.ErrDecryption, name)
+		}
+
+		// Different shared information.
+		q := *p
+		q.SharedInfo1 = nil
+		_, err = q.Decrypt(sk, ct)
+		if err != ecies.ErrDecryption {
+			test.ReportError(t, err, ecies.ErrDecryption, name)
+		}
+		q = *p
+		q.SharedInfo2 = []byte("other")
+		_, err = q.Decrypt(sk, ct)
+		if err != ecies.ErrDecryption {
+			test.ReportError(t, err, ecies.ErrDecryption, name)
+		}
+
+		// Another variant of the scheme cannot decrypt.
+		q = *p
+		q.Mode = 1 - p.Mode
+		_, err = q.Decrypt(sk, ct)
+		if err != ecies.ErrDecryption {
+			test.ReportError(t, err, ecies.ErrDecryption, name)
+		}
+		q = *p
+		q.Hash = crypto.SHA512
+		_, err = q.Decrypt(sk, ct)
+		if err != ecies.ErrDecryption {
+			test.ReportError(t, err, ecies.ErrDecryption, name)
+		}
+	}
+}
+
+func TestCompressedEphemeral(t *testing.T) {
+	// Decryption accepts both encodings of the ephemeral key.
+	msg := []byte("a small payload")
+	p := &ecies.Params{Group: ecies.P384, Compressed: true}
+	pk, sk, err := p.GenerateKey(rand.Reader)
+	test.CheckNoErr(t, err, "key generation failed")
+	ct, err := p.Encrypt(rand.Reader, pk, msg)
+	test.CheckNoErr(t, err, "encryption failed")
+
+	q := *p
+	q.Compressed = false
+	got, err := q.Decrypt(sk, ct)
+	test.CheckNoErr(t, err, "decryption failed")
+	if !bytes.Equal(got, msg) {
+		test.ReportError(t, got, msg)
+	}
+
+	// The public key can be given in any encoding.
+	var P p384.Point
+	test.CheckNoErr(t, P.Unmarshal(pk), "invalid public key")
+	ct, err = q.Encrypt(rand.Reader, P.AppendUncompressed(nil), msg)
+	test.CheckNoErr(t, err, "encryption failed")
+	got, err = p.Decrypt(sk, ct)
+	test.CheckNoErr(t, err, "decryption failed")
+	if !bytes.Equal(got, msg) {
+		test.ReportError(t, got, msg)
+	}
+}
+
+func TestInvalidKeys(t *testing.T) {
+	msg := []byte("a small payload")
+
+	p := &ecies.Params{Group: ecies.P384}
+	for _, pk := range [][]byte{nil, {0x00}, make([]byte, p384.UncompressedSize)} {
+		_, err := p.Encrypt(rand.Reader, pk, msg)
+		test.CheckIsErr(t, err, "should fail with invalid public key")
+	}
+	pk, _, _ := p.GenerateKey(rand.Reader)
+	ct, _ := p.Encrypt(rand.Reader, pk, msg)
+	for _, sk := range [][]byte{nil, make([]byte, p384.ScalarSize), bytes.Repeat([]byte{0xFF}, p384.ScalarSize)} {
+		_, err := p.Decrypt(sk, ct)
+		test.CheckIsErr(t, err, "should fail with invalid private key")
+	}
+
+	// Low-order points of X25519 are rejected.
+	p = &ecies.Params{Group: ecies.X25519}
+	lowOrder := make([]byte, x25519.Size)
+	_, err := p.Encrypt(rand.Reader, lowOrder, msg)
+	test.CheckIsErr(t, err, "should fail with low-order public key")
+	_, sk, _ := p.GenerateKey(rand.Reader)
+	_, err = p.Decrypt(sk, append(lowOrder, make([]byte, 32)...))
+	if err != ecies.ErrDecryption {
+		test.ReportError(t, err, ecies.ErrDecryption)
+	}
+
+	// Invalid parameters.
+	for _, q := range []ecies.Params{{Group: 2}, {Mode: 2}, {KDF: 2}, {Hash: crypto.Hash(0xFF)}} {
+		_, _, err = q.GenerateKey(rand.Reader)
+		_, err2 := q.Encrypt(rand.Reader, pk, msg)
+		if q.Group == 2 {
+			test.CheckIsErr(t, err, "should fail with invalid group")
+		}
+		test.CheckIsErr(t, err2, "should fail with invalid parameters")
+	}
+}
+
+func BenchmarkECIES(b *testing.B) {
+	msg := make([]byte, 64)
+	for _, p := range []*ecies.Params{
+		{Group: ecies.P384, Compressed: true},
+		{Group: ecies.X25519},
+	} {
+		pk, sk, _ := p.GenerateKey(rand.Reader)
+		ct, _ := p.Encrypt(rand.Reader, pk, msg)
+		name := map[ecies.Group]string{ecies.P384: "P384", ecies.X25519: "X25519"}[p.Group]
+		b.Run(name+"/Encrypt", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _ = p.Encrypt(rand.Reader, pk, msg)
+			}
+		})
+		b.Run(name+"/Decrypt", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _ = p.Decrypt(sk, ct)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package ecies
+
+import (
+	"crypto"
+	"encoding/binary"
+
+	"github.com/cloudflare/circl/internal/hkdf"
+)
+
+// kdf2 derives length bytes from the secret z and the optional info using
+// KDF2 from ISO 18033-2, that is, the concatenation of Hash(z||counter||info)
+// for counter = 1, 2, ...
+func kdf2(h crypto.Hash, z, info []byte, length int) []byte {
+	out := make([]byte, 0, length+h.Size())
+	var counter [4]byte
+	hh := h.New()
+	for i := uint32(1); len(out) < length; i++ {
+		binary.BigEndian.PutUint32(counter[:], i)
+		hh.Reset()
+		_, _ = hh.Write(z)
+		_, _ = hh.Write(counter[:])
+		_, _ = hh.Write(info)
+		out = hh.Sum(out)
+	}
+	return out[:length]
+}
+
+// deriveHKDF derives length bytes from the secret z, the salt and the
+// optional info using HKDF as specified in RFC-5869.
+func deriveHKDF(h crypto.Hash, z, salt, info []byte, length int) []byte {
+	return hkdf.Expand(h.New, hkdf.Extract(h.New, z, salt), info, length)
+}
@@ -0,0 +1,43 @@
+package ecies
+
+import (
+	"bytes"
+	"crypto"
+	"encoding/hex"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+)
+
+func TestKDF(t *testing.T) {
+	hexDecode := func(s string) []byte { b, _ := hex.DecodeString(s); return b }
+
+	// RFC-5869 (Appendix A.1).
+	got := deriveHKDF(crypto.SHA256,
+		hexDecode("0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b0b"),
+		hexDecode("000102030405060708090a0b0c"),
+		hexDecode("f0f1f2f3f4f5f6f7f8f9"), 42)
+	want := hexDecode("3cb25f25faacd57a90434f64d0362f2a2d2d0a90cf1a5a4c5db02d56ecc4c5bf34007208d5b887185865")
+	if !bytes.Equal(got, want) {
+		test.ReportError(t, got, want)
+	}
+
+	// NIST CAVS ANSI X9.63 KDF (SHA-256).
+	got = kdf2(crypto.SHA256,
+		hexDecode("96c05619d56c328ab95fe84b18264b08725b85e33fd34f08"), nil, 16)
+	want = hexDecode("443024c3dae66b95e6f5670601558f71")
+	if !bytes.Equal(got, want) {
+		test.ReportError(t, got, want)
+	}
+
+	got = kdf2(crypto.SHA256,
+		hexDecode("22518b10e70f2a3f243810ae3254139efbee04aa57c7af7d"),
+		hexDecode("75eef81aa3041e33b80971203d2c0c52"), 128)
+	want = hexDecode("c498af77161cc59f2962b9a713e2b215152d139766ce34a776df11866a69bf2e" +
+		"52a13d9c7c6fc878c50c5ea0bc7b00e0da2447cfd874f6cf92f30d0097111485" +
+		"500c90c3af8b487872d04685d14c8d1dc8d7fa08beb0ce0ababc11f0bd496269" +
+		"142d43525a78e5bc79a17f59676a5706dc54d54d4d1f0bd7e386128ec26afc21")
+	if !bytes.Equal(got, want) {
+		test.ReportError(t, got, want)
+	}
+}
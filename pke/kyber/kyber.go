@@ -0,0 +1,14 @@
+//go:generate go run gen.go
+
+// Package kyber implements the CRYSTALS-Kyber.CPAPKE public key encryption
+// as submitted to round 3 of the NIST PQC competition and described in
+//
+//	https://pq-crystals.org/kyber/data/kyber-specification-round3.pdf
+//
+// The parameter sets Kyber512, Kyber768 and Kyber1024 are implemented in the
+// subpackages of the same name. Polynomial arithmetic is implemented in pure
+// Go, without assembly.
+//
+// The related key encapsulation mechanism (KEM) CRYSTALS-Kyber.CCAKEM can
+// be found in the package github.com/cloudflare/circl/kem/kyber.
+package kyber
@@ -0,0 +1,17 @@
+// Package sha3 implements the SHA-3 hash functions SHA3-224, SHA3-256,
+// SHA3-384 and SHA3-512, and the extendable-output functions SHAKE128 and
+// SHAKE256 of FIPS 202, together with their customizable versions cSHAKE128
+// and cSHAKE256 of NIST SP 800-185.
+//
+// This code has been copied from golang.org/x/crypto/sha3 and heavily
+// modified. This version doesn't use heap when computing cSHAKE. It makes
+// it possible to allocate heap once when object is created and then reuse
+// heap allocated structures in subsequent calls.
+//
+// The fixed-output functions implement hash.Hash, and the extendable-output
+// functions implement XOF, which can be read to any length and cloned.
+//
+// References:
+//   - FIPS 202 https://doi.org/10.6028/NIST.FIPS.202
+//   - NIST SP 800-185 https://doi.org/10.6028/NIST.SP.800-185
+package sha3
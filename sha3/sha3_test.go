@@ -0,0 +1,584 @@
+// Copyright 2014 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sha3
+
+// Tests include all the ShortMsgKATs provided by the Keccak team at
+// https://github.com/gvanas/KeccakCodePackage
+//
+// They only include the zero-bit case of the bitwise testvectors
+// published by NIST in the draft of FIPS-202.
+//
+// The cSHAKE tests are the examples published by NIST at
+// https://csrc.nist.gov/projects/cryptographic-standards-and-guidelines/example-values
+//
+// The ACVP tests are the SHA3, SHAKE and cSHAKE vector sets of the NIST
+// Automated Cryptographic Validation Testing System, with their validated
+// results, as collected at https://github.com/geomys/acvp-testdata. They are
+// courtesy of NIST, and reproduced under the license of
+// https://github.com/usnistgov/ACVP-Server. In October 2026 they were merged
+// into a single file, and the intermediate results of the SHAKE and cSHAKE
+// Monte Carlo tests were cut to their first 16 bytes, which are the input of
+// the next iteration.
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+const (
+	testString   = "brekeccakkeccak koax koax"
+	katFilename  = "testdata/keccakKats.json.deflate"
+	acvpFilename = "testdata/acvp.json.deflate"
+)
+
+// decodeHex converts a hex-encoded string into a raw byte string.
+func decodeHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// structs used to marshal JSON test-cases.
+type KeccakKats struct {
+	Kats map[string][]struct {
+		Digest  string `json:"digest"`
+		Length  int64  `json:"length"`
+		Message string `json:"message"`
+
+		// Defined only for cSHAKE
+		N string `json:"N"`
+		S string `json:"S"`
+	}
+}
+
+// TestKeccakKats tests the SHA-3 and Shake implementations against all the
+// ShortMsgKATs from https://github.com/gvanas/KeccakCodePackage
+// (The testvectors are stored in keccakKats.json.deflate due to their length.)
+func TestKeccakKats(t *testing.T) {
+	// Read the KATs.
+	deflated, err := os.Open(katFilename)
+	if err != nil {
+		t.Errorf("error opening %s: %s", katFilename, err)
+	}
+	file := flate.NewReader(deflated)
+	dec := json.NewDecoder(file)
+	var katSet KeccakKats
+	err = dec.Decode(&katSet)
+	if err != nil {
+		t.Errorf("error decoding KATs: %s", err)
+	}
+
+	for function, newHash := range map[string]func() hash.Hash{
+		"SHA3-224": New224,
+		"SHA3-256": New256,
+		"SHA3-384": New384,
+		"SHA3-512": New512,
+		"SHAKE128": func() hash.Hash { return NewShake128() },
+		"SHAKE256": func() hash.Hash { return NewShake256() },
+	} {
+		for _, kat := range katSet.Kats[function] {
+			d := newHash()
+			in, err := hex.DecodeString(kat.Message)
+			if err != nil {
+				t.Errorf("error decoding KAT: %s", err)
+			}
+
+			d.Write(in[:kat.Length/8])
+			out := make([]byte, len(kat.Digest)/2)
+			d.(io.Reader).Read(out)
+			got := strings.ToUpper(hex.EncodeToString(out))
+			if got != kat.Digest {
+				t.Errorf("function=%s, length=%d N:%s\n S:%s\nmessage:\n %s \ngot:\n  %s\nwanted:\n %s",
+					function, kat.Length, kat.N, kat.S, kat.Message, got, kat.Digest)
+				t.Logf("wanted %+v", kat)
+				t.FailNow()
+			}
+		}
+	}
+}
+
+// acvpTest is a test case of an ACVP vector set, merged with its expected
+// results.
+type acvpTest struct {
+	Algorithm     string `json:"algorithm"`
+	TestType      string `json:"testType"`
+	TcID          int    `json:"tcId"`
+	Msg           string `json:"msg"`
+	Len           int    `json:"len"`
+	OutLen        int    `json:"outLen"`
+	FunctionName  string `json:"functionName"`
+	Customization string `json:"customization"`
+	Md            string `json:"md"`
+
+	// Defined only for the Monte Carlo tests of SHAKE and cSHAKE
+	MinOutLen       int `json:"minOutLen"`
+	MaxOutLen       int `json:"maxOutLen"`
+	OutLenIncrement int `json:"outLenIncrement"`
+
+	ResultsArray []struct {
+		Md     string `json:"md"`
+		OutLen int    `json:"outLen"`
+	} `json:"resultsArray"`
+}
+
+// TestACVP tests the SHA-3, SHAKE and cSHAKE implementations against the
+// vector sets of NIST's ACVP server. The Monte Carlo tests follow Section
+// 6.2 of https://pages.nist.gov/ACVP/draft-celi-acvp-sha3.html and Section
+// 6.2 of https://pages.nist.gov/ACVP/draft-celi-acvp-xof.html.
+func TestACVP(t *testing.T) {
+	deflated, err := os.Open(acvpFilename)
+	if err != nil {
+		t.Fatalf("error opening %s: %s", acvpFilename, err)
+	}
+	defer deflated.Close()
+	var tests []acvpTest
+	if err := json.NewDecoder(flate.NewReader(deflated)).Decode(&tests); err != nil {
+		t.Fatalf("error decoding ACVP tests: %s", err)
+	}
+
+	sha3 := map[string]func() hash.Hash{
+		"SHA3-224": New224,
+		"SHA3-256": New256,
+		"SHA3-384": New384,
+		"SHA3-512": New512,
+	}
+	xof := map[string]func(N, S []byte) XOF{
+		"SHAKE-128":  func(_, _ []byte) XOF { return NewShake128() },
+		"SHAKE-256":  func(_, _ []byte) XOF { return NewShake256() },
+		"cSHAKE-128": func(N, S []byte) XOF { return NewCShake128(N, S) },
+		"cSHAKE-256": func(N, S []byte) XOF { return NewCShake256(N, S) },
+	}
+
+	for _, v := range tests {
+		msg := decodeHex(v.Msg)[:v.Len/8]
+		newHash, isHash := sha3[v.Algorithm]
+		newXOF, isXOF := xof[v.Algorithm]
+		switch {
+		case isHash && v.TestType == "AFT":
+			h := newHash()
+			h.Write(msg)
+			if got := hex.EncodeToString(h.Sum(nil)); got != v.Md {
+				t.Errorf("%s tcId=%d: got %s, want %s", v.Algorithm, v.TcID, got, v.Md)
+			}
+		case isHash && v.TestType == "MCT":
+			md := msg
+			for j, r := range v.ResultsArray {
+				for i := 0; i < 1000; i++ {
+					h := newHash()
+					h.Write(md)
+					md = h.Sum(nil)
+				}
+				if got := hex.EncodeToString(md); got != r.Md {
+					t.Fatalf("%s tcId=%d: iteration %d: got %s, want %s", v.Algorithm, v.TcID, j, got, r.Md)
+				}
+			}
+		case isXOF && (v.TestType == "AFT" || v.TestType == "VOT"):
+			x := newXOF([]byte(v.FunctionName), []byte(v.Customization))
+			x.Write(msg)
+			out := make([]byte, v.OutLen/8)
+			_, _ = x.Read(out)
+			if got := hex.EncodeToString(out); got != v.Md {
+				t.Errorf("%s tcId=%d: got %s, want %s", v.Algorithm, v.TcID, got, v.Md)
+			}
+		case isXOF && v.TestType == "MCT":
+			// Output lengths are in bytes, the bounds of the vector sets are
+			// in bits.
+			minLen, maxLen := v.MinOutLen/8, v.MaxOutLen/8
+			outLen, out := maxLen, msg
+			var S []byte
+			for j, r := range v.ResultsArray {
+				for i := 0; i < 1000; i++ {
+					var in [16]byte
+					copy(in[:], out)
+					x := newXOF(nil, S)
+					x.Write(in[:])
+					out = make([]byte, outLen)
+					_, _ = x.Read(out)
+
+					right := int(out[outLen-2])<<8 | int(out[outLen-1])
+					if v.OutLenIncrement == 0 {
+						outLen = minLen + right%(maxLen-minLen+1)
+					} else {
+						// cSHAKE also takes the next customization string
+						// from the input and the last output bits.
+						inc := v.OutLenIncrement
+						outLen = (v.MinOutLen + right%(v.MaxOutLen-v.MinOutLen+1)/inc*inc) / 8
+						S = append(in[:], out[len(out)-2:]...)
+						for k := range S {
+							S[k] = 'A' + S[k]%26
+						}
+					}
+				}
+				got := hex.EncodeToString(out)
+				if !strings.HasPrefix(got, r.Md) || 8*len(out) != r.OutLen {
+					t.Fatalf("%s tcId=%d: iteration %d: got %s (%d bits), want %s (%d bits)",
+						v.Algorithm, v.TcID, j, got, 8*len(out), r.Md, r.OutLen)
+				}
+			}
+		default:
+			t.Fatalf("%s tcId=%d: unknown test type %s", v.Algorithm, v.TcID, v.TestType)
+		}
+	}
+}
+
+// TestSum checks that the Sum functions match the hash.Hash interface.
+func TestSum(t *testing.T) {
+	data := sequentialBytes(200)
+	d224, d256, d384, d512 := Sum224(data), Sum256(data), Sum384(data), Sum512(data)
+	for _, v := range []struct {
+		h    hash.Hash
+		want []byte
+	}{
+		{New224(), d224[:]},
+		{New256(), d256[:]},
+		{New384(), d384[:]},
+		{New512(), d512[:]},
+	} {
+		v.h.Write(data)
+		if got := v.h.Sum(nil); !bytes.Equal(got, v.want) {
+			t.Errorf("SHA3-%d: got %x want %x", 8*v.h.Size(), got, v.want)
+		}
+	}
+
+	// The Sum of a XOF is a prefix of its output, and leaves its state
+	// unchanged.
+	for _, h := range []XOF{NewShake128(), NewShake256()} {
+		h.Write(data)
+		sum := h.Sum(nil)
+		want := make([]byte, 2*h.Size())
+		h.Read(want)
+		if !bytes.Equal(sum, want[:h.Size()]) {
+			t.Errorf("SHAKE: got %x want %x", sum, want[:h.Size()])
+		}
+	}
+	want := make([]byte, 100)
+	got := make([]byte, 100)
+	h := NewShake256()
+	h.Write(data)
+	h.Read(want)
+	ShakeSum256(got, data)
+	if !bytes.Equal(got, want) {
+		t.Errorf("ShakeSum256: got %x want %x", got, want)
+	}
+}
+
+func TestCShake(t *testing.T) {
+	data := sequentialBytes(200)
+	for i, v := range []struct {
+		newXOF func(N, S []byte) *Shake
+		data   []byte
+		want   string
+	}{
+		{
+			NewCShake128, data[:4],
+			"c1c36925b6409a04f1b504fcbca9d82b4017277cb5ed2b2065fc1d3814d5aaf5",
+		},
+		{
+			NewCShake128, data,
+			"c5221d50e4f822d96a2e8881a961420f294b7b24fe3d2094baed2c6524cc166b",
+		},
+		{
+			NewCShake256, data[:4],
+			"d008828e2b80ac9d2218ffee1d070c48b8e4c87bff32c9699d5b6896eee0edd1" +
+				"64020e2be0560858d9c00c037e34a96937c561a74c412bb4c746469527281c8c",
+		},
+		{
+			NewCShake256, data,
+			"07dc27b11e51fbac75bc7b3c1d983e8b4b85fb1defaf218912ac864302730917" +
+				"27f42b17ed1df63e8ec118f04b23633c1dfb1574c8fb55cb45da8e25afb092bb",
+		},
+	} {
+		want := decodeHex(v.want)
+		got := make([]byte, len(want))
+		h := v.newXOF(nil, []byte("Email Signature"))
+		h.Write(v.data)
+		h.Read(got)
+		if !bytes.Equal(got, want) {
+			t.Errorf("sample %d: got %x want %x", i+1, got, want)
+		}
+
+		// Reset and Clone keep the customization.
+		h.Reset()
+		h.Write(v.data[:1])
+		h2 := h.Clone()
+		h2.Write(v.data[1:])
+		h2.Read(got)
+		if !bytes.Equal(got, want) {
+			t.Errorf("sample %d after reset: got %x want %x", i+1, got, want)
+		}
+	}
+
+	// Without function name nor customization string, cSHAKE is SHAKE.
+	want := make([]byte, 64)
+	got := make([]byte, 64)
+	h := NewShake128()
+	h.Write(data)
+	h.Read(want)
+	c := NewCShake128(nil, nil)
+	c.Write(data)
+	c.Read(got)
+	if !bytes.Equal(got, want) {
+		t.Errorf("cSHAKE128: got %x want %x", got, want)
+	}
+}
+
+func TestLeftEncode(t *testing.T) {
+	var b [9]byte
+	for _, v := range []struct {
+		x    uint64
+		want []byte
+	}{
+		{0, []byte{1, 0}},
+		{255, []byte{1, 255}},
+		{256, []byte{2, 1, 0}},
+		{168, []byte{1, 168}},
+		{1<<64 - 1, []byte{8, 255, 255, 255, 255, 255, 255, 255, 255}},
+	} {
+		if got := leftEncode(&b, v.x); !bytes.Equal(got, v.want) {
+			t.Errorf("leftEncode(%d): got %x want %x", v.x, got, v.want)
+		}
+	}
+}
+
+// TestKeccak does a basic test of the non-standardized Keccak hash functions.
+func TestKeccak(t *testing.T) {
+	tests := []struct {
+		fn   func() hash.Hash
+		data []byte
+		want string
+	}{
+		{
+			NewLegacyKeccak256,
+			[]byte("abc"),
+			"4e03657aea45a94fc7d47ba826c8d667c0d1e6e33a64a036ec44f58fa12d6c45",
+		},
+	}
+
+	for _, u := range tests {
+		h := u.fn()
+		h.Write(u.data)
+		got := h.Sum(nil)
+		want := decodeHex(u.want)
+		if !bytes.Equal(got, want) {
+			t.Errorf("unexpected hash for size %d: got '%x' want '%s'", h.Size()*8, got, u.want)
+		}
+	}
+}
+
+// TestUnalignedWrite tests that writing data in an arbitrary pattern with
+// small input buffers.
+func TestUnalignedWrite(t *testing.T) {
+	buf := sequentialBytes(0x10000)
+
+	// Same for SHAKE
+	want := make([]byte, 16)
+	got := make([]byte, 16)
+	d := NewShake256()
+
+	d.Reset()
+	d.Write(buf)
+	d.Read(want)
+	d.Reset()
+	for i := 0; i < len(buf); {
+		// Cycle through offsets which make a 137 byte sequence.
+		// Because 137 is prime this sequence should exercise all corner cases.
+		offsets := [17]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 1}
+		for _, j := range offsets {
+			if v := len(buf) - i; v < j {
+				j = v
+			}
+			d.Write(buf[i : i+j])
+			i += j
+		}
+	}
+	d.Read(got)
+	if !bytes.Equal(got, want) {
+		t.Errorf("Unaligned writes, alg=SHAKE256\ngot %q, want %q", got, want)
+	}
+}
+
+// TestSqueezing checks that squeezing the full output a single time produces
+// the same output as repeatedly squeezing the instance.
+func TestSqueezing(t *testing.T) {
+	d0 := NewShake256()
+	d0.Write([]byte(testString))
+	ref := make([]byte, 32)
+	d0.Read(ref)
+
+	d1 := NewShake256()
+	d1.Write([]byte(testString))
+	multiple := make([]byte, 0, len(ref))
+	for range ref {
+		one := make([]byte, 1)
+		d1.Read(one)
+		multiple = append(multiple, one...)
+	}
+	if !bytes.Equal(ref, multiple) {
+		t.Errorf("SHAKE256 : squeezing %d bytes one at a time failed", len(ref))
+	}
+}
+
+// sequentialBytes produces a buffer of size consecutive bytes 0x00, 0x01, ..., used for testing.
+func sequentialBytes(size int) []byte {
+	result := make([]byte, size)
+	for i := range result {
+		result[i] = byte(i)
+	}
+	return result
+}
+
+func TestReset(t *testing.T) {
+	out1 := make([]byte, 32)
+	out2 := make([]byte, 32)
+
+	// Calculate hash for the first time
+	c := NewShake256()
+	c.Write(sequentialBytes(0x100))
+	c.Read(out1)
+
+	// Calculate hash again
+	c.Reset()
+	c.Write(sequentialBytes(0x100))
+	c.Read(out2)
+
+	if !bytes.Equal(out1, out2) {
+		t.Error("\nExpected:\n", out1, "\ngot:\n", out2)
+	}
+}
+
+func TestClone(t *testing.T) {
+	out1 := make([]byte, 16)
+	out2 := make([]byte, 16)
+	in := sequentialBytes(0x100)
+
+	h1 := NewShake256()
+	h1.Write([]byte{0x01})
+
+	h2 := h1.Clone()
+
+	h1.Write(in)
+	h1.Read(out1)
+
+	h2.Write(in)
+	h2.Read(out2)
+
+	if !bytes.Equal(out1, out2) {
+		t.Error("\nExpected:\n", hex.EncodeToString(out1), "\ngot:\n", hex.EncodeToString(out2))
+	}
+}
+
+// Checks wether reset works correctly after clone
+func TestCloneAndReset(t *testing.T) {
+	// Shake 256, uses SHA-3 with rate = 136
+	d1 := NewShake256()
+	buf1 := make([]byte, 28)
+	buf2 := make([]byte, 28)
+	d1.Write([]byte{0xcc})
+	// Reading x bytes where x<168-136. This makes capability
+	// of the state buffer shorter.
+	d1.Read(buf1)
+	// This will crash if sha-3 code uses cap() instead
+	// of len() when calculating length of state buffer
+	d2 := d1.Clone()
+	d2.Reset()
+	d2.Write([]byte{0xcc})
+	d2.Read(buf2)
+
+	if !bytes.Equal(buf1, buf2) {
+		t.Error("Different value when reading after reset")
+	}
+}
+
+// BenchmarkPermutationFunction measures the speed of the permutation function
+// with no input data.
+func BenchmarkPermutationFunction(b *testing.B) {
+	b.SetBytes(int64(200))
+	var lanes [25]uint64
+	for i := 0; i < b.N; i++ {
+		keccakF1600(&lanes)
+	}
+}
+
+// benchmarkShake is specialized to the Shake instances, which don't
+// require a copy on reading output.
+func benchmarkShake(b *testing.B, h *Shake, size, num int) {
+	b.StopTimer()
+	h.Reset()
+	data := sequentialBytes(size)
+	var d [32]byte
+
+	b.SetBytes(int64(size * num))
+	b.StartTimer()
+
+	for i := 0; i < b.N; i++ {
+		h.Reset()
+		for j := 0; j < num; j++ {
+			h.Write(data)
+		}
+		h.Read(d[:])
+	}
+}
+
+func BenchmarkShake256_MTU(b *testing.B)  { benchmarkShake(b, NewShake256(), 1350, 1) }
+func BenchmarkShake256_16x(b *testing.B)  { benchmarkShake(b, NewShake256(), 16, 1024) }
+func BenchmarkShake256_1MiB(b *testing.B) { benchmarkShake(b, NewShake256(), 1024, 1024) }
+func BenchmarkCShake256_448_16x(b *testing.B) {
+	benchmarkShake(b, NewCShake256(nil, []byte("Email Signature")), 448, 16)
+}
+func BenchmarkCShake256_1MiB(b *testing.B) {
+	benchmarkShake(b, NewCShake256(nil, []byte("Email Signature")), 1024, 1024)
+}
+
+func Example_sum() {
+	buf := []byte("some data to hash")
+	// A hash needs to be 64 bytes long to have 256-bit collision resistance.
+	h := make([]byte, 64)
+	// Compute a 64-byte hash of buf and put it in h.
+	shake := NewShake256()
+	shake.Write(buf)
+	shake.Read(h)
+	fmt.Printf("%x\n", h)
+	// Output: 0f65fe41fc353e52c55667bb9e2b27bfcc8476f2c413e9437d272ee3194a4e3146d05ec04a25d16b8f577c19b82d16b1424c3e022e783d2b4da98de3658d363d
+}
+
+func Example_mac() {
+	k := []byte("this is a secret key; you should generate a strong random key that's at least 32 bytes long")
+	buf := []byte("and this is some data to authenticate")
+	// A MAC with 32 bytes of output has 256-bit security strength -- if you use at least a 32-byte-long key.
+	h := make([]byte, 32)
+	d := NewShake256()
+	// Write the key into the hash.
+	d.Write(k)
+	// Now write the data.
+	d.Write(buf)
+	// Read 32 bytes of output from the hash into h.
+	d.Read(h)
+	fmt.Printf("%x\n", h)
+	// Output: 78de2974bd2711d5549ffd32b753ef0f5fa80a0db2556db60f0987eb8a9218ff
+}
+
+func ExampleShake() {
+	out := make([]byte, 32)
+	msg := []byte("The quick brown fox jumps over the lazy dog")
+
+	// Example 1: Simple Shake
+	c1 := NewShake256()
+	c1.Write(msg)
+	c1.Read(out)
+	fmt.Println(hex.EncodeToString(out))
+
+	// Output:
+	//2f671343d9b2e1604dc9dcf0753e5fe15c7c64a0d283cbbf722d411a0e36f6ca
+}
@@ -0,0 +1,143 @@
+// Copyright 2014 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sha3
+
+// This file defines the Shake struct, and provides
+// functions for creating SHAKE and cSHAKE instances, as well as utility
+// functions for hashing bytes to arbitrary-length output.
+//
+//
+// SHAKE implementation is based on FIPS PUB 202 [1]
+// cSHAKE implementations is based on NIST SP 800-185 [2]
+//
+// [1] https://nvlpubs.nist.gov/nistpubs/FIPS/NIST.FIPS.202.pdf
+// [2] https://doi.org/10.6028/NIST.SP.800-185
+
+import (
+	"hash"
+	"io"
+)
+
+// XOF is an extendable-output function: a hash.Hash whose output can be
+// read to any length with Read, and whose state can be cloned.
+type XOF interface {
+	hash.Hash
+	io.Reader
+
+	// Clone returns a copy of the XOF in its current state.
+	Clone() XOF
+}
+
+// cSHAKE specific context
+type Shake struct {
+	state // SHA-3 state context and Read/Write operations
+
+	// initState is the state after absorbing the function name and
+	// customization string of cSHAKE, and zero for SHAKE. It is kept so
+	// that Reset doesn't need to absorb them again.
+	initState [25]uint64
+}
+
+var _ XOF = (*Shake)(nil)
+
+// Consts for configuring initial SHA-3 state
+const (
+	dsbyteShake  = 0x1f
+	dsbyteCShake = 0x04
+	rate128      = 168
+	rate256      = 136
+)
+
+// Reset resets the hash to initial state.
+func (c *Shake) Reset() {
+	c.state.Reset()
+	c.a = c.initState
+}
+
+// Clone returns copy of a cSHAKE context within its current state.
+func (c *Shake) Clone() XOF {
+	ret := &Shake{initState: c.initState}
+	c.clone(&ret.state)
+	return ret
+}
+
+// NewShake128 creates a new SHAKE128 variable-output-length Shake.
+// Its generic security strength is 128 bits against all attacks if
+// at least 32 bytes of its output are used.
+func NewShake128() *Shake {
+	return &Shake{state: state{rate: rate128, outputLen: 32, dsbyte: dsbyteShake}}
+}
+
+// NewShake256 creates a new SHAKE256 variable-output-length Shake.
+// Its generic security strength is 256 bits against all attacks if
+// at least 64 bytes of its output are used.
+func NewShake256() *Shake {
+	return &Shake{state: state{rate: rate256, outputLen: 64, dsbyte: dsbyteShake}}
+}
+
+// NewCShake128 creates a new cSHAKE128 variable-output-length Shake with
+// the function name N and the customization string S. When both are empty,
+// it is equivalent to SHAKE128.
+func NewCShake128(N, S []byte) *Shake {
+	return newCShake(NewShake128(), N, S)
+}
+
+// NewCShake256 creates a new cSHAKE256 variable-output-length Shake with
+// the function name N and the customization string S. When both are empty,
+// it is equivalent to SHAKE256.
+func NewCShake256(N, S []byte) *Shake {
+	return newCShake(NewShake256(), N, S)
+}
+
+// newCShake absorbs bytepad(encode_string(N) || encode_string(S), rate)
+// into c, as in Section 3.3 of SP 800-185.
+func newCShake(c *Shake, N, S []byte) *Shake {
+	if len(N) == 0 && len(S) == 0 {
+		return c
+	}
+	c.dsbyte = dsbyteCShake
+
+	var b [9]byte
+	n, _ := c.Write(leftEncode(&b, uint64(c.rate)))
+	for _, s := range [][]byte{N, S} {
+		k, _ := c.Write(leftEncode(&b, uint64(len(s))*8))
+		n += k
+		k, _ = c.Write(s)
+		n += k
+	}
+	var zeros [maxRate]byte
+	_, _ = c.Write(zeros[:(c.rate-n%c.rate)%c.rate])
+
+	c.initState = c.a
+	return c
+}
+
+// leftEncode encodes x as in Section 2.3.1 of SP 800-185: the number of
+// bytes of x followed by x in big-endian order, with at least one byte.
+func leftEncode(b *[9]byte, x uint64) []byte {
+	n := 1
+	for v := x >> 8; v > 0; v >>= 8 {
+		n++
+	}
+	b[0] = byte(n)
+	for i := 1; i <= n; i++ {
+		b[i] = byte(x >> uint(8*(n-i)))
+	}
+	return b[:n+1]
+}
+
+// ShakeSum128 writes an arbitrary-length digest of data into hash.
+func ShakeSum128(hash, data []byte) {
+	h := NewShake128()
+	_, _ = h.Write(data)
+	_, _ = h.Read(hash)
+}
+
+// ShakeSum256 writes an arbitrary-length digest of data into hash.
+func ShakeSum256(hash, data []byte) {
+	h := NewShake256()
+	_, _ = h.Write(data)
+	_, _ = h.Read(hash)
+}
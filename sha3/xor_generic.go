@@ -4,7 +4,7 @@
 
 // +build !amd64,!386,!ppc64le
 
-package shake
+package sha3
 
 import "encoding/binary"
 
@@ -0,0 +1,81 @@
+package dilithium
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/cloudflare/circl/sha3"
+)
+
+func hexHash(in []byte) string {
+	var ret [16]byte
+	h := sha3.NewShake256()
+	_, _ = h.Write(in[:])
+	_, _ = h.Read(ret[:])
+	return hex.EncodeToString(ret[:])
+}
+
+func TestNewKeyFromSeed(t *testing.T) {
+	// Test vectors generated from reference implementation
+	for _, tc := range []struct {
+		name string
+		esk  string
+		epk  string
+	}{
+		{"Dilithium2", "afe2e91f5f5899354230744c18410498",
+			"7522162619f3329b5312322d3ee45b87"},
+		{"Dilithium3", "8ad3142e08b718b33f7c2668cd9d053c",
+			"3562fc184dce1a10aad099051705b5d3"},
+		{"Dilithium5", "3956d812a7961af6e5dad16af15c736c",
+			"665388291aa01e12e7f94bdc7769db18"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mode := ModeByName(tc.name)
+			if mode == nil {
+				t.Fatal()
+			}
+			var seed [32]byte
+			pk, sk := mode.NewKeyFromSeed(seed[:])
+
+			pkh := hexHash(pk.Bytes())
+			skh := hexHash(sk.Bytes())
+			if pkh != tc.epk {
+				t.Fatalf("%s expected pk %s, got %s", tc.name, tc.epk, pkh)
+			}
+			if skh != tc.esk {
+				t.Fatalf("%s expected sk %s, got %s", tc.name, tc.esk, skh)
+			}
+		})
+	}
+}
+
+func TestSignRandomized(t *testing.T) {
+	msg := []byte("Some message")
+	for _, name := range ModeNames() {
+		mode := ModeByName(name)
+		t.Run(name, func(t *testing.T) {
+			pk, sk, err := mode.GenerateKey(nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sig, err := mode.SignRandomized(sk, msg, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(sig) != mode.SignatureSize() {
+				t.Fatal()
+			}
+			if !mode.Verify(pk, msg, sig) {
+				t.Fatal("valid signature rejected")
+			}
+			if bytes.Equal(sig, mode.Sign(sk, msg)) {
+				t.Fatal("randomized signature equals the deterministic one")
+			}
+			sig[0] ^= 1
+			if mode.Verify(pk, msg, sig) {
+				t.Fatal("tampered signature accepted")
+			}
+		})
+	}
+}
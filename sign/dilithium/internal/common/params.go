@@ -0,0 +1,18 @@
+package common
+
+import (
+	"github.com/cloudflare/circl/sign/dilithium/internal/common/params"
+)
+
+const (
+	SeedSize     = params.SeedSize
+	N            = params.N
+	Q            = params.Q
+	QBits        = params.QBits
+	Qinv         = params.Qinv
+	ROver256     = params.ROver256
+	D            = params.D
+	PolyT1Size   = params.PolyT1Size
+	PolyT0Size   = params.PolyT0Size
+	PolyLe16Size = params.PolyLe16Size
+)
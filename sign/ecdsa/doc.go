@@ -0,0 +1,23 @@
+// Package ecdsa implements the Elliptic Curve Digital Signature Algorithm
+// over the curves provided by this library: ecc/p256, ecc/p384 and ecc/p521.
+//
+// Signing derives the nonce deterministically from the private key and the
+// message digest as specified in RFC-6979. Optionally, randomness can be mixed
+// into the derivation (hedged signatures), which protects against fault attacks
+// while keeping the security of deterministic nonces if the random source
+// fails. Verification uses the double-point multiplication of the curve
+// (CombinedMult).
+//
+// Signatures can be encoded either in ASN.1/DER form, as used in X.509 and
+// TLS, or in fixed-width raw form (r||s), as used in JWS and IEEE P1363.
+//
+// Signatures carrying a recovery id allow to recover the signer's public key
+// (SEC1, Section 4.1.6), and to verify many of them at once using a random
+// linear combination of the verification equations. For ecc/p384, this is
+// computed with a single multi-scalar multiplication.
+//
+// References:
+//   - FIPS 186-4 https://doi.org/10.6028/NIST.FIPS.186-4
+//   - RFC6979 https://rfc-editor.org/rfc/rfc6979.txt
+//   - SEC1 https://www.secg.org/sec1-v2.pdf
+package ecdsa
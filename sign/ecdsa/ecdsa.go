@@ -0,0 +1,171 @@
+package ecdsa
+
+import (
+	"crypto"
+	"crypto/elliptic"
+	"errors"
+	"io"
+	"math/big"
+)
+
+// Curve is an elliptic curve providing double-point multiplication, which is
+// used for verifying signatures. The curves of ecc/p384 implement it.
+type Curve interface {
+	elliptic.Curve
+	// IsAtInfinity returns True is the point is the identity point.
+	IsAtInfinity(X, Y *big.Int) bool
+	// CombinedMult calculates P=mG+nQ, where G is the generator and
+	// Q=(Qx,Qy). The scalars m and n are positive integers in big-endian form.
+	CombinedMult(Qx, Qy *big.Int, m, n []byte) (Px, Py *big.Int)
+}
+
+// PublicKey represents an ECDSA public key.
+type PublicKey struct {
+	Curve
+	X, Y *big.Int
+}
+
+// PrivateKey represents an ECDSA private key.
+type PrivateKey struct {
+	PublicKey
+	D *big.Int
+}
+
+var (
+	errHash     = errors.New("ecdsa: hash function not available")
+	errKey      = errors.New("ecdsa: invalid private key")
+	errEncoding = errors.New("ecdsa: invalid signature encoding")
+)
+
+// GenerateKey generates a key pair for the curve c using the random source
+// rand. The private key is generated following FIPS 186-4 (B.4.1).
+func GenerateKey(c Curve, rand io.Reader) (*PrivateKey, error) {
+	params := c.Params()
+	b := make([]byte, params.BitSize/8+8)
+	if _, err := io.ReadFull(rand, b); err != nil {
+		return nil, err
+	}
+	one := big.NewInt(1)
+	nMinusOne := new(big.Int).Sub(params.N, one)
+	d := new(big.Int).SetBytes(b)
+	d.Mod(d, nMinusOne).Add(d, one)
+
+	priv := new(PrivateKey)
+	priv.Curve = c
+	priv.D = d
+	priv.X, priv.Y = c.ScalarBaseMult(d.Bytes())
+	return priv, nil
+}
+
+// Public returns the public key corresponding to priv.
+func (priv *PrivateKey) Public() crypto.PublicKey { return &priv.PublicKey }
+
+// Sign signs digest with priv and returns the signature encoded in ASN.1/DER
+// form. The hash function opts.HashFunc() is used to derive the nonce, so it
+// must be the one used to compute digest. If rand is nil, the nonce is
+// deterministic, otherwise randomness from rand is mixed into the nonce.
+// This method implements crypto.Signer.
+func (priv *PrivateKey) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	return SignASN1(rand, priv, opts.HashFunc(), digest)
+}
+
+// Sign signs digest with priv and returns the signature as a pair of
+// integers. The nonce is derived following RFC-6979 using the hash function h,
+// which must be the one used to compute digest. If rand is nil, signing is
+// deterministic; otherwise, randomness read from rand is mixed into the
+// derivation of the nonce (hedged signatures).
+func Sign(rand io.Reader, priv *PrivateKey, h crypto.Hash, digest []byte) (r, s *big.Int, err error) {
+	r, s, _, err = sign(rand, priv, h, digest)
+	return
+}
+
+// sign produces a signature (r,s) and its recovery id v, see SignRecoverable.
+func sign(rand io.Reader, priv *PrivateKey, h crypto.Hash, digest []byte) (r, s *big.Int, v byte, err error) {
+	if !h.Available() {
+		return nil, nil, 0, errHash
+	}
+	params := priv.Curve.Params()
+	N := params.N
+	if priv.D == nil || priv.D.Sign() <= 0 || priv.D.Cmp(N) >= 0 {
+		return nil, nil, 0, errKey
+	}
+
+	var extra []byte
+	if rand != nil {
+		extra = make([]byte, (N.BitLen()+7)/8)
+		if _, err = io.ReadFull(rand, extra); err != nil {
+			return nil, nil, 0, err
+		}
+	}
+
+	e := hashToInt(digest, N)
+	g := newNonceGenerator(h, N, priv.D, digest, extra)
+	kInv := new(big.Int)
+	for {
+		k := g.next()
+		x, y := priv.Curve.ScalarBaseMult(k.Bytes())
+		v = byte(y.Bit(0))
+		if x.Cmp(N) >= 0 {
+			v |= 2
+		}
+		r = x.Mod(x, N)
+		if r.Sign() == 0 {
+			continue
+		}
+		// s = (e + r*d)/k mod N
+		fermatInverse(kInv, k, N)
+		s = new(big.Int).Mul(r, priv.D)
+		s.Add(s, e).Mul(s, kInv).Mod(s, N)
+		if s.Sign() != 0 {
+			return r, s, v, nil
+		}
+	}
+}
+
+// Verify reports whether (r,s) is a valid signature of digest under the
+// public key pub.
+func Verify(pub *PublicKey, digest []byte, r, s *big.Int) bool {
+	if pub.Curve == nil || pub.X == nil || pub.Y == nil || r == nil || s == nil {
+		return false
+	}
+	N := pub.Curve.Params().N
+	if r.Sign() <= 0 || s.Sign() <= 0 || r.Cmp(N) >= 0 || s.Cmp(N) >= 0 {
+		return false
+	}
+	if pub.IsAtInfinity(pub.X, pub.Y) || !pub.IsOnCurve(pub.X, pub.Y) {
+		return false
+	}
+
+	e := hashToInt(digest, N)
+	w := new(big.Int).ModInverse(s, N)
+	u1 := e.Mul(e, w).Mod(e, N)
+	u2 := w.Mul(r, w).Mod(w, N)
+	x, y := pub.CombinedMult(pub.X, pub.Y, u1.Bytes(), u2.Bytes())
+	if pub.IsAtInfinity(x, y) {
+		return false
+	}
+	return x.Mod(x, N).Cmp(r) == 0
+}
+
+// hashToInt converts a digest into an integer taking the leftmost bits, as
+// many as the bit length of N (bits2int in RFC-6979).
+func hashToInt(digest []byte, N *big.Int) *big.Int {
+	orderBits := N.BitLen()
+	orderBytes := (orderBits + 7) / 8
+	if len(digest) > orderBytes {
+		digest = digest[:orderBytes]
+	}
+	e := new(big.Int).SetBytes(digest)
+	if excess := len(digest)*8 - orderBits; excess > 0 {
+		e.Rsh(e, uint(excess))
+	}
+	return e
+}
+
+// fermatInverse calculates z = k^-1 mod N using Fermat's little theorem,
+// which avoids the data-dependent branches of the extended Euclidean
+// algorithm.
+func fermatInverse(z, k, N *big.Int) {
+	nMinusTwo := new(big.Int).Sub(N, big.NewInt(2))
+	z.Exp(k, nMinusTwo, N)
+}
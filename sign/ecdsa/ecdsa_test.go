@@ -0,0 +1,203 @@
+package ecdsa_test
+
+import (
+	"crypto"
+	goecdsa "crypto/ecdsa"
+	"crypto/rand"
+	_ "crypto/sha1"
+	_ "crypto/sha256"
+	"crypto/sha512"
+	"math/big"
+	"testing"
+
+	"github.com/cloudflare/circl/ecc/p256"
+	"github.com/cloudflare/circl/ecc/p384"
+	"github.com/cloudflare/circl/ecc/p521"
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/sign/ecdsa"
+)
+
+func hexInt(s string) *big.Int {
+	z, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		panic("bad hex")
+	}
+	return z
+}
+
+func TestRFC6979(t *testing.T) {
+	// Test vectors from RFC-6979 (Appendix A.2.6).
+	priv := &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{
+			Curve: p384.P384(),
+			X:     hexInt("EC3A4E415B4E19A4568618029F427FA5DA9A8BC4AE92E02E06AAE5286B300C64DEF8F0EA9055866064A254515480BC13"),
+			Y:     hexInt("8015D9B72D7D57244EA8EF9AC0C621896708A59367F9DFB9F54CA84B3F1C9DB1288B231C3AE0D4FE7344FD2533264720"),
+		},
+		D: hexInt("6B9D3DAD2E1B8C1C05B19875B6659F4DE23C3B667BF297BA9AA47740787137D896D5724E4C70A825F872C9EA60D2EDF5"),
+	}
+	vectors := []struct {
+		h    crypto.Hash
+		msg  string
+		r, s string
+	}{
+		{crypto.SHA1, "sample",
+			"EC748D839243D6FBEF4FC5C4859A7DFFD7F3ABDDF72014540C16D73309834FA37B9BA002899F6FDA3A4A9386790D4EB2",
+			"A3BCFA947BEEF4732BF247AC17F71676CB31A847B9FF0CBC9C9ED4C1A5B3FACF26F49CA031D4857570CCB5CA4424A443"},
+		{crypto.SHA224, "sample",
+			"42356E76B55A6D9B4631C865445DBE54E056D3B3431766D0509244793C3F9366450F76EE3DE43F5A125333A6BE060122",
+			"9DA0C81787064021E78DF658F2FBB0B042BF304665DB721F077A4298B095E4834C082C03D83028EFBF93A3C23940CA8D"},
+		{crypto.SHA256, "sample",
+			"21B13D1E013C7FA1392D03C5F99AF8B30C570C6F98D4EA8E354B63A21D3DAA33BDE1E888E63355D92FA2B3C36D8FB2CD",
+			"F3AA443FB107745BF4BD77CB3891674632068A10CA67E3D45DB2266FA7D1FEEBEFDC63ECCD1AC42EC0CB8668A4FA0AB0"},
+		{crypto.SHA384, "sample",
+			"94EDBB92A5ECB8AAD4736E56C691916B3F88140666CE9FA73D64C4EA95AD133C81A648152E44ACF96E36DD1E80FABE46",
+			"99EF4AEB15F178CEA1FE40DB2603138F130E740A19624526203B6351D0A3A94FA329C145786E679E7B82C71A38628AC8"},
+		{crypto.SHA512, "sample",
+			"ED0959D5880AB2D869AE7F6C2915C6D60F96507F9CB3E047C0046861DA4A799CFE30F35CC900056D7C99CD7882433709",
+			"512C8CCEEE3890A84058CE1E22DBC2198F42323CE8ACA9135329F03C068E5112DC7CC3EF3446DEFCEB01A45C2667FDD5"},
+		{crypto.SHA1, "test",
+			"4BC35D3A50EF4E30576F58CD96CE6BF638025EE624004A1F7789A8B8E43D0678ACD9D29876DAF46638645F7F404B11C7",
+			"D5A6326C494ED3FF614703878961C0FDE7B2C278F9A65FD8C4B7186201A2991695BA1C84541327E966FA7B50F7382282"},
+		{crypto.SHA224, "test",
+			"E8C9D0B6EA72A0E7837FEA1D14A1A9557F29FAA45D3E7EE888FC5BF954B5E62464A9A817C47FF78B8C11066B24080E72",
+			"07041D4A7A0379AC7232FF72E6F77B6DDB8F09B16CCE0EC3286B2BD43FA8C6141C53EA5ABEF0D8231077A04540A96B66"},
+		{crypto.SHA256, "test",
+			"6D6DEFAC9AB64DABAFE36C6BF510352A4CC27001263638E5B16D9BB51D451559F918EEDAF2293BE5B475CC8F0188636B",
+			"2D46F3BECBCC523D5F1A1256BF0C9B024D879BA9E838144C8BA6BAEB4B53B47D51AB373F9845C0514EEFB14024787265"},
+		{crypto.SHA384, "test",
+			"8203B63D3C853E8D77227FB377BCF7B7B772E97892A80F36AB775D509D7A5FEB0542A7F0812998DA8F1DD3CA3CF023DB",
+			"DDD0760448D42D8A43AF45AF836FCE4DE8BE06B485E9B61B827C2F13173923E06A739F040649A667BF3B828246BAA5A5"},
+		{crypto.SHA512, "test",
+			"A0D5D090C9980FAF3C2CE57B7AE951D31977DD11C775D314AF55F76C676447D06FB6495CD21B4B6E340FC236584FB277",
+			"976984E59B4C77B0E8E4460DCA3D9F20E07B9BB1F63BEEFAF576F6B2E8B224634A2092CD3792E0159AD9CEE37659C736"},
+	}
+	for i, v := range vectors {
+		h := v.h.New()
+		h.Write([]byte(v.msg))
+		digest := h.Sum(nil)
+		r, s, err := ecdsa.Sign(nil, priv, v.h, digest)
+		test.CheckNoErr(t, err, "sign failed")
+		wantR, wantS := hexInt(v.r), hexInt(v.s)
+		if r.Cmp(wantR) != 0 || s.Cmp(wantS) != 0 {
+			test.ReportError(t, r, wantR, i, v.msg)
+		}
+		if !ecdsa.Verify(&priv.PublicKey, digest, r, s) {
+			test.ReportError(t, false, true, i, v.msg)
+		}
+	}
+}
+
+func TestSignVerify(t *testing.T) {
+	const testTimes = 1 << 6
+	curve := p384.P384()
+	msg := []byte("message to be signed")
+	digest := sha512.Sum384(msg)
+
+	for i := 0; i < testTimes; i++ {
+		priv, err := ecdsa.GenerateKey(curve, rand.Reader)
+		test.CheckNoErr(t, err, "key generation failed")
+		pub := &priv.PublicKey
+
+		// Hedged signatures are randomized.
+		sig1, err := ecdsa.SignASN1(rand.Reader, priv, crypto.SHA384, digest[:])
+		test.CheckNoErr(t, err, "sign failed")
+		sig2, err := ecdsa.SignASN1(rand.Reader, priv, crypto.SHA384, digest[:])
+		test.CheckNoErr(t, err, "sign failed")
+		if string(sig1) == string(sig2) {
+			test.ReportError(t, sig1, "different signature", i)
+		}
+		for _, sig := range [][]byte{sig1, sig2} {
+			got := ecdsa.VerifyASN1(pub, digest[:], sig)
+			want := true
+			if got != want {
+				test.ReportError(t, got, want, i)
+			}
+		}
+
+		// Deterministic signatures are reproducible.
+		sig1, err = ecdsa.SignRaw(nil, priv, crypto.SHA384, digest[:])
+		test.CheckNoErr(t, err, "sign failed")
+		sig2, err = ecdsa.SignRaw(nil, priv, crypto.SHA384, digest[:])
+		test.CheckNoErr(t, err, "sign failed")
+		if string(sig1) != string(sig2) {
+			test.ReportError(t, sig1, sig2, i)
+		}
+		if !ecdsa.VerifyRaw(pub, digest[:], sig1) {
+			test.ReportError(t, false, true, i)
+		}
+
+		// Tampered digest must fail.
+		bad := digest
+		bad[0] ^= 1
+		if ecdsa.VerifyRaw(pub, bad[:], sig1) {
+			test.ReportError(t, true, false, i)
+		}
+	}
+}
+
+func TestSigner(t *testing.T) {
+	curve := p384.P384()
+	priv, err := ecdsa.GenerateKey(curve, rand.Reader)
+	test.CheckNoErr(t, err, "key generation failed")
+
+	var signer crypto.Signer = priv
+	digest := sha512.Sum384([]byte("message to be signed"))
+	sig, err := signer.Sign(rand.Reader, digest[:], crypto.SHA384)
+	test.CheckNoErr(t, err, "sign failed")
+
+	// Signatures must be verified by the standard library.
+	pub := &goecdsa.PublicKey{Curve: curve.Params(), X: priv.X, Y: priv.Y}
+	if !goecdsa.VerifyASN1(pub, digest[:], sig) {
+		test.ReportError(t, false, true)
+	}
+	r, s, err := ecdsa.UnmarshalASN1(sig)
+	test.CheckNoErr(t, err, "unmarshal failed")
+	if !goecdsa.Verify(pub, digest[:], r, s) {
+		test.ReportError(t, false, true)
+	}
+
+	_, err = signer.Sign(nil, digest[:], crypto.Hash(0))
+	test.CheckIsErr(t, err, "should fail with unavailable hash")
+}
+
+func TestCurves(t *testing.T) {
+	msg := []byte("message to be signed")
+	for _, c := range []ecdsa.Curve{p256.P256(), p384.P384(), p521.P521()} {
+		priv, err := ecdsa.GenerateKey(c, rand.Reader)
+		test.CheckNoErr(t, err, "key generation failed")
+		digest := sha512.Sum512(msg)
+		sig, err := ecdsa.SignASN1(rand.Reader, priv, crypto.SHA512, digest[:])
+		test.CheckNoErr(t, err, "sign failed")
+
+		pub := &goecdsa.PublicKey{Curve: c.Params(), X: priv.X, Y: priv.Y}
+		got := goecdsa.VerifyASN1(pub, digest[:], sig) &&
+			ecdsa.VerifyASN1(&priv.PublicKey, digest[:], sig)
+		want := true
+		if got != want {
+			test.ReportError(t, got, want, c.Params().Name)
+		}
+	}
+}
+
+func BenchmarkECDSA(b *testing.B) {
+	curve := p384.P384()
+	priv, _ := ecdsa.GenerateKey(curve, rand.Reader)
+	digest := sha512.Sum384([]byte("message to be signed"))
+	r, s, _ := ecdsa.Sign(rand.Reader, priv, crypto.SHA384, digest[:])
+
+	b.Run("GenerateKey", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = ecdsa.GenerateKey(curve, rand.Reader)
+		}
+	})
+	b.Run("Sign", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _, _ = ecdsa.Sign(rand.Reader, priv, crypto.SHA384, digest[:])
+		}
+	})
+	b.Run("Verify", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			ecdsa.Verify(&priv.PublicKey, digest[:], r, s)
+		}
+	})
+}
@@ -0,0 +1,73 @@
+package ecdsa
+
+import (
+	"crypto"
+	"encoding/asn1"
+	"io"
+	"math/big"
+)
+
+type asn1Signature struct{ R, S *big.Int }
+
+// SignASN1 signs digest as Sign does, and returns the signature encoded as
+// an ASN.1/DER sequence of two integers.
+func SignASN1(rand io.Reader, priv *PrivateKey, h crypto.Hash, digest []byte) ([]byte, error) {
+	r, s, err := Sign(rand, priv, h, digest)
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(asn1Signature{r, s})
+}
+
+// VerifyASN1 reports whether sig is a valid signature of digest under the
+// public key pub. The signature must be encoded in ASN.1/DER form, any other
+// encoding (e.g. BER) is rejected.
+func VerifyASN1(pub *PublicKey, digest, sig []byte) bool {
+	r, s, err := UnmarshalASN1(sig)
+	if err != nil {
+		return false
+	}
+	return Verify(pub, digest, r, s)
+}
+
+// UnmarshalASN1 parses a signature encoded in ASN.1/DER form.
+func UnmarshalASN1(sig []byte) (r, s *big.Int, err error) {
+	var v asn1Signature
+	rest, err := asn1.Unmarshal(sig, &v)
+	if err != nil || len(rest) != 0 {
+		return nil, nil, errEncoding
+	}
+	// Re-encoding ensures the input is the unique DER encoding.
+	der, err := asn1.Marshal(v)
+	if err != nil || string(der) != string(sig) {
+		return nil, nil, errEncoding
+	}
+	return v.R, v.S, nil
+}
+
+// SignRaw signs digest as Sign does, and returns the signature encoded in
+// fixed-width form r||s, where each integer is encoded in big-endian order
+// using as many bytes as the order of the curve.
+func SignRaw(rand io.Reader, priv *PrivateKey, h crypto.Hash, digest []byte) ([]byte, error) {
+	r, s, err := Sign(rand, priv, h, digest)
+	if err != nil {
+		return nil, err
+	}
+	size := scalarSize(priv.Curve)
+	return append(int2octets(r, size), int2octets(s, size)...), nil
+}
+
+// VerifyRaw reports whether sig is a valid signature of digest under the
+// public key pub. The signature must be encoded in fixed-width form r||s.
+func VerifyRaw(pub *PublicKey, digest, sig []byte) bool {
+	size := scalarSize(pub.Curve)
+	if len(sig) != 2*size {
+		return false
+	}
+	r := new(big.Int).SetBytes(sig[:size])
+	s := new(big.Int).SetBytes(sig[size:])
+	return Verify(pub, digest, r, s)
+}
+
+// scalarSize is the length in bytes of the order of the curve.
+func scalarSize(c Curve) int { return (c.Params().N.BitLen() + 7) / 8 }
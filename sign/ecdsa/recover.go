@@ -0,0 +1,203 @@
+package ecdsa
+
+import (
+	"crypto"
+	"errors"
+	"io"
+	"math/big"
+)
+
+var errRecover = errors.New("ecdsa: public key cannot be recovered")
+
+// RecoverableSignature is an ECDSA signature (R,S) together with its recovery
+// id V. The least significant bit of V is the parity of the y-coordinate of
+// the point kG, and the second bit is set when its x-coordinate is larger than
+// the order of the group, i.e., when R was reduced modulo N.
+type RecoverableSignature struct {
+	R, S *big.Int
+	V    byte
+}
+
+// multiScalarMultiplier is implemented by curves supporting multi-scalar
+// multiplication, such as the one of ecc/p384.
+type multiScalarMultiplier interface {
+	MultiScalarMult(X, Y []*big.Int, k [][]byte) (Px, Py *big.Int, err error)
+}
+
+// SignRecoverable signs digest with priv as Sign does, and also returns the
+// recovery id of the signature, which allows to recover the public key from
+// the signature using RecoverPublicKey.
+func SignRecoverable(rand io.Reader, priv *PrivateKey, h crypto.Hash, digest []byte) (*RecoverableSignature, error) {
+	r, s, v, err := sign(rand, priv, h, digest)
+	if err != nil {
+		return nil, err
+	}
+	return &RecoverableSignature{R: r, S: s, V: v}, nil
+}
+
+// RecoverPublicKey returns the public key under which sig is a valid signature
+// of digest. It returns an error if no such key exists. Runs in non-constant
+// time, so only use it with public inputs.
+func RecoverPublicKey(c Curve, digest []byte, sig *RecoverableSignature) (*PublicKey, error) {
+	N := c.Params().N
+	if !checkRange(sig, N) {
+		return nil, errRecover
+	}
+	Rx, Ry, ok := liftX(c, sig)
+	if !ok {
+		return nil, errRecover
+	}
+
+	// Q = r^-1 (sR - eG) = (-e/r)G + (s/r)R.
+	e := hashToInt(digest, N)
+	rInv := new(big.Int).ModInverse(sig.R, N)
+	u1 := e.Neg(e).Mul(e, rInv).Mod(e, N)
+	u2 := rInv.Mul(sig.S, rInv).Mod(rInv, N)
+	x, y := c.CombinedMult(Rx, Ry, u1.Bytes(), u2.Bytes())
+	if c.IsAtInfinity(x, y) {
+		return nil, errRecover
+	}
+	return &PublicKey{Curve: c, X: x, Y: y}, nil
+}
+
+// BatchVerify reports whether every sigs[i] is a valid signature of
+// digests[i] under the public key pubs[i]. All keys must belong to the same
+// curve. The recovery id of each signature is used to reconstruct the point
+// kG, so signatures with a wrong recovery id are rejected even if they pass
+// Verify. When the curve supports multi-scalar multiplication, the signatures
+// are checked at once with a random linear combination of the verification
+// equations, using 128-bit coefficients read from rand; a batch containing an
+// invalid signature is accepted with probability at most 2^-128.
+func BatchVerify(rand io.Reader, pubs []*PublicKey, digests [][]byte, sigs []RecoverableSignature) bool {
+	n := len(pubs)
+	if len(digests) != n || len(sigs) != n {
+		return false
+	}
+	if n == 0 {
+		return true
+	}
+	c := pubs[0].Curve
+	if c == nil {
+		return false
+	}
+	params := c.Params()
+	for i := range pubs {
+		pub := pubs[i]
+		if pub.Curve == nil || pub.Params() != params || pub.X == nil || pub.Y == nil {
+			return false
+		}
+		if pub.IsAtInfinity(pub.X, pub.Y) || !pub.IsOnCurve(pub.X, pub.Y) {
+			return false
+		}
+		if !checkRange(&sigs[i], params.N) {
+			return false
+		}
+	}
+
+	msm, ok := c.(multiScalarMultiplier)
+	if !ok {
+		for i := range pubs {
+			if !verifyRecoverable(pubs[i], digests[i], &sigs[i]) {
+				return false
+			}
+		}
+		return true
+	}
+
+	// Checks that sum z[i](u1[i]G + u2[i]Q[i] - R[i]) is the identity, where
+	// the z[i] are random coefficients.
+	N := params.N
+	X := make([]*big.Int, 2*n+1)
+	Y := make([]*big.Int, 2*n+1)
+	k := make([][]byte, 2*n+1)
+	a := new(big.Int)
+	z := new(big.Int)
+	w := new(big.Int)
+	t := new(big.Int)
+	buf := make([]byte, 16)
+	for i := range pubs {
+		Rx, Ry, ok := liftX(c, &sigs[i])
+		if !ok {
+			return false
+		}
+		if _, err := io.ReadFull(rand, buf); err != nil {
+			return false
+		}
+		z.SetBytes(buf)
+		if z.Sign() == 0 {
+			z.SetInt64(1)
+		}
+		w.ModInverse(sigs[i].S, N)
+		e := hashToInt(digests[i], N)
+		// a += z*u1 = z*e/s
+		e.Mul(e, w).Mul(e, z)
+		a.Add(a, e)
+		// z*u2 = z*r/s
+		t.Mul(sigs[i].R, w).Mul(t, z).Mod(t, N)
+		X[i+1], Y[i+1], k[i+1] = pubs[i].X, pubs[i].Y, t.Bytes()
+		// -z
+		t.Sub(N, z)
+		X[n+i+1], Y[n+i+1], k[n+i+1] = Rx, Ry, t.Bytes()
+	}
+	a.Mod(a, N)
+	X[0], Y[0], k[0] = params.Gx, params.Gy, a.Bytes()
+
+	x, y, err := msm.MultiScalarMult(X, Y, k)
+	return err == nil && c.IsAtInfinity(x, y)
+}
+
+// verifyRecoverable checks the signature sig as Verify does, and additionally
+// that its recovery id matches the point u1G+u2Q.
+func verifyRecoverable(pub *PublicKey, digest []byte, sig *RecoverableSignature) bool {
+	N := pub.Params().N
+	Rx, Ry, ok := liftX(pub.Curve, sig)
+	if !ok {
+		return false
+	}
+	e := hashToInt(digest, N)
+	w := new(big.Int).ModInverse(sig.S, N)
+	u1 := e.Mul(e, w).Mod(e, N)
+	u2 := w.Mul(sig.R, w).Mod(w, N)
+	x, y := pub.CombinedMult(pub.X, pub.Y, u1.Bytes(), u2.Bytes())
+	return x.Cmp(Rx) == 0 && y.Cmp(Ry) == 0
+}
+
+// checkRange reports whether the components of sig are in the valid range.
+func checkRange(sig *RecoverableSignature, N *big.Int) bool {
+	return sig.R != nil && sig.S != nil && sig.V < 4 &&
+		sig.R.Sign() > 0 && sig.R.Cmp(N) < 0 &&
+		sig.S.Sign() > 0 && sig.S.Cmp(N) < 0
+}
+
+// liftX returns the point R whose x-coordinate is sig.R (plus N if the
+// second bit of sig.V is set) and whose y-coordinate has the parity given by
+// the first bit of sig.V. It assumes a short Weierstrass curve with a=-3.
+func liftX(c Curve, sig *RecoverableSignature) (x, y *big.Int, ok bool) {
+	params := c.Params()
+	P := params.P
+	x = new(big.Int).Set(sig.R)
+	if sig.V&2 != 0 {
+		x.Add(x, params.N)
+	}
+	if x.Cmp(P) >= 0 {
+		return nil, nil, false
+	}
+
+	// y^2 = x^3 - 3x + B
+	y2 := new(big.Int).Mul(x, x)
+	y2.Mul(y2, x)
+	threeX := new(big.Int).Lsh(x, 1)
+	threeX.Add(threeX, x)
+	y2.Sub(y2, threeX).Add(y2, params.B).Mod(y2, P)
+	y = new(big.Int).ModSqrt(y2, P)
+	if y == nil {
+		return nil, nil, false
+	}
+	if y.Bit(0) != uint(sig.V&1) {
+		y.Sub(P, y)
+	}
+	if y.Sign() == 0 && sig.V&1 != 0 {
+		return nil, nil, false
+	}
+	return x, y, true
+}
@@ -0,0 +1,150 @@
+package ecdsa_test
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/cloudflare/circl/ecc/p256"
+	"github.com/cloudflare/circl/ecc/p384"
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/sign/ecdsa"
+)
+
+func TestRecoverPublicKey(t *testing.T) {
+	const testTimes = 1 << 5
+	for _, c := range []ecdsa.Curve{p256.P256(), p384.P384()} {
+		for i := 0; i < testTimes; i++ {
+			priv, err := ecdsa.GenerateKey(c, rand.Reader)
+			test.CheckNoErr(t, err, "key generation failed")
+			digest := sha512.Sum384([]byte(fmt.Sprintf("message %v", i)))
+			sig, err := ecdsa.SignRecoverable(nil, priv, crypto.SHA384, digest[:])
+			test.CheckNoErr(t, err, "sign failed")
+
+			if !ecdsa.Verify(&priv.PublicKey, digest[:], sig.R, sig.S) {
+				test.ReportError(t, false, true, i)
+			}
+			pub, err := ecdsa.RecoverPublicKey(c, digest[:], sig)
+			test.CheckNoErr(t, err, "recovery failed")
+			if pub.X.Cmp(priv.X) != 0 || pub.Y.Cmp(priv.Y) != 0 {
+				test.ReportError(t, pub.X, priv.X, i)
+			}
+
+			// A wrong recovery id yields either another key or an error.
+			bad := *sig
+			bad.V ^= 1
+			pub, err = ecdsa.RecoverPublicKey(c, digest[:], &bad)
+			if err == nil && pub.X.Cmp(priv.X) == 0 && pub.Y.Cmp(priv.Y) == 0 {
+				test.ReportError(t, pub.X, "other key", i)
+			}
+		}
+	}
+
+	c := p384.P384()
+	N := c.Params().N
+	digest := sha512.Sum384([]byte("message"))
+	one := big.NewInt(1)
+	nMinusOne := new(big.Int).Sub(N, one)
+	for _, sig := range []ecdsa.RecoverableSignature{
+		{R: nil, S: one, V: 0},
+		{R: one, S: nil, V: 0},
+		{R: big.NewInt(0), S: one, V: 0},
+		{R: one, S: N, V: 0},
+		{R: one, S: one, V: 4},
+		{R: nMinusOne, S: one, V: 2}, // R+N is larger than P.
+	} {
+		_, err := ecdsa.RecoverPublicKey(c, digest[:], &sig)
+		test.CheckIsErr(t, err, "recovery should fail")
+	}
+}
+
+func TestBatchVerify(t *testing.T) {
+	const n = 16
+	for _, c := range []ecdsa.Curve{p256.P256(), p384.P384()} {
+		name := c.Params().Name
+		pubs := make([]*ecdsa.PublicKey, n)
+		digests := make([][]byte, n)
+		sigs := make([]ecdsa.RecoverableSignature, n)
+		var priv *ecdsa.PrivateKey
+		for i := range pubs {
+			// Some keys are repeated within the batch.
+			if i%3 != 1 {
+				var err error
+				priv, err = ecdsa.GenerateKey(c, rand.Reader)
+				test.CheckNoErr(t, err, "key generation failed")
+			}
+			digest := sha256.Sum256([]byte(fmt.Sprintf("message %v", i)))
+			sig, err := ecdsa.SignRecoverable(rand.Reader, priv, crypto.SHA256, digest[:])
+			test.CheckNoErr(t, err, "sign failed")
+			pubs[i], digests[i], sigs[i] = &priv.PublicKey, digest[:], *sig
+		}
+
+		got := ecdsa.BatchVerify(rand.Reader, pubs, digests, sigs)
+		want := true
+		if got != want {
+			test.ReportError(t, got, want, name)
+		}
+
+		got = ecdsa.BatchVerify(rand.Reader, nil, nil, nil)
+		if got != want {
+			test.ReportError(t, got, want, name)
+		}
+
+		for _, tamper := range []func(i int, s *ecdsa.RecoverableSignature){
+			func(i int, s *ecdsa.RecoverableSignature) { s.S = new(big.Int).Add(s.S, big.NewInt(1)) },
+			func(i int, s *ecdsa.RecoverableSignature) { s.R, s.S = sigs[(i+1)%n].R, sigs[(i+1)%n].S },
+			func(i int, s *ecdsa.RecoverableSignature) { s.V ^= 1 },
+		} {
+			i := n / 2
+			bad := append([]ecdsa.RecoverableSignature{}, sigs...)
+			tamper(i, &bad[i])
+			got = ecdsa.BatchVerify(rand.Reader, pubs, digests, bad)
+			want = false
+			if got != want {
+				test.ReportError(t, got, want, name, i)
+			}
+		}
+
+		got = ecdsa.BatchVerify(rand.Reader, pubs, digests[1:], sigs)
+		want = false
+		if got != want {
+			test.ReportError(t, got, want, name)
+		}
+	}
+}
+
+func BenchmarkBatchVerify(b *testing.B) {
+	const n = 64
+	curve := p384.P384()
+	pubs := make([]*ecdsa.PublicKey, n)
+	digests := make([][]byte, n)
+	sigs := make([]ecdsa.RecoverableSignature, n)
+	for i := range pubs {
+		priv, _ := ecdsa.GenerateKey(curve, rand.Reader)
+		digest := sha512.Sum384([]byte(fmt.Sprintf("message %v", i)))
+		sig, _ := ecdsa.SignRecoverable(rand.Reader, priv, crypto.SHA384, digest[:])
+		pubs[i], digests[i], sigs[i] = &priv.PublicKey, digest[:], *sig
+	}
+
+	b.Run("RecoverPublicKey", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = ecdsa.RecoverPublicKey(curve, digests[0], &sigs[0])
+		}
+	})
+	b.Run("Verify", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for j := range pubs {
+				ecdsa.Verify(pubs[j], digests[j], sigs[j].R, sigs[j].S)
+			}
+		}
+	})
+	b.Run("BatchVerify", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			ecdsa.BatchVerify(rand.Reader, pubs, digests, sigs)
+		}
+	})
+}
@@ -0,0 +1,100 @@
+package ecdsa
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"hash"
+	"math/big"
+)
+
+// nonceGenerator is the HMAC-DRBG based generator of nonces described in
+// RFC-6979 (Section 3.2). The additional data of Section 3.6 is used to
+// generate hedged nonces.
+type nonceGenerator struct {
+	mac  func() hash.Hash
+	N    *big.Int
+	k, v []byte
+	// first indicates that no candidate has been generated yet.
+	first bool
+}
+
+func newNonceGenerator(h crypto.Hash, N, d *big.Int, digest, extra []byte) *nonceGenerator {
+	rlen := (N.BitLen() + 7) / 8
+	hlen := h.Size()
+	g := &nonceGenerator{N: N, first: true}
+	g.k = make([]byte, hlen)
+	g.v = make([]byte, hlen)
+	for i := range g.v {
+		g.v[i] = 0x01
+	}
+
+	// Input of the HMAC is int2octets(x) || bits2octets(h1) || extra.
+	x := int2octets(d, rlen)
+	h1 := hashToInt(digest, N)
+	if h1.Cmp(N) >= 0 {
+		h1.Sub(h1, N)
+	}
+	hOct := int2octets(h1, rlen)
+
+	g.mac = func() hash.Hash { return hmac.New(h.New, g.k) }
+	for _, sep := range []byte{0x00, 0x01} {
+		m := g.mac()
+		m.Write(g.v)
+		m.Write([]byte{sep})
+		m.Write(x)
+		m.Write(hOct)
+		m.Write(extra)
+		g.k = m.Sum(g.k[:0])
+		m = g.mac()
+		m.Write(g.v)
+		g.v = m.Sum(g.v[:0])
+	}
+	return g
+}
+
+// next returns the next nonce candidate k in [1, N-1].
+func (g *nonceGenerator) next() *big.Int {
+	qlen := g.N.BitLen()
+	k := new(big.Int)
+	for {
+		if !g.first {
+			m := g.mac()
+			m.Write(g.v)
+			m.Write([]byte{0x00})
+			g.k = m.Sum(g.k[:0])
+			m = g.mac()
+			m.Write(g.v)
+			g.v = m.Sum(g.v[:0])
+		}
+		g.first = false
+
+		var t []byte
+		for len(t)*8 < qlen {
+			m := g.mac()
+			m.Write(g.v)
+			g.v = m.Sum(g.v[:0])
+			t = append(t, g.v...)
+		}
+		k = hashToIntBits(k, t, qlen)
+		if k.Sign() > 0 && k.Cmp(g.N) < 0 {
+			return k
+		}
+	}
+}
+
+// hashToIntBits sets z to the integer formed by the leftmost qlen bits of b.
+func hashToIntBits(z *big.Int, b []byte, qlen int) *big.Int {
+	z.SetBytes(b)
+	if excess := len(b)*8 - qlen; excess > 0 {
+		z.Rsh(z, uint(excess))
+	}
+	return z
+}
+
+// int2octets encodes x as a big-endian string of rlen bytes.
+func int2octets(x *big.Int, rlen int) []byte {
+	out := make([]byte, rlen)
+	b := x.Bytes()
+	copy(out[rlen-len(b):], b)
+	return out
+}
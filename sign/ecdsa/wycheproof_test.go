@@ -0,0 +1,88 @@
+package ecdsa_test
+
+import (
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/cloudflare/circl/ecc/p384"
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/sign/ecdsa"
+)
+
+type group struct {
+	Key struct {
+		Curve string `json:"curve"`
+		Wx    string `json:"wx"`
+		Wy    string `json:"wy"`
+	} `json:"publicKey"`
+	Sha   string `json:"sha"`
+	Tests []struct {
+		TcID    int      `json:"tcId"`
+		Comment string   `json:"comment"`
+		Msg     string   `json:"msg"`
+		Sig     string   `json:"sig"`
+		Result  string   `json:"result"`
+		Flags   []string `json:"flags"`
+	} `json:"tests"`
+}
+
+type Wycheproof struct {
+	Alg    string  `json:"algorithm"`
+	Num    int     `json:"numberOfTests"`
+	Groups []group `json:"testGroups"`
+}
+
+func readWycheproof(t *testing.T, nameFile string) *Wycheproof {
+	jsonFile, err := os.Open(nameFile)
+	if err != nil {
+		t.Fatalf("File %v can not be opened. Error: %v", nameFile, err)
+	}
+	defer jsonFile.Close()
+	input, _ := ioutil.ReadAll(jsonFile)
+
+	var kat Wycheproof
+	err = json.Unmarshal(input, &kat)
+	if err != nil {
+		t.Fatalf("File %v can not be loaded. Error: %v", nameFile, err)
+	}
+	return &kat
+}
+
+func TestWycheproof(t *testing.T) {
+	// Test vectors from Wycheproof (testvectors_v1).
+	for _, v := range []struct {
+		name, file string
+		verify     func(*ecdsa.PublicKey, []byte, []byte) bool
+	}{
+		{"ASN1", "testdata/ecdsa_secp384r1_sha384_test.json", ecdsa.VerifyASN1},
+		{"Raw", "testdata/ecdsa_secp384r1_sha384_p1363_test.json", ecdsa.VerifyRaw},
+	} {
+		v := v
+		t.Run(v.name, func(t *testing.T) {
+			kat := readWycheproof(t, v.file)
+			for _, g := range kat.Groups {
+				if g.Key.Curve != "secp384r1" || g.Sha != "SHA-384" {
+					t.Fatalf("Group not expected %v %v", g.Key.Curve, g.Sha)
+				}
+				pub := &ecdsa.PublicKey{Curve: p384.P384()}
+				pub.X, _ = new(big.Int).SetString(g.Key.Wx, 16)
+				pub.Y, _ = new(big.Int).SetString(g.Key.Wy, 16)
+				for _, tc := range g.Tests {
+					msg, _ := hex.DecodeString(tc.Msg)
+					sig, _ := hex.DecodeString(tc.Sig)
+					digest := sha512.Sum384(msg)
+					got := v.verify(pub, digest[:], sig)
+					want := tc.Result == "valid"
+					if got != want {
+						test.ReportError(t, got, want, tc.TcID, tc.Comment)
+					}
+				}
+			}
+		})
+	}
+}
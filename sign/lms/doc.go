@@ -0,0 +1,24 @@
+// Package lms implements the stateful hash-based signature schemes LMS and
+// HSS as specified in RFC 8554, with the parameter sets approved by NIST
+// SP 800-208.
+//
+// An LMS tree authenticates 2^h one-time keys of LM-OTS with a Merkle tree,
+// and HSS chains up to eight levels of trees, each of which signs the public
+// key of a tree on the level below. Each signature consumes the next unused
+// one-time key of the last level, and signing twice with the same one-time
+// key allows forgeries. Hence, the state of the private key is saved with a
+// StateStore provided by the caller before any signature is computed, and
+// signing fails if the state cannot be saved.
+//
+// This package provides the parameter sets instantiated with SHA-256 and
+// with SHAKE256, which is computed with the Keccak permutation of this
+// library, with outputs of 32 or 24 bytes. All the levels of a key must use
+// the same hash function and output size. The secret values of the one-time
+// keys are derived from a seed as in Appendix A of RFC 8554, and so are the
+// identifiers and seeds of the lower trees and the randomizers of the
+// message digests.
+//
+// References:
+//   - RFC 8554 https://www.rfc-editor.org/rfc/rfc8554
+//   - NIST SP 800-208 https://doi.org/10.6028/NIST.SP.800-208
+package lms
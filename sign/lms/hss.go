@@ -0,0 +1,360 @@
+package lms
+
+import (
+	"bytes"
+	cryptoRand "crypto/rand"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"math/big"
+	"sync"
+)
+
+// StateStore persists the state of a private key, so that none of its
+// one-time keys is ever used twice. It is supplied by the caller and
+// typically writes to a file, a database or a hardware token.
+type StateStore interface {
+	// Store saves the packed private key sk, replacing any previous state
+	// of the key. It must not return nil before the state is durably
+	// written, since a signature may be released right after it returns.
+	Store(sk []byte) error
+}
+
+// PublicKey is an HSS public key, which is encoded as the number of levels
+// followed by the LMS public key of the top level.
+type PublicKey struct {
+	pk []byte
+}
+
+// PrivateKey is an HSS private key. Its packed form consists of the number
+// of levels L, the parameter sets of the levels, the state of the levels,
+// the identifier I and the seed of the top tree, and its root.
+//
+// The state holds, for each level but the last, the index of the one-time
+// key that signs the current tree of the level below, and for the last
+// level the index of the next unused one-time key. The identifiers and
+// seeds of the lower trees are derived from their signing key, so that
+// they need not be stored.
+//
+// A private key caches the nodes of the current tree of each level, which
+// takes 2^(h+1)*m bytes per level. It is safe for concurrent use.
+type PrivateKey struct {
+	levels []Level
+	sk     []byte
+	store  StateStore
+
+	mu     sync.Mutex
+	trees  []*lmsTree
+	signed [][]byte // Signed public keys of the trees below the top.
+}
+
+var (
+	errLevels    = errors.New("lms: invalid parameter sets")
+	errEncoding  = errors.New("lms: invalid encoding")
+	errNoStore   = errors.New("lms: missing state store")
+	errExhausted = errors.New("lms: private key exhausted")
+)
+
+// checkLevels validates the parameter sets of a hierarchy, whose trees and
+// one-time keys must all use the same hash function and output size, and
+// returns this size.
+func checkLevels(levels []Level) (int, error) {
+	if len(levels) < 1 || len(levels) > maxLevels {
+		return 0, errLevels
+	}
+	p0, _ := levels[0].LMS.params()
+	for _, l := range levels {
+		p, ok1 := l.LMS.params()
+		op, ok2 := l.OTS.params()
+		if !ok1 || !ok2 || p.shake != p0.shake || p.m != p0.m ||
+			op.shake != p0.shake || op.n != p0.m {
+			return 0, errLevels
+		}
+	}
+	return p0.m, nil
+}
+
+func privateKeySize(levels, n int) int { return 4 + 12*levels + 16 + 2*n }
+
+// GenerateKey generates an HSS key pair with the given levels, from the top
+// level down, using entropy from rand, and saves the new private key with
+// store. If rand is nil, crypto/rand.Reader is used. The key is returned
+// only after store succeeds. A single level amounts to an LMS key pair.
+//
+// Key generation computes every leaf of the top tree, and signing computes
+// every leaf of a lower tree whenever it takes a new one.
+func GenerateKey(levels []Level, rand io.Reader, store StateStore) (*PublicKey, *PrivateKey, error) {
+	n, err := checkLevels(levels)
+	if err != nil {
+		return nil, nil, err
+	}
+	if store == nil {
+		return nil, nil, errNoStore
+	}
+	if rand == nil {
+		rand = cryptoRand.Reader
+	}
+	sk := &PrivateKey{
+		levels: append([]Level{}, levels...),
+		sk:     make([]byte, privateKeySize(len(levels), n)),
+		store:  store,
+	}
+	binary.BigEndian.PutUint32(sk.sk, uint32(len(levels)))
+	for i, l := range levels {
+		binary.BigEndian.PutUint32(sk.sk[4+8*i:], uint32(l.LMS))
+		binary.BigEndian.PutUint32(sk.sk[8+8*i:], uint32(l.OTS))
+	}
+	if _, err := io.ReadFull(rand, sk.sk[len(sk.sk)-16-2*n:len(sk.sk)-n]); err != nil {
+		return nil, nil, err
+	}
+
+	top := newTree(levels[0], sk.ident(), sk.seed())
+	top.build()
+	copy(sk.root(), top.root())
+	sk.trees = make([]*lmsTree, len(levels))
+	sk.trees[0] = top
+
+	if err := store.Store(sk.pack()); err != nil {
+		return nil, nil, err
+	}
+	return sk.Public(), sk, nil
+}
+
+// Sign returns an HSS signature of msg using the next unused one-time key
+// of sk.
+//
+// The state of the key is advanced and saved with the state store before
+// the signature is computed. If the store fails, no signature is produced
+// and the error is returned; the one-time key stays consumed, so that it is
+// not reused should the state have been partially written.
+func Sign(sk *PrivateKey, msg []byte) ([]byte, error) {
+	sk.mu.Lock()
+	defer sk.mu.Unlock()
+	if sk.store == nil {
+		return nil, errNoStore
+	}
+	last := len(sk.levels) - 1
+	q := sk.counter(last)
+	if q == sk.leaves(last) {
+		j := last - 1
+		for j >= 0 && sk.counter(j)+1 == sk.leaves(j) {
+			j--
+		}
+		if j < 0 {
+			return nil, errExhausted
+		}
+		sk.setCounter(j, sk.counter(j)+1)
+		for k := j + 1; k <= last; k++ {
+			sk.setCounter(k, 0)
+		}
+		q = 0
+	}
+	sk.setCounter(last, q+1)
+	if err := sk.store.Store(sk.pack()); err != nil {
+		return nil, err
+	}
+
+	sk.updateTrees()
+	sig := make([]byte, 4)
+	binary.BigEndian.PutUint32(sig, uint32(last))
+	for _, s := range sk.signed {
+		sig = append(sig, s...)
+	}
+	return append(sig, sk.trees[last].sign(q, msg)...), nil
+}
+
+// updateTrees computes the current tree of each level from the state, and
+// the signatures of their public keys, unless they are cached.
+func (sk *PrivateKey) updateTrees() {
+	if sk.trees == nil {
+		sk.trees = make([]*lmsTree, len(sk.levels))
+	}
+	if sk.trees[0] == nil {
+		sk.trees[0] = newTree(sk.levels[0], sk.ident(), sk.seed())
+		sk.trees[0].build()
+	}
+	if sk.signed == nil {
+		sk.signed = make([][]byte, len(sk.levels)-1)
+	}
+	n := len(sk.seed())
+	for i := 1; i < len(sk.levels); i++ {
+		parent, q := sk.trees[i-1], sk.counter(i-1)
+		o := parent.otsKey(q)
+		ident := make([]byte, n)
+		o.derive(ident, deriveIdent)
+		if t := sk.trees[i]; t != nil && bytes.Equal(t.ident, ident[:16]) {
+			continue
+		}
+		seed := make([]byte, n)
+		o.derive(seed, deriveSeed)
+		t := newTree(sk.levels[i], ident[:16], seed)
+		t.build()
+		sk.trees[i] = t
+		pk := t.publicKey()
+		sk.signed[i-1] = append(parent.sign(q, pk), pk...)
+	}
+}
+
+// Verify returns true if sig is a valid HSS signature of msg under pk,
+// following Algorithm 6 of RFC 8554.
+func Verify(pk *PublicKey, msg, sig []byte) bool {
+	levels := binary.BigEndian.Uint32(pk.pk)
+	key, _, ok := parsePublicKey(pk.pk[4:])
+	if !ok || len(sig) < 4 {
+		return false
+	}
+	nspk := binary.BigEndian.Uint32(sig)
+	if uint64(nspk)+1 != uint64(levels) {
+		return false
+	}
+	sig = sig[4:]
+	for i := uint32(0); i < nspk; i++ {
+		size, ok := signatureSize(sig)
+		if !ok {
+			return false
+		}
+		lmsSig := sig[:size]
+		next, rest, ok := parsePublicKey(sig[size:])
+		if !ok || !key.verify(sig[size:len(sig)-len(rest)], lmsSig) {
+			return false
+		}
+		key, sig = next, rest
+	}
+	return key.verify(msg, sig)
+}
+
+func (sk *PrivateKey) leaves(level int) uint32 {
+	p, _ := sk.levels[level].LMS.params()
+	return 1 << uint(p.h)
+}
+
+func (sk *PrivateKey) counter(level int) uint32 {
+	return binary.BigEndian.Uint32(sk.sk[4+8*len(sk.levels)+4*level:])
+}
+
+func (sk *PrivateKey) setCounter(level int, q uint32) {
+	binary.BigEndian.PutUint32(sk.sk[4+8*len(sk.levels)+4*level:], q)
+}
+
+func (sk *PrivateKey) ident() []byte {
+	o := 4 + 12*len(sk.levels)
+	return sk.sk[o : o+16]
+}
+
+func (sk *PrivateKey) seed() []byte {
+	n := (len(sk.sk) - privateKeySize(len(sk.levels), 0)) / 2
+	o := 4 + 12*len(sk.levels) + 16
+	return sk.sk[o : o+n]
+}
+
+func (sk *PrivateKey) root() []byte {
+	n := (len(sk.sk) - privateKeySize(len(sk.levels), 0)) / 2
+	return sk.sk[len(sk.sk)-n:]
+}
+
+func (sk *PrivateKey) pack() []byte { return append([]byte{}, sk.sk...) }
+
+// Levels returns the parameter sets of the levels of the key.
+func (sk *PrivateKey) Levels() []Level { return append([]Level{}, sk.levels...) }
+
+// Remaining returns the number of signatures that sk can still issue, or
+// math.MaxUint64 if it is larger.
+func (sk *PrivateKey) Remaining() uint64 {
+	sk.mu.Lock()
+	defer sk.mu.Unlock()
+	total, used := big.NewInt(1), new(big.Int)
+	for i := range sk.levels {
+		leaves := big.NewInt(int64(sk.leaves(i)))
+		total.Mul(total, leaves)
+		used.Mul(used, leaves)
+		used.Add(used, big.NewInt(int64(sk.counter(i))))
+	}
+	// The counters of the upper levels point at the key in use, whereas the
+	// counter of the last level points past the last key used.
+	rem := total.Sub(total, used)
+	if !rem.IsUint64() {
+		return math.MaxUint64
+	}
+	return rem.Uint64()
+}
+
+// Public returns the public key corresponding to the private key.
+func (sk *PrivateKey) Public() *PublicKey {
+	t := newTree(sk.levels[0], sk.ident(), nil)
+	t.nodes = [][]byte{sk.root()}
+	pk := make([]byte, 4, 4+24+len(sk.root()))
+	binary.BigEndian.PutUint32(pk, uint32(len(sk.levels)))
+	return &PublicKey{append(pk, t.publicKey()...)}
+}
+
+// Equal returns true if both public keys are equal.
+func (pk *PublicKey) Equal(other *PublicKey) bool {
+	return bytes.Equal(pk.pk, other.pk)
+}
+
+// MarshalBinary returns the packed public key.
+func (pk *PublicKey) MarshalBinary() ([]byte, error) {
+	return append([]byte{}, pk.pk...), nil
+}
+
+// MarshalBinary returns the packed private key, including its current
+// state.
+func (sk *PrivateKey) MarshalBinary() ([]byte, error) {
+	sk.mu.Lock()
+	defer sk.mu.Unlock()
+	return sk.pack(), nil
+}
+
+// UnmarshalPublicKey unpacks an HSS public key.
+func UnmarshalPublicKey(data []byte) (*PublicKey, error) {
+	if len(data) < 4 {
+		return nil, errEncoding
+	}
+	levels := binary.BigEndian.Uint32(data)
+	_, rest, ok := parsePublicKey(data[4:])
+	if !ok || len(rest) != 0 || levels < 1 || levels > maxLevels {
+		return nil, errEncoding
+	}
+	return &PublicKey{append([]byte{}, data...)}, nil
+}
+
+// UnmarshalPrivateKey unpacks an HSS private key, whose subsequent states
+// are saved with store. The caller must ensure that data is the latest
+// state saved for this key: restoring an older state would reuse one-time
+// keys, which breaks the security of the scheme.
+func UnmarshalPrivateKey(data []byte, store StateStore) (*PrivateKey, error) {
+	if store == nil {
+		return nil, errNoStore
+	}
+	if len(data) < 4 {
+		return nil, errEncoding
+	}
+	l := binary.BigEndian.Uint32(data)
+	if l < 1 || l > maxLevels || len(data) < 4+8*int(l) {
+		return nil, errEncoding
+	}
+	levels := make([]Level, l)
+	for i := range levels {
+		levels[i].LMS = LMSType(binary.BigEndian.Uint32(data[4+8*i:]))
+		levels[i].OTS = OTSType(binary.BigEndian.Uint32(data[8+8*i:]))
+	}
+	n, err := checkLevels(levels)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) != privateKeySize(len(levels), n) {
+		return nil, errEncoding
+	}
+	sk := &PrivateKey{levels: levels, sk: append([]byte{}, data...), store: store}
+	last := len(levels) - 1
+	for i := 0; i < last; i++ {
+		if sk.counter(i) >= sk.leaves(i) {
+			return nil, errEncoding
+		}
+	}
+	if sk.counter(last) > sk.leaves(last) {
+		return nil, errEncoding
+	}
+	return sk, nil
+}
@@ -0,0 +1,178 @@
+package lms
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+
+	"github.com/cloudflare/circl/sha3"
+)
+
+// Domain separators of the hash calls.
+const (
+	dPblc = 0x8080 // Public key of LM-OTS.
+	dMesg = 0x8181 // Message digest of LM-OTS.
+	dLeaf = 0x8282 // Leaf of an LMS tree.
+	dIntr = 0x8383 // Internal node of an LMS tree.
+)
+
+// Values of the chain index when deriving pseudorandom values from the
+// seed as in Appendix A of RFC 8554. The chain indices of the secret values
+// are below 265, so these values do not collide with them.
+const (
+	deriveC     = 0xfffd // Randomizer of the message digest.
+	deriveSeed  = 0xfffe // Seed of a child tree.
+	deriveIdent = 0xffff // Identifier of a child tree.
+)
+
+// hasher computes SHA-256 or SHAKE256 truncated to the size of its output.
+// It is not safe for concurrent use.
+type hasher struct {
+	sha   hash.Hash
+	shake *sha3.Shake
+	sum   []byte
+}
+
+func newHasher(useShake bool) *hasher {
+	if useShake {
+		return &hasher{shake: sha3.NewShake256()}
+	}
+	return &hasher{sha: sha256.New(), sum: make([]byte, 0, sha256.Size)}
+}
+
+// hash writes the hash of the concatenation of in to out. The output may
+// overlap the input.
+func (s *hasher) hash(out []byte, in ...[]byte) {
+	if s.shake != nil {
+		s.shake.Reset()
+		for _, b := range in {
+			_, _ = s.shake.Write(b)
+		}
+		_, _ = s.shake.Read(out)
+		return
+	}
+	s.sha.Reset()
+	for _, b := range in {
+		_, _ = s.sha.Write(b)
+	}
+	s.sum = s.sha.Sum(s.sum[:0])
+	copy(out, s.sum)
+}
+
+// ots is an LM-OTS key pair, given by the identifier of its tree, its
+// index q in the tree, and the seed from which its secret values derive.
+type ots struct {
+	otsParams
+	typ   OTSType
+	ident []byte
+	q     uint32
+	seed  []byte
+	h     *hasher
+}
+
+// prefix returns the common beginning of the hash inputs, I || u32str(q).
+func (o *ots) prefix() []byte {
+	b := make([]byte, 20, 23+o.n)
+	copy(b, o.ident)
+	binary.BigEndian.PutUint32(b[16:], o.q)
+	return b
+}
+
+// derive computes a pseudorandom value as
+// H(I || u32str(q) || u16str(i) || u8str(0xff) || SEED).
+func (o *ots) derive(out []byte, i uint16) {
+	b := o.prefix()
+	b = append(b, byte(i>>8), byte(i), 0xff)
+	o.h.hash(out[:o.n], b, o.seed)
+}
+
+// chain iterates the hash chain i from the value in out, which is taken as
+// its start-th value, up to its end-th value.
+func (o *ots) chain(out []byte, i int, start, end uint) {
+	b := append(o.prefix(), byte(i>>8), byte(i), 0)
+	b = append(b, out[:o.n]...)
+	for j := start; j < end; j++ {
+		b[22] = byte(j)
+		o.h.hash(b[23:], b)
+	}
+	copy(out, b[23:])
+}
+
+// digits returns the Winternitz coefficients of the digest Q followed by
+// those of its checksum.
+func (o *ots) digits(q []byte) []uint {
+	mask := uint(1)<<o.w - 1
+	coef := func(s []byte, i int) uint {
+		perByte := 8 / int(o.w)
+		shift := 8 - o.w*uint(i%perByte+1)
+		return uint(s[i/perByte]>>shift) & mask
+	}
+	u := 8 * o.n / int(o.w)
+	sum := uint(0)
+	for i := 0; i < u; i++ {
+		sum += mask - coef(q, i)
+	}
+	sum <<= o.ls
+	s := append(append([]byte{}, q[:o.n]...), byte(sum>>8), byte(sum))
+	a := make([]uint, o.p)
+	for i := range a {
+		a[i] = coef(s, i)
+	}
+	return a
+}
+
+// msgDigest computes Q = H(I || u32str(q) || u16str(D_MESG) || C || msg).
+func (o *ots) msgDigest(c, msg []byte) []byte {
+	b := append(o.prefix(), dMesg>>8, dMesg&0xff)
+	q := make([]byte, o.n)
+	o.h.hash(q, b, c, msg)
+	return q
+}
+
+// publicKey computes the public key K, from the ends of the chains in y.
+func (o *ots) publicKey(k, y []byte) {
+	b := append(o.prefix(), dPblc>>8, dPblc&0xff)
+	o.h.hash(k[:o.n], b, y)
+}
+
+// genPublicKey computes the public key K of the key pair.
+func (o *ots) genPublicKey(k []byte) {
+	n, end := o.n, uint(1)<<o.w-1
+	y := make([]byte, o.p*n)
+	for i := 0; i < o.p; i++ {
+		o.derive(y[i*n:], uint16(i))
+		o.chain(y[i*n:], i, 0, end)
+	}
+	o.publicKey(k, y)
+}
+
+// signatureSize returns the size of an LM-OTS signature.
+func (p *otsParams) signatureSize() int { return 4 + p.n + p.p*p.n }
+
+// sign returns the signature u32str(type) || C || y[0] || ... || y[p-1] of
+// msg. The randomizer C is derived from the seed, so the signature of a
+// given message is deterministic.
+func (o *ots) sign(msg []byte) []byte {
+	n := o.n
+	sig := make([]byte, o.signatureSize())
+	binary.BigEndian.PutUint32(sig, uint32(o.typ))
+	c, y := sig[4:4+n], sig[4+n:]
+	o.derive(c, deriveC)
+	for i, a := range o.digits(o.msgDigest(c, msg)) {
+		o.derive(y[i*n:], uint16(i))
+		o.chain(y[i*n:], i, 0, a)
+	}
+	return sig
+}
+
+// candidate computes the public key candidate Kc from a signature of msg,
+// which must have the size and type of the key pair.
+func (o *ots) candidate(k, sig, msg []byte) {
+	n, end := o.n, uint(1)<<o.w-1
+	c := sig[4 : 4+n]
+	y := append([]byte{}, sig[4+n:]...)
+	for i, a := range o.digits(o.msgDigest(c, msg)) {
+		o.chain(y[i*n:], i, a, end)
+	}
+	o.publicKey(k, y)
+}
@@ -0,0 +1,176 @@
+package lms
+
+import (
+	"crypto/subtle"
+	"encoding/binary"
+)
+
+// lmsTree is an LMS tree, given by its parameter sets and its identifier I,
+// and for a private tree by the seed of its one-time keys and its nodes.
+type lmsTree struct {
+	typ    LMSType
+	otsTyp OTSType
+	p      lmsParams
+	op     otsParams
+	ident  []byte
+	seed   []byte
+	nodes  [][]byte // nodes[k] holds the nodes of height k.
+	hasher *hasher
+}
+
+func newTree(l Level, ident, seed []byte) *lmsTree {
+	t := &lmsTree{typ: l.LMS, otsTyp: l.OTS, ident: ident, seed: seed}
+	t.p, _ = l.LMS.params()
+	t.op, _ = l.OTS.params()
+	t.hasher = newHasher(t.p.shake)
+	return t
+}
+
+func (t *lmsTree) otsKey(q uint32) *ots {
+	return &ots{otsParams: t.op, typ: t.otsTyp, ident: t.ident, q: q, seed: t.seed, h: t.hasher}
+}
+
+// nodeHash computes the node T[r] of the tree from the public key of a
+// leaf, or from the two children of an internal node.
+func (t *lmsTree) nodeHash(out []byte, r uint32, d uint16, left, right []byte) {
+	var b [22]byte
+	copy(b[:], t.ident)
+	binary.BigEndian.PutUint32(b[16:], r)
+	binary.BigEndian.PutUint16(b[20:], d)
+	t.hasher.hash(out[:t.p.m], b[:], left, right)
+}
+
+// build computes all the nodes of the tree.
+func (t *lmsTree) build() {
+	m, h := t.p.m, t.p.h
+	t.nodes = make([][]byte, h+1)
+	leaves := make([]byte, m<<uint(h))
+	k := make([]byte, t.op.n)
+	for q := 0; q < 1<<uint(h); q++ {
+		t.otsKey(uint32(q)).genPublicKey(k)
+		t.nodeHash(leaves[q*m:], uint32(1<<uint(h)+q), dLeaf, k, nil)
+	}
+	t.nodes[0] = leaves
+	for i := 1; i <= h; i++ {
+		below := t.nodes[i-1]
+		level := make([]byte, len(below)/2)
+		first := uint32(1) << uint(h-i)
+		for j := 0; j < len(level)/m; j++ {
+			t.nodeHash(level[j*m:], first+uint32(j), dIntr,
+				below[2*j*m:(2*j+1)*m], below[(2*j+1)*m:(2*j+2)*m])
+		}
+		t.nodes[i] = level
+	}
+}
+
+func (t *lmsTree) root() []byte { return t.nodes[len(t.nodes)-1] }
+
+// publicKey returns u32str(type) || u32str(otstype) || I || T[1].
+func (t *lmsTree) publicKey() []byte {
+	pk := make([]byte, 24+t.p.m)
+	binary.BigEndian.PutUint32(pk, uint32(t.typ))
+	binary.BigEndian.PutUint32(pk[4:], uint32(t.otsTyp))
+	copy(pk[8:], t.ident)
+	copy(pk[24:], t.root())
+	return pk
+}
+
+func (t *lmsTree) signatureSize() int { return 8 + t.op.signatureSize() + t.p.h*t.p.m }
+
+// sign returns the signature u32str(q) || lmots_signature || u32str(type)
+// || path[0] || ... || path[h-1] of msg with the one-time key q.
+func (t *lmsTree) sign(q uint32, msg []byte) []byte {
+	m := t.p.m
+	sig := make([]byte, 4, t.signatureSize())
+	binary.BigEndian.PutUint32(sig, q)
+	sig = append(sig, t.otsKey(q).sign(msg)...)
+	sig = append(sig, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(sig[len(sig)-4:], uint32(t.typ))
+	for i := 0; i < t.p.h; i++ {
+		j := int(q>>uint(i)) ^ 1
+		sig = append(sig, t.nodes[i][j*m:(j+1)*m]...)
+	}
+	return sig
+}
+
+// verify checks a signature of msg under the public tree, following
+// Algorithm 6a of RFC 8554.
+func (t *lmsTree) verify(msg, sig []byte) bool {
+	m, h := t.p.m, t.p.h
+	otsLen := t.op.signatureSize()
+	if len(sig) != 8+otsLen+h*m ||
+		OTSType(binary.BigEndian.Uint32(sig[4:])) != t.otsTyp ||
+		LMSType(binary.BigEndian.Uint32(sig[4+otsLen:])) != t.typ {
+		return false
+	}
+	q := binary.BigEndian.Uint32(sig)
+	if q >= 1<<uint(h) {
+		return false
+	}
+	k := make([]byte, t.op.n)
+	t.otsKey(q).candidate(k, sig[4:4+otsLen], msg)
+
+	node := make([]byte, m)
+	r := uint32(1)<<uint(h) + q
+	t.nodeHash(node, r, dLeaf, k, nil)
+	path := sig[8+otsLen:]
+	for ; r > 1; r >>= 1 {
+		if r&1 == 1 {
+			t.nodeHash(node, r/2, dIntr, path[:m], node)
+		} else {
+			t.nodeHash(node, r/2, dIntr, node, path[:m])
+		}
+		path = path[m:]
+	}
+	return subtle.ConstantTimeCompare(node, t.root()) == 1
+}
+
+// parsePublicKey parses the LMS public key at the beginning of data, and
+// returns the rest of data.
+func parsePublicKey(data []byte) (*lmsTree, []byte, bool) {
+	if len(data) < 8 {
+		return nil, nil, false
+	}
+	l := Level{
+		LMS: LMSType(binary.BigEndian.Uint32(data)),
+		OTS: OTSType(binary.BigEndian.Uint32(data[4:])),
+	}
+	if _, ok := l.LMS.params(); !ok {
+		return nil, nil, false
+	}
+	if _, ok := l.OTS.params(); !ok {
+		return nil, nil, false
+	}
+	t := newTree(l, nil, nil)
+	if len(data) < 24+t.p.m {
+		return nil, nil, false
+	}
+	t.ident = data[8:24]
+	t.nodes = [][]byte{data[24 : 24+t.p.m]}
+	return t, data[24+t.p.m:], true
+}
+
+// signatureSize returns the size of the LMS signature at the beginning of
+// data, or false if it cannot be parsed.
+func signatureSize(data []byte) (int, bool) {
+	if len(data) < 8 {
+		return 0, false
+	}
+	op, ok := OTSType(binary.BigEndian.Uint32(data[4:])).params()
+	if !ok {
+		return 0, false
+	}
+	size := 4 + op.signatureSize()
+	if len(data) < size+4 {
+		return 0, false
+	}
+	p, ok := LMSType(binary.BigEndian.Uint32(data[size:])).params()
+	if !ok {
+		return 0, false
+	}
+	size += 4 + p.h*p.m
+	if len(data) < size {
+		return 0, false
+	}
+	return size, true
+}
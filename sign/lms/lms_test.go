@@ -0,0 +1,255 @@
+package lms
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+)
+
+// memStore keeps the saved states in memory, and fails when err is set.
+type memStore struct {
+	states [][]byte
+	err    error
+}
+
+func (m *memStore) Store(sk []byte) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.states = append(m.states, append([]byte{}, sk...))
+	return nil
+}
+
+func (m *memStore) last() []byte { return m.states[len(m.states)-1] }
+
+func TestSignVerify(t *testing.T) {
+	msg := []byte("HSS signature")
+	for _, levels := range [][]Level{
+		{{LMSSHA256M32H5, LMOTSSHA256N32W8}},
+		{{LMSSHA256M32H5, LMOTSSHA256N32W4}, {LMSSHA256M32H5, LMOTSSHA256N32W2}},
+		{{LMSSHA256M24H5, LMOTSSHA256N24W4}, {LMSSHA256M24H5, LMOTSSHA256N24W1}},
+		{{LMSSHAKEM32H5, LMOTSSHAKEN32W4}, {LMSSHAKEM32H5, LMOTSSHAKEN32W4}, {LMSSHAKEM32H5, LMOTSSHAKEN32W4}},
+		{{LMSSHAKEM24H10, LMOTSSHAKEN24W4}},
+	} {
+		name := levels[0].LMS.String() + "/" + levels[0].OTS.String()
+		levels := levels
+		t.Run(name, func(t *testing.T) {
+			store := &memStore{}
+			pk, sk, err := GenerateKey(levels, rand.Reader, store)
+			test.CheckNoErr(t, err, "key generation failed")
+			if !pk.Equal(sk.Public()) {
+				t.Fatal("public key mismatch")
+			}
+			if got, want := len(store.states), 1; got != want {
+				test.ReportError(t, got, want)
+			}
+
+			// The second signature uses new trees below the top level.
+			last := len(levels) - 1
+			for i := 1; i <= last; i++ {
+				sk.setCounter(i, sk.leaves(i)-1)
+			}
+			for i := 0; i < 2; i++ {
+				before := sk.Remaining()
+				sig, err := Sign(sk, msg)
+				test.CheckNoErr(t, err, "signing failed")
+				if got, want := sk.Remaining(), before-1; got != want {
+					test.ReportError(t, got, want)
+				}
+				if !Verify(pk, msg, sig) {
+					t.Fatal("valid signature rejected")
+				}
+				packed, _ := sk.MarshalBinary()
+				if !bytes.Equal(store.last(), packed) {
+					t.Fatal("state not saved before signing")
+				}
+
+				if Verify(pk, msg[1:], sig) {
+					t.Fatal("signature of another message accepted")
+				}
+				if Verify(pk, msg, sig[:len(sig)-1]) {
+					t.Fatal("truncated signature accepted")
+				}
+				if Verify(pk, msg, append(sig, 0)) {
+					t.Fatal("extended signature accepted")
+				}
+				for _, j := range []int{3, 7, 11, len(sig) / 2, len(sig) - 1} {
+					sig[j] ^= 0x01
+					if Verify(pk, msg, sig) {
+						t.Fatalf("tampered signature accepted (byte %d)", j)
+					}
+					sig[j] ^= 0x01
+				}
+			}
+		})
+	}
+}
+
+func TestState(t *testing.T) {
+	levels := []Level{{LMSSHA256M32H5, LMOTSSHA256N32W4}, {LMSSHA256M32H5, LMOTSSHA256N32W4}}
+	msg := []byte("HSS signature")
+	store := &memStore{}
+	pk, sk, err := GenerateKey(levels, rand.Reader, store)
+	test.CheckNoErr(t, err, "key generation failed")
+	if got, want := sk.Remaining(), uint64(1024); got != want {
+		test.ReportError(t, got, want)
+	}
+
+	// A failing store prevents signing, and burns the one-time key.
+	store.err = errors.New("disk full")
+	sig, err := Sign(sk, msg)
+	test.CheckIsErr(t, err, "should fail when the state is not stored")
+	if sig != nil {
+		t.Fatal("signature released without storing the state")
+	}
+	store.err = nil
+	sig, err = Sign(sk, msg)
+	test.CheckNoErr(t, err, "signing failed")
+	if !Verify(pk, msg, sig) {
+		t.Fatal("valid signature rejected")
+	}
+
+	// A restored key resumes from the stored state, and issues the same
+	// signed public key of the lower tree.
+	sk2, err := UnmarshalPrivateKey(store.last(), store)
+	test.CheckNoErr(t, err, "unmarshal failed")
+	sig2, err := Sign(sk2, msg)
+	test.CheckNoErr(t, err, "signing failed")
+	if !Verify(pk, msg, sig2) {
+		t.Fatal("valid signature rejected")
+	}
+	upper := len(sig) - sk2.trees[1].signatureSize()
+	if !bytes.Equal(sig[:upper], sig2[:upper]) {
+		t.Fatal("signed public keys differ")
+	}
+	if got, want := sk2.Remaining(), uint64(1024-3); got != want {
+		test.ReportError(t, got, want)
+	}
+
+	// An exhausted key refuses to sign.
+	sk2.setCounter(0, 31)
+	sk2.setCounter(1, 31)
+	_, err = Sign(sk2, msg)
+	test.CheckNoErr(t, err, "signing failed")
+	if sk2.Remaining() != 0 {
+		t.Fatal("key should be exhausted")
+	}
+	_, err = Sign(sk2, msg)
+	test.CheckIsErr(t, err, "should fail on exhausted key")
+
+	_, err = Sign(&PrivateKey{levels: levels, sk: sk2.sk}, msg)
+	test.CheckIsErr(t, err, "should fail without a state store")
+	_, _, err = GenerateKey(levels, rand.Reader, nil)
+	test.CheckIsErr(t, err, "should fail without a state store")
+}
+
+func TestMarshal(t *testing.T) {
+	levels := []Level{{LMSSHAKEM24H5, LMOTSSHAKEN24W8}, {LMSSHAKEM24H5, LMOTSSHAKEN24W8}}
+	store := &memStore{}
+	pk, sk, err := GenerateKey(levels, rand.Reader, store)
+	test.CheckNoErr(t, err, "key generation failed")
+
+	ppk, err := pk.MarshalBinary()
+	test.CheckNoErr(t, err, "marshal failed")
+	psk, err := sk.MarshalBinary()
+	test.CheckNoErr(t, err, "marshal failed")
+	if got, want := len(ppk), 4+24+24; got != want {
+		test.ReportError(t, got, want)
+	}
+
+	pk2, err := UnmarshalPublicKey(ppk)
+	test.CheckNoErr(t, err, "unmarshal failed")
+	sk2, err := UnmarshalPrivateKey(psk, store)
+	test.CheckNoErr(t, err, "unmarshal failed")
+	if !pk.Equal(pk2) || !pk.Equal(sk2.Public()) {
+		t.Fatal("keys differ after unmarshaling")
+	}
+	if got, want := sk2.Levels(), levels; len(got) != len(want) || got[1] != want[1] {
+		test.ReportError(t, got, want)
+	}
+
+	_, err = UnmarshalPublicKey(ppk[1:])
+	test.CheckIsErr(t, err, "should fail on wrong size")
+	_, err = UnmarshalPrivateKey(psk[1:], store)
+	test.CheckIsErr(t, err, "should fail on wrong size")
+	_, err = UnmarshalPrivateKey(psk, nil)
+	test.CheckIsErr(t, err, "should fail without a state store")
+
+	for _, bad := range [][]Level{
+		nil,
+		{{LMSSHA256M32H5, LMOTSSHA256N24W4}},
+		{{LMSSHA256M32H5, LMOTSSHAKEN32W4}},
+		{{LMSSHA256M32H5, LMOTSSHA256N32W4}, {LMSSHA256M24H5, LMOTSSHA256N24W4}},
+		{{LMSType(0), LMOTSSHA256N32W4}},
+		{{LMSSHA256M32H5, OTSType(0x11)}},
+		make([]Level, maxLevels+1),
+	} {
+		_, _, err = GenerateKey(bad, rand.Reader, store)
+		test.CheckIsErr(t, err, "should fail on invalid parameter sets")
+	}
+}
+
+func TestParams(t *testing.T) {
+	// Table 1 of RFC 8554 and Section 4.1 of SP 800-208.
+	for _, v := range []struct {
+		typ   OTSType
+		name  string
+		p     int
+		ls    uint
+		sigSz int
+	}{
+		{LMOTSSHA256N32W1, "LMOTS_SHA256_N32_W1", 265, 7, 8516},
+		{LMOTSSHA256N32W2, "LMOTS_SHA256_N32_W2", 133, 6, 4292},
+		{LMOTSSHA256N32W4, "LMOTS_SHA256_N32_W4", 67, 4, 2180},
+		{LMOTSSHA256N32W8, "LMOTS_SHA256_N32_W8", 34, 0, 1124},
+		{LMOTSSHA256N24W1, "LMOTS_SHA256_N24_W1", 200, 8, 4828},
+		{LMOTSSHAKEN24W2, "LMOTS_SHAKE_N24_W2", 101, 6, 2452},
+		{LMOTSSHAKEN24W4, "LMOTS_SHAKE_N24_W4", 51, 4, 1252},
+		{LMOTSSHAKEN32W8, "LMOTS_SHAKE_N32_W8", 34, 0, 1124},
+	} {
+		p, ok := v.typ.params()
+		if !ok {
+			t.Fatal("invalid parameter set")
+		}
+		if got, want := v.typ.String(), v.name; got != want {
+			test.ReportError(t, got, want)
+		}
+		if got, want := p.p, v.p; got != want {
+			test.ReportError(t, got, want, v.name)
+		}
+		if got, want := p.ls, v.ls; got != want {
+			test.ReportError(t, got, want, v.name)
+		}
+		if got, want := p.signatureSize(), v.sigSz; got != want {
+			test.ReportError(t, got, want, v.name)
+		}
+	}
+	if got, want := LMSSHAKEM24H20.String(), "LMS_SHAKE_M24_H20"; got != want {
+		test.ReportError(t, got, want)
+	}
+	if got, want := LMSSHA256M32H25.String(), "LMS_SHA256_M32_H25"; got != want {
+		test.ReportError(t, got, want)
+	}
+}
+
+func BenchmarkHSS(b *testing.B) {
+	levels := []Level{{LMSSHA256M32H10, LMOTSSHA256N32W4}, {LMSSHA256M32H5, LMOTSSHA256N32W4}}
+	msg := []byte("HSS signature")
+	store := &memStore{}
+	pk, sk, _ := GenerateKey(levels, rand.Reader, store)
+	sig, _ := Sign(sk, msg)
+	b.Run("Sign", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			store.states = store.states[:0]
+			_, _ = Sign(sk, msg)
+		}
+	})
+	b.Run("Verify", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Verify(pk, msg, sig)
+		}
+	})
+}
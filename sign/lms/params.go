@@ -0,0 +1,150 @@
+package lms
+
+import "fmt"
+
+// LMSType identifies a parameter set of the LMS Merkle tree, by its value in
+// the IANA registry.
+type LMSType uint32
+
+// Parameter sets of LMS from RFC 8554 and NIST SP 800-208. The names follow
+// the hash function, the size M of the tree nodes, and the height H of the
+// tree. SHAKE stands for SHAKE256.
+const (
+	LMSSHA256M32H5  LMSType = 0x05
+	LMSSHA256M32H10 LMSType = 0x06
+	LMSSHA256M32H15 LMSType = 0x07
+	LMSSHA256M32H20 LMSType = 0x08
+	LMSSHA256M32H25 LMSType = 0x09
+	LMSSHA256M24H5  LMSType = 0x0a
+	LMSSHA256M24H10 LMSType = 0x0b
+	LMSSHA256M24H15 LMSType = 0x0c
+	LMSSHA256M24H20 LMSType = 0x0d
+	LMSSHA256M24H25 LMSType = 0x0e
+	LMSSHAKEM32H5   LMSType = 0x0f
+	LMSSHAKEM32H10  LMSType = 0x10
+	LMSSHAKEM32H15  LMSType = 0x11
+	LMSSHAKEM32H20  LMSType = 0x12
+	LMSSHAKEM32H25  LMSType = 0x13
+	LMSSHAKEM24H5   LMSType = 0x14
+	LMSSHAKEM24H10  LMSType = 0x15
+	LMSSHAKEM24H15  LMSType = 0x16
+	LMSSHAKEM24H20  LMSType = 0x17
+	LMSSHAKEM24H25  LMSType = 0x18
+)
+
+// OTSType identifies a parameter set of the LM-OTS one-time signature, by
+// its value in the IANA registry.
+type OTSType uint32
+
+// Parameter sets of LM-OTS from RFC 8554 and NIST SP 800-208. The names
+// follow the hash function, the size N of the hashes, and the Winternitz
+// parameter W. SHAKE stands for SHAKE256.
+const (
+	LMOTSSHA256N32W1 OTSType = 0x01
+	LMOTSSHA256N32W2 OTSType = 0x02
+	LMOTSSHA256N32W4 OTSType = 0x03
+	LMOTSSHA256N32W8 OTSType = 0x04
+	LMOTSSHA256N24W1 OTSType = 0x05
+	LMOTSSHA256N24W2 OTSType = 0x06
+	LMOTSSHA256N24W4 OTSType = 0x07
+	LMOTSSHA256N24W8 OTSType = 0x08
+	LMOTSSHAKEN32W1  OTSType = 0x09
+	LMOTSSHAKEN32W2  OTSType = 0x0a
+	LMOTSSHAKEN32W4  OTSType = 0x0b
+	LMOTSSHAKEN32W8  OTSType = 0x0c
+	LMOTSSHAKEN24W1  OTSType = 0x0d
+	LMOTSSHAKEN24W2  OTSType = 0x0e
+	LMOTSSHAKEN24W4  OTSType = 0x0f
+	LMOTSSHAKEN24W8  OTSType = 0x10
+)
+
+// lmsParams holds the values describing a parameter set of LMS.
+type lmsParams struct {
+	shake bool // Whether it is instantiated with SHAKE256 or with SHA-256.
+	m     int  // Size in bytes of the tree nodes.
+	h     int  // Height of the tree.
+}
+
+// otsParams holds the values describing a parameter set of LM-OTS.
+type otsParams struct {
+	shake bool // Whether it is instantiated with SHAKE256 or with SHA-256.
+	n     int  // Size in bytes of the hashes.
+	w     uint // Width in bits of the Winternitz coefficients.
+	p     int  // Number of chains.
+	ls    uint // Left shift of the checksum.
+}
+
+func (t LMSType) params() (lmsParams, bool) {
+	if t < LMSSHA256M32H5 || t > LMSSHAKEM24H25 {
+		return lmsParams{}, false
+	}
+	i := int(t - LMSSHA256M32H5)
+	family := i / 5
+	return lmsParams{
+		shake: family >= 2,
+		m:     32 - 8*(family%2),
+		h:     5 * (i%5 + 1),
+	}, true
+}
+
+func (t OTSType) params() (otsParams, bool) {
+	if t < LMOTSSHA256N32W1 || t > LMOTSSHAKEN24W8 {
+		return otsParams{}, false
+	}
+	i := int(t - LMOTSSHA256N32W1)
+	family := i / 4
+	p := otsParams{
+		shake: family >= 2,
+		n:     32 - 8*(family%2),
+		w:     1 << uint(i%4),
+	}
+	// Section 4.1 of RFC 8554: u digits of the hash, followed by the v
+	// digits of the checksum, which is shifted to the top of 16 bits.
+	u := (8*p.n + int(p.w) - 1) / int(p.w)
+	maxSum := (1<<p.w - 1) * u
+	bits := 0
+	for ; maxSum > 0; maxSum >>= 1 {
+		bits++
+	}
+	v := (bits + int(p.w) - 1) / int(p.w)
+	p.p = u + v
+	p.ls = 16 - uint(v)*p.w
+	return p, true
+}
+
+// String returns the name of the parameter set, such as
+// "LMS_SHA256_M32_H10".
+func (t LMSType) String() string {
+	p, ok := t.params()
+	if !ok {
+		return fmt.Sprintf("LMSType(%d)", uint32(t))
+	}
+	hash := "SHA256"
+	if p.shake {
+		hash = "SHAKE"
+	}
+	return fmt.Sprintf("LMS_%s_M%d_H%d", hash, p.m, p.h)
+}
+
+// String returns the name of the parameter set, such as
+// "LMOTS_SHA256_N32_W4".
+func (t OTSType) String() string {
+	p, ok := t.params()
+	if !ok {
+		return fmt.Sprintf("OTSType(%d)", uint32(t))
+	}
+	hash := "SHA256"
+	if p.shake {
+		hash = "SHAKE"
+	}
+	return fmt.Sprintf("LMOTS_%s_N%d_W%d", hash, p.n, p.w)
+}
+
+// Level is the parameter set of one level of an HSS hierarchy.
+type Level struct {
+	LMS LMSType
+	OTS OTSType
+}
+
+// maxLevels is the maximum number of levels of an HSS hierarchy.
+const maxLevels = 8
@@ -0,0 +1,87 @@
+package sphincsplus
+
+import "encoding/binary"
+
+// Types of the addresses, which separate the domains of the hash calls.
+const (
+	addrWots     = 0
+	addrWotsPk   = 1
+	addrHashTree = 2
+	addrForsTree = 3
+	addrForsPk   = 4
+	addrWotsPrf  = 5
+	addrForsPrf  = 6
+)
+
+// offsets locates the fields of an address. SHAKE256 uses the full 32-byte
+// address, whereas SHA-2 uses a compressed 22-byte address that fits
+// together with the public seed into a single compression call.
+type offsets struct {
+	size      int
+	layer     int
+	tree      int
+	typ       int
+	keyPair2  int
+	keyPair1  int
+	chain     int
+	hash      int
+	treeHgt   int
+	treeIndex int
+}
+
+var (
+	shakeOffsets = offsets{
+		size: 32, layer: 3, tree: 8, typ: 19, keyPair2: 22, keyPair1: 23,
+		chain: 27, hash: 31, treeHgt: 27, treeIndex: 28,
+	}
+	sha2Offsets = offsets{
+		size: 22, layer: 0, tree: 1, typ: 9, keyPair2: 12, keyPair1: 13,
+		chain: 17, hash: 21, treeHgt: 17, treeIndex: 18,
+	}
+)
+
+// address is the structure used to tweak every hash call.
+type address struct {
+	b [32]byte
+	o *offsets
+}
+
+func (a *address) bytes() []byte { return a.b[:a.o.size] }
+
+func (a *address) setLayer(layer uint32) { a.b[a.o.layer] = byte(layer) }
+
+func (a *address) setTree(tree uint64) {
+	binary.BigEndian.PutUint64(a.b[a.o.tree:], tree)
+}
+
+func (a *address) setType(typ uint32) { a.b[a.o.typ] = byte(typ) }
+
+// copySubtree copies the layer and tree fields from b.
+func (a *address) copySubtree(b *address) {
+	copy(a.b[:a.o.tree+8], b.b[:b.o.tree+8])
+}
+
+// setKeyPair sets the index of the WOTS+ (or FORS) key pair. The leaves of
+// the trees are at most 2^9, so two bytes always suffice; the high byte is
+// zero for sets with trees of height at most 8.
+func (a *address) setKeyPair(keyPair uint32) {
+	a.b[a.o.keyPair2] = byte(keyPair >> 8)
+	a.b[a.o.keyPair1] = byte(keyPair)
+}
+
+// copyKeyPair copies the layer, tree and key pair fields from b.
+func (a *address) copyKeyPair(b *address) {
+	a.copySubtree(b)
+	a.b[a.o.keyPair2] = b.b[b.o.keyPair2]
+	a.b[a.o.keyPair1] = b.b[b.o.keyPair1]
+}
+
+func (a *address) setChain(chain uint32) { a.b[a.o.chain] = byte(chain) }
+
+func (a *address) setHash(hash uint32) { a.b[a.o.hash] = byte(hash) }
+
+func (a *address) setTreeHeight(height uint32) { a.b[a.o.treeHgt] = byte(height) }
+
+func (a *address) setTreeIndex(index uint32) {
+	binary.BigEndian.PutUint32(a.b[a.o.treeIndex:], index)
+}
@@ -0,0 +1,18 @@
+// Package sphincsplus implements the SPHINCS+ stateless hash-based signature
+// scheme, version 3.1 of the submission to the NIST PQC standardization.
+//
+// A signature consists of a randomizer, a FORS few-time signature of the
+// message digest, and a chain of WOTS+ one-time signatures and authentication
+// paths through the d layers of a hypertree, whose root is the public key.
+//
+// This package provides the parameter sets instantiated with SHAKE256, which
+// is computed with the Keccak permutation of this library, and with SHA-256,
+// together with both the simple and the robust tweakable hash constructions.
+// As in version 3.1, the SHA-2 parameter sets of security level above 128
+// bits use SHA-512 for the message digest, the message PRF, and the
+// tweakable hashes of more than one block.
+//
+// References:
+//   - SPHINCS+ https://sphincs.org/
+//   - Specification v3.1 https://sphincs.org/data/sphincs+-r3.1-specification.pdf
+package sphincsplus
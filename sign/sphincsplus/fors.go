@@ -0,0 +1,74 @@
+package sphincsplus
+
+// messageToIndices reads the k indices of the FORS leaves, of a bits each,
+// from msg in little-endian bit order.
+func (s *state) messageToIndices(indices []uint32, msg []byte) {
+	offset := 0
+	for i := range indices[:s.k] {
+		indices[i] = 0
+		for j := 0; j < s.a; j++ {
+			bit := (msg[offset>>3] >> uint(offset&7)) & 1
+			indices[i] ^= uint32(bit) << uint(j)
+			offset++
+		}
+	}
+}
+
+// forsSign writes the FORS signature of msg, and the FORS public key to pk.
+func (s *state) forsSign(sig, pk, msg []byte, forsAddr *address) {
+	n := s.n
+	indices := make([]uint32, s.k)
+	roots := make([]byte, s.k*n)
+	treeAddr, leafAddr, pkAddr := s.newAddress(), s.newAddress(), s.newAddress()
+	treeAddr.copyKeyPair(forsAddr)
+	leafAddr.copyKeyPair(forsAddr)
+	pkAddr.copyKeyPair(forsAddr)
+	pkAddr.setType(addrForsPk)
+
+	genLeaf := func(leaf []byte, idx uint32) {
+		leafAddr.setTreeIndex(idx)
+		leafAddr.setType(addrForsPrf)
+		s.prf(leaf, &leafAddr)
+		leafAddr.setType(addrForsTree)
+		s.thash(leaf, leaf[:n], &leafAddr)
+	}
+
+	s.messageToIndices(indices, msg)
+	for i, idx := range indices {
+		offset := uint32(i) << uint(s.a)
+		treeAddr.setTreeHeight(0)
+		treeAddr.setTreeIndex(idx + offset)
+		treeAddr.setType(addrForsPrf)
+		s.prf(sig, &treeAddr)
+		treeAddr.setType(addrForsTree)
+		sig = sig[n:]
+		s.treeHash(roots[i*n:], sig, idx, offset, s.a, genLeaf, &treeAddr)
+		sig = sig[s.a*n:]
+	}
+	s.thash(pk, roots, &pkAddr)
+}
+
+// forsPkFromSig computes the FORS public key from a signature of msg.
+func (s *state) forsPkFromSig(pk, sig, msg []byte, forsAddr *address) {
+	n := s.n
+	indices := make([]uint32, s.k)
+	roots := make([]byte, s.k*n)
+	leaf := make([]byte, n)
+	treeAddr, pkAddr := s.newAddress(), s.newAddress()
+	treeAddr.copyKeyPair(forsAddr)
+	pkAddr.copyKeyPair(forsAddr)
+	treeAddr.setType(addrForsTree)
+	pkAddr.setType(addrForsPk)
+
+	s.messageToIndices(indices, msg)
+	for i, idx := range indices {
+		offset := uint32(i) << uint(s.a)
+		treeAddr.setTreeHeight(0)
+		treeAddr.setTreeIndex(idx + offset)
+		s.thash(leaf, sig[:n], &treeAddr)
+		sig = sig[n:]
+		s.computeRoot(roots[i*n:], leaf, idx, offset, sig, s.a, &treeAddr)
+		sig = sig[s.a*n:]
+	}
+	s.thash(pk, roots, &pkAddr)
+}
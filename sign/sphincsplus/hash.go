@@ -0,0 +1,226 @@
+package sphincsplus
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding"
+	"encoding/binary"
+	"hash"
+
+	"github.com/cloudflare/circl/sha3"
+)
+
+// state holds the seeds of a key pair together with the hash functions used
+// to compute the tweakable hashes and PRFs keyed by them. It is not safe for
+// concurrent use.
+type state struct {
+	*params
+	o      *offsets
+	pkSeed []byte
+	skSeed []byte
+
+	shake *sha3.Shake
+
+	// SHA-2 hashes, and their states after absorbing the public seed padded
+	// to a full block, so that it is processed only once.
+	h256, h512           hash.Hash
+	seeded256, seeded512 []byte
+
+	sum  []byte // Output of the SHA-2 hashes.
+	mask []byte // Bitmasks of the robust tweakable hash.
+	seed []byte // Input of MGF1.
+}
+
+func newState(p *params, pkSeed, skSeed []byte) *state {
+	s := &state{params: p, pkSeed: pkSeed, skSeed: skSeed}
+	s.mask = make([]byte, p.n*max(p.wotsLen, p.k))
+	if !p.sha2 {
+		s.o = &shakeOffsets
+		s.shake = sha3.NewShake256()
+		return s
+	}
+	s.o = &sha2Offsets
+	s.sum = make([]byte, 0, sha512.Size)
+	s.seed = make([]byte, 0, 2*sha512.Size+sha2Offsets.size)
+	s.h256, s.seeded256 = seededHash(sha256.New(), pkSeed)
+	if p.n > 16 {
+		s.h512, s.seeded512 = seededHash(sha512.New(), pkSeed)
+	}
+	return s
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// seededHash returns h and its state after absorbing the seed padded with
+// zeros to the block size of h.
+func seededHash(h hash.Hash, seed []byte) (hash.Hash, []byte) {
+	block := make([]byte, h.BlockSize())
+	copy(block, seed)
+	_, _ = h.Write(block)
+	st, err := h.(encoding.BinaryMarshaler).MarshalBinary()
+	if err != nil {
+		panic(err)
+	}
+	return h, st
+}
+
+func restore(h hash.Hash, st []byte) {
+	if err := h.(encoding.BinaryUnmarshaler).UnmarshalBinary(st); err != nil {
+		panic(err)
+	}
+}
+
+func (s *state) newAddress() address { return address{o: s.o} }
+
+// prf derives the secret value at the given address: PRF(PK.seed, SK.seed,
+// ADRS).
+func (s *state) prf(out []byte, addr *address) {
+	if s.sha2 {
+		restore(s.h256, s.seeded256)
+		_, _ = s.h256.Write(addr.bytes())
+		_, _ = s.h256.Write(s.skSeed)
+		s.sum = s.h256.Sum(s.sum[:0])
+		copy(out[:s.n], s.sum)
+		return
+	}
+	h := s.shake
+	h.Reset()
+	_, _ = h.Write(s.pkSeed)
+	_, _ = h.Write(addr.bytes())
+	_, _ = h.Write(s.skSeed)
+	_, _ = h.Read(out[:s.n])
+}
+
+// thash computes the tweakable hash of in, which consists of one or more
+// n-byte blocks, at the given address. The output may overlap the input.
+func (s *state) thash(out, in []byte, addr *address) {
+	if s.sha2 {
+		s.thashSha2(out, in, addr)
+	} else {
+		s.thashShake(out, in, addr)
+	}
+}
+
+func (s *state) thashShake(out, in []byte, addr *address) {
+	h := s.shake
+	if s.robust {
+		mask := s.mask[:len(in)]
+		h.Reset()
+		_, _ = h.Write(s.pkSeed)
+		_, _ = h.Write(addr.bytes())
+		_, _ = h.Read(mask)
+		for i := range mask {
+			mask[i] ^= in[i]
+		}
+		in = mask
+	}
+	h.Reset()
+	_, _ = h.Write(s.pkSeed)
+	_, _ = h.Write(addr.bytes())
+	_, _ = h.Write(in)
+	_, _ = h.Read(out[:s.n])
+}
+
+func (s *state) thashSha2(out, in []byte, addr *address) {
+	// Sets with security level above 128 bits use SHA-512 for the hashes
+	// taking more than one block.
+	h, seeded := s.h256, s.seeded256
+	if len(in) > s.n && s.h512 != nil {
+		h, seeded = s.h512, s.seeded512
+	}
+	if s.robust {
+		mask := s.mask[:len(in)]
+		s.seed = append(append(s.seed[:0], s.pkSeed...), addr.bytes()...)
+		s.mgf1(mask, h, s.seed)
+		for i := range mask {
+			mask[i] ^= in[i]
+		}
+		in = mask
+	}
+	restore(h, seeded)
+	_, _ = h.Write(addr.bytes())
+	_, _ = h.Write(in)
+	s.sum = h.Sum(s.sum[:0])
+	copy(out[:s.n], s.sum)
+}
+
+// mgf1 fills out with the mask generation function MGF1 of seed using h.
+func (s *state) mgf1(out []byte, h hash.Hash, seed []byte) {
+	var ctr [4]byte
+	for c := uint32(0); len(out) > 0; c++ {
+		binary.BigEndian.PutUint32(ctr[:], c)
+		h.Reset()
+		_, _ = h.Write(seed)
+		_, _ = h.Write(ctr[:])
+		s.sum = h.Sum(s.sum[:0])
+		out = out[copy(out, s.sum):]
+	}
+}
+
+// prfMsg computes the randomizer R of the message digest: PRF_msg(SK.prf,
+// OptRand, M).
+func (s *state) prfMsg(out, skPrf, optRand, msg []byte) {
+	if s.sha2 {
+		newHash := sha256.New
+		if s.h512 != nil {
+			newHash = sha512.New
+		}
+		m := hmac.New(newHash, skPrf)
+		_, _ = m.Write(optRand)
+		_, _ = m.Write(msg)
+		s.sum = m.Sum(s.sum[:0])
+		copy(out[:s.n], s.sum)
+		return
+	}
+	h := s.shake
+	h.Reset()
+	_, _ = h.Write(skPrf)
+	_, _ = h.Write(optRand)
+	_, _ = h.Write(msg)
+	_, _ = h.Read(out[:s.n])
+}
+
+// hashMsg computes the message digest H_msg(R, PK.seed, PK.root, M), which
+// is split into the part signed by FORS and the indices of the tree and of
+// the leaf of the hypertree used to sign the FORS public key.
+func (s *state) hashMsg(digest, r, pk, msg []byte) (tree uint64, leaf uint32) {
+	buf := make([]byte, s.digestBytes)
+	if s.sha2 {
+		h := sha256.New()
+		if s.h512 != nil {
+			h = sha512.New()
+		}
+		_, _ = h.Write(r)
+		_, _ = h.Write(pk)
+		_, _ = h.Write(msg)
+		s.sum = h.Sum(s.sum[:0])
+		s.seed = append(append(append(s.seed[:0], r...), s.pkSeed...), s.sum...)
+		s.mgf1(buf, h, s.seed)
+	} else {
+		h := s.shake
+		h.Reset()
+		_, _ = h.Write(r)
+		_, _ = h.Write(pk)
+		_, _ = h.Write(msg)
+		_, _ = h.Read(buf)
+	}
+
+	copy(digest, buf[:s.forsMsgBytes])
+	buf = buf[s.forsMsgBytes:]
+	treeBytes := (s.treeBits + 7) / 8
+	for _, b := range buf[:treeBytes] {
+		tree = tree<<8 | uint64(b)
+	}
+	tree &= ^uint64(0) >> uint(64-s.treeBits)
+	for _, b := range buf[treeBytes:] {
+		leaf = leaf<<8 | uint32(b)
+	}
+	leaf &= ^uint32(0) >> uint(32-s.hPrime)
+	return tree, leaf
+}
@@ -0,0 +1,105 @@
+package sphincsplus
+
+// Code to generate the NIST "PQCsignKAT" test vectors.
+// See PQCgenKAT_sign.c and rng.c in the reference implementation.
+
+import (
+	"crypto/sha256"
+	"flag"
+	"fmt"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/nist"
+)
+
+// Indicates whether long tests should be run
+var runLongTest = flag.Bool("long", false, "runs longer tests")
+
+func TestPQCgenKATSign(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipped in short mode")
+	}
+
+	// SHA-256 of the .rsp files of the reference implementation of v3.1.
+	kats := []struct {
+		id   ID
+		want string
+	}{
+		{SHAKE256Simple128s, "e7d5caee1941be99b6dfe46a95fc4535a34792f429e61d1cdc7fd3bbafe9ff02"},
+		{SHAKE256Simple128f, "5167df2ce46f33b76ccf0688f7769217d91878bd7d9b431080a3032eba51da10"},
+		{SHAKE256Simple192s, "4cc01c4a562d738ac54f5abfead35ecc4f46a1e2531fa12b4bc2819f4560c351"},
+		{SHAKE256Simple192f, "f204fd1cd5dce187441d104ae7159b64322b6a4afae708d48dc9966fe418ec4e"},
+		{SHAKE256Simple256s, "4ce4552e2e9b009a9016eb6dbcbefae3da2de151d61e2f392d4b9517eaeab91d"},
+		{SHAKE256Simple256f, "127f7ab83c740344546fe30777b221e8cb39f30fc4242d07d7608dc31a9835d4"},
+		{SHAKE256Robust128s, "fbe6c99d6ccc42fc9af5babbac532f28288d4164b182515dffeb1cd47f351d12"},
+		{SHAKE256Robust128f, "4be71430814589ce7c861030c7cdce0aa73f75885b693b41fdb7c34d8f32fa79"},
+		{SHAKE256Robust192s, "cb13eaa2b1c074f53c87f1025e6bb1b356ad8de3bea9388b90a058a6460766bb"},
+		{SHAKE256Robust192f, "243d0e25de08fea547b0beae5f778a48bd55e56066435f9cdb9afc60a722699e"},
+		{SHAKE256Robust256s, "4d2ca7d10f2206c3cb9a26c6b00a0361601a1fe2dddf102fbfd6d3dac0be10fe"},
+		{SHAKE256Robust256f, "5a736aeba47f8d84e3ca47126715affcb4ce6cef13e3c9f6af220827973aa383"},
+		{SHA256Simple128s, "65942fac8e225fde77dd277d297e68c94c2e25a2a4089f88be4b56fa92b18a84"},
+		{SHA256Simple128f, "708f6ab77f8026361e975f7be7b9b5d1cd8aca56e4a3604c85ef3f9fe6618549"},
+		{SHA256Simple192s, "13efa67b9297afa051b9b30e2686266350c8b4000caa49aa432516e2a86d0b68"},
+		{SHA256Simple192f, "84b1a342683bcad658efb6c65f7367c6b30623e74e3a24c2238d19eaf74722ab"},
+		{SHA256Simple256s, "c816ca365a667e4d6564a95ac576bc9d7be0de7e66eff93e6f05dd4f134a183f"},
+		{SHA256Simple256f, "46e286dc1a20012789c1bf4793a8eb2043dd0c11df729fa36d9f96b0aeffdac6"},
+		{SHA256Robust128s, "f4c2f31082fc8ad15419edc4f24c34a83d909f75eb37ea5ffe53df0fb5ef5306"},
+		{SHA256Robust128f, "b6c82007bbce794f9fd67de708cd4d959319c744b918ddb28795fd491b713aa9"},
+		{SHA256Robust192s, "50c4b94dc788446077b48af1d8fa0170dc2114b4cb72a19f1d8c7628f9dadfd6"},
+		{SHA256Robust192f, "b8e617db2099e617dfc372ff732eead88872aea791e2fe82628568d75dd03c78"},
+		{SHA256Robust256s, "1f42b407e1e351861ba23e520b1974f399e349fcb66c614d727a38fb4e646634"},
+		{SHA256Robust256f, "dc3330f8f19c816f45ee9a1127bf2b8a8c900e05df9a964bb760f0adf8f9b1b3"},
+	}
+	for _, kat := range kats {
+		kat := kat
+		t.Run(kat.id.String(), func(t *testing.T) {
+			p := kat.id.params()
+			if !*runLongTest && (p.n > 16 || p.d < 20) {
+				t.Skip("add -long flag to run the KATs of this parameter set")
+			}
+			testPQCgenKATSign(t, kat.id, kat.want)
+		})
+	}
+}
+
+func testPQCgenKATSign(t *testing.T, id ID, expected string) {
+	var seed [48]byte
+	for i := 0; i < 48; i++ {
+		seed[i] = byte(i)
+	}
+	f := sha256.New()
+	g := nist.NewDRBG(&seed)
+	fmt.Fprintf(f, "# SPHINCS+\n\n")
+	for i := 0; i < 100; i++ {
+		mlen := 33 * (i + 1)
+		msg := make([]byte, mlen)
+		g.Fill(seed[:])
+		g.Fill(msg)
+
+		g2 := nist.NewDRBG(&seed)
+		pk, sk, err := GenerateKey(id, &g2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig, err := Sign(sk, msg, &g2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !Verify(pk, msg, sig) {
+			t.Fatal("signature verification failed")
+		}
+		ppk, _ := pk.MarshalBinary()
+		psk, _ := sk.MarshalBinary()
+		fmt.Fprintf(f, "count = %d\n", i)
+		fmt.Fprintf(f, "seed = %X\n", seed)
+		fmt.Fprintf(f, "mlen = %d\n", mlen)
+		fmt.Fprintf(f, "msg = %X\n", msg)
+		fmt.Fprintf(f, "pk = %X\n", ppk)
+		fmt.Fprintf(f, "sk = %X\n", psk)
+		fmt.Fprintf(f, "smlen = %d\n", len(sig)+mlen)
+		fmt.Fprintf(f, "sm = %X%X\n\n", sig, msg)
+	}
+	if got := fmt.Sprintf("%x", f.Sum(nil)); got != expected {
+		t.Fatalf("%s: got %s, want %s", id, got, expected)
+	}
+}
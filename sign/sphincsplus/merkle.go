@@ -0,0 +1,119 @@
+package sphincsplus
+
+// treeHash computes the root of a tree of the given height whose leaves are
+// produced by genLeaf, and writes the authentication path of leafIdx. The
+// tree nodes are addressed by treeAddr, and offset lets FORS number its
+// trees as contiguous parts of a single tree.
+func (s *state) treeHash(
+	root, authPath []byte,
+	leafIdx, offset uint32,
+	height int,
+	genLeaf func(leaf []byte, idx uint32),
+	treeAddr *address,
+) {
+	n := s.n
+	stack := make([]byte, height*n)
+	// The current node is kept on the right half, so that the left node from
+	// the stack can be copied next to it for hashing.
+	current := make([]byte, 2*n)
+	maxIdx := uint32(1)<<uint(height) - 1
+	for idx := uint32(0); ; idx++ {
+		genLeaf(current[n:], idx+offset)
+
+		internalOffset := offset
+		internalIdx := idx
+		internalLeaf := leafIdx
+		h := 0
+		for ; ; h++ {
+			if h == height {
+				copy(root, current[n:])
+				return
+			}
+			if internalIdx^internalLeaf == 1 {
+				copy(authPath[h*n:], current[n:])
+			}
+			// A left child waits on the stack for its sibling, except at
+			// the end of the tree where all the pending nodes are merged.
+			if internalIdx&1 == 0 && idx < maxIdx {
+				break
+			}
+			internalOffset >>= 1
+			internalIdx >>= 1
+			internalLeaf >>= 1
+			treeAddr.setTreeHeight(uint32(h + 1))
+			treeAddr.setTreeIndex(internalIdx + internalOffset)
+			copy(current[:n], stack[h*n:])
+			s.thash(current[n:], current, treeAddr)
+		}
+		copy(stack[h*n:], current[n:])
+	}
+}
+
+// computeRoot computes the root of a tree from a leaf and its
+// authentication path.
+func (s *state) computeRoot(
+	root, leaf []byte,
+	leafIdx, offset uint32,
+	authPath []byte,
+	height int,
+	addr *address,
+) {
+	n := s.n
+	buf := make([]byte, 2*n)
+	if leafIdx&1 == 1 {
+		copy(buf[n:], leaf)
+		copy(buf, authPath[:n])
+	} else {
+		copy(buf, leaf)
+		copy(buf[n:], authPath[:n])
+	}
+	for i := 1; i <= height; i++ {
+		leafIdx >>= 1
+		offset >>= 1
+		addr.setTreeHeight(uint32(i))
+		addr.setTreeIndex(leafIdx + offset)
+		if i == height {
+			s.thash(root, buf, addr)
+			return
+		}
+		authPath = authPath[n:]
+		if leafIdx&1 == 1 {
+			s.thash(buf[n:], buf, addr)
+			copy(buf, authPath[:n])
+		} else {
+			s.thash(buf, buf, addr)
+			copy(buf[n:], authPath[:n])
+		}
+	}
+}
+
+// merkleSign writes the WOTS+ signature of root followed by the
+// authentication path of idxLeaf in the tree given by treeAddr, and then
+// overwrites root with the root of this tree.
+func (s *state) merkleSign(sig, root []byte, wotsAddr, treeAddr *address, idxLeaf uint32) {
+	info := &wotsLeaf{
+		leafAddr: s.newAddress(),
+		pkAddr:   s.newAddress(),
+		sig:      sig,
+		steps:    make([]uint32, s.wotsLen),
+		signLeaf: idxLeaf,
+		buf:      make([]byte, s.wotsBytes),
+	}
+	s.chainLengths(info.steps, root)
+	treeAddr.setType(addrHashTree)
+	info.pkAddr.setType(addrWotsPk)
+	info.leafAddr.copySubtree(wotsAddr)
+	info.pkAddr.copySubtree(wotsAddr)
+	genLeaf := func(leaf []byte, idx uint32) { s.wotsGenLeaf(leaf, idx, info) }
+	s.treeHash(root, sig[s.wotsBytes:], idxLeaf, 0, s.hPrime, genLeaf, treeAddr)
+}
+
+// merkleGenRoot computes the root of the top tree of the hypertree.
+func (s *state) merkleGenRoot(root []byte) {
+	sig := make([]byte, s.wotsBytes+s.hPrime*s.n)
+	topTreeAddr, wotsAddr := s.newAddress(), s.newAddress()
+	topTreeAddr.setLayer(uint32(s.d - 1))
+	wotsAddr.setLayer(uint32(s.d - 1))
+	// No leaf has this index, so neither a signature nor a path is needed.
+	s.merkleSign(sig, root, &wotsAddr, &topTreeAddr, ^uint32(0))
+}
@@ -0,0 +1,143 @@
+package sphincsplus
+
+import "fmt"
+
+// ID identifies a parameter set of SPHINCS+.
+type ID uint8
+
+// Parameter sets of SPHINCS+ instantiated with SHAKE256 or SHA-256. The
+// names follow the hash function, the tweakable hash construction (simple or
+// robust), the security level, and whether the set is optimized for small
+// signatures (s) or for fast signing (f).
+const (
+	SHAKE256Simple128s ID = iota
+	SHAKE256Simple128f
+	SHAKE256Simple192s
+	SHAKE256Simple192f
+	SHAKE256Simple256s
+	SHAKE256Simple256f
+	SHAKE256Robust128s
+	SHAKE256Robust128f
+	SHAKE256Robust192s
+	SHAKE256Robust192f
+	SHAKE256Robust256s
+	SHAKE256Robust256f
+	SHA256Simple128s
+	SHA256Simple128f
+	SHA256Simple192s
+	SHA256Simple192f
+	SHA256Simple256s
+	SHA256Simple256f
+	SHA256Robust128s
+	SHA256Robust128f
+	SHA256Robust192s
+	SHA256Robust192f
+	SHA256Robust256s
+	SHA256Robust256f
+
+	numIDs
+)
+
+const (
+	// logW is the base-2 logarithm of the Winternitz parameter.
+	logW = 4
+
+	// w is the Winternitz parameter.
+	w = 1 << logW
+
+	// wotsLen2 is the number of base-w digits of the WOTS+ checksum. It
+	// is the same for every parameter set, as len1*(w-1) < w^3.
+	wotsLen2 = 3
+)
+
+// params holds the values describing a parameter set.
+type params struct {
+	id     ID
+	n      int  // Size in bytes of hashes, seeds and tree nodes.
+	h      int  // Height of the hypertree.
+	d      int  // Number of layers of the hypertree.
+	hPrime int  // Height of each tree of the hypertree, h/d.
+	a      int  // Height of each FORS tree.
+	k      int  // Number of FORS trees.
+	sha2   bool // Whether it is instantiated with SHA-2 or with SHAKE256.
+	robust bool // Whether it uses the robust tweakable hash.
+
+	wotsLen      int // Number of chains of a WOTS+ key.
+	wotsBytes    int // Size of a WOTS+ signature.
+	forsBytes    int // Size of a FORS signature.
+	forsMsgBytes int // Size of the part of the digest signed by FORS.
+	treeBits     int // Bits of the digest selecting the hypertree leaf tree.
+	digestBytes  int // Size of the message digest.
+	sigBytes     int // Size of a signature.
+}
+
+func newParams(id ID, n, h, d, a, k int, sha2, robust bool) params {
+	p := params{id: id, n: n, h: h, d: d, a: a, k: k, sha2: sha2, robust: robust}
+	p.hPrime = h / d
+	p.wotsLen = 2*n + wotsLen2
+	p.wotsBytes = p.wotsLen * n
+	p.forsBytes = (a + 1) * k * n
+	p.forsMsgBytes = (a*k + 7) / 8
+	p.treeBits = p.hPrime * (d - 1)
+	p.digestBytes = p.forsMsgBytes + (p.treeBits+7)/8 + (p.hPrime+7)/8
+	p.sigBytes = n + p.forsBytes + d*p.wotsBytes + h*n
+	return p
+}
+
+var allParams [numIDs]params
+
+func init() {
+	sets := []struct{ n, h, d, a, k int }{
+		{16, 63, 7, 12, 14}, // 128s
+		{16, 66, 22, 6, 33}, // 128f
+		{24, 63, 7, 14, 17}, // 192s
+		{24, 66, 22, 8, 33}, // 192f
+		{32, 64, 8, 14, 22}, // 256s
+		{32, 68, 17, 9, 35}, // 256f
+	}
+	for i := range allParams {
+		s := sets[i%len(sets)]
+		sha2 := i >= 2*len(sets)
+		robust := (i/len(sets))%2 == 1
+		allParams[i] = newParams(ID(i), s.n, s.h, s.d, s.a, s.k, sha2, robust)
+	}
+}
+
+func (id ID) params() *params {
+	if id >= numIDs {
+		panic("sphincsplus: invalid parameter set")
+	}
+	return &allParams[id]
+}
+
+// String returns the name of the parameter set, for example
+// "SPHINCS+-SHAKE256-128s-simple".
+func (id ID) String() string {
+	if id >= numIDs {
+		return fmt.Sprintf("ID(%d)", uint8(id))
+	}
+	p := &allParams[id]
+	hash, thash, opt := "SHAKE256", "simple", "f"
+	if p.sha2 {
+		hash = "SHA256"
+	}
+	if p.robust {
+		thash = "robust"
+	}
+	if p.d <= 8 {
+		opt = "s"
+	}
+	return fmt.Sprintf("SPHINCS+-%s-%d%s-%s", hash, 8*p.n, opt, thash)
+}
+
+// PublicKeySize returns the size in bytes of a packed public key.
+func (id ID) PublicKeySize() int { return 2 * id.params().n }
+
+// PrivateKeySize returns the size in bytes of a packed private key.
+func (id ID) PrivateKeySize() int { return 4 * id.params().n }
+
+// SeedSize returns the size in bytes of the seed used by NewKeyFromSeed.
+func (id ID) SeedSize() int { return 3 * id.params().n }
+
+// SignatureSize returns the size in bytes of a signature.
+func (id ID) SignatureSize() int { return id.params().sigBytes }
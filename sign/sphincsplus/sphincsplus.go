@@ -0,0 +1,206 @@
+package sphincsplus
+
+import (
+	"bytes"
+	cryptoRand "crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"io"
+)
+
+// PublicKey is a SPHINCS+ public key, consisting of the public seed and
+// the root of the hypertree.
+type PublicKey struct {
+	id ID
+	pk []byte
+}
+
+// PrivateKey is a SPHINCS+ private key, consisting of the secret seed, the
+// PRF key, and the public key.
+type PrivateKey struct {
+	id ID
+	sk []byte
+}
+
+var (
+	errSize = errors.New("sphincsplus: wrong size")
+	errID   = errors.New("sphincsplus: invalid parameter set")
+)
+
+// NewKeyFromSeed derives a key pair from a seed of id.SeedSize() bytes.
+// Panics if the length of the seed is wrong.
+func NewKeyFromSeed(id ID, seed []byte) (*PublicKey, *PrivateKey) {
+	p := id.params()
+	n := p.n
+	if len(seed) != 3*n {
+		panic("sphincsplus: seed must be of length SeedSize")
+	}
+	sk := make([]byte, 4*n)
+	copy(sk, seed)
+	s := newState(p, sk[2*n:3*n], sk[:n])
+	s.merkleGenRoot(sk[3*n:])
+	pk := append([]byte{}, sk[2*n:]...)
+	return &PublicKey{id, pk}, &PrivateKey{id, sk}
+}
+
+// GenerateKey generates a key pair using entropy from rand. If rand is nil,
+// crypto/rand.Reader is used.
+func GenerateKey(id ID, rand io.Reader) (*PublicKey, *PrivateKey, error) {
+	if id >= numIDs {
+		return nil, nil, errID
+	}
+	if rand == nil {
+		rand = cryptoRand.Reader
+	}
+	seed := make([]byte, id.SeedSize())
+	if _, err := io.ReadFull(rand, seed); err != nil {
+		return nil, nil, err
+	}
+	pk, sk := NewKeyFromSeed(id, seed)
+	return pk, sk, nil
+}
+
+// Sign returns a signature of msg. The randomizer of the message digest is
+// derived from the private key, the message, and n bytes read from rand. If
+// rand is nil, the public seed takes their place and the signature is
+// deterministic.
+func Sign(sk *PrivateKey, msg []byte, rand io.Reader) ([]byte, error) {
+	p := sk.id.params()
+	n := p.n
+	skSeed, skPrf, pk := sk.sk[:n], sk.sk[n:2*n], sk.sk[2*n:]
+	optRand := pk[:n]
+	if rand != nil {
+		optRand = make([]byte, n)
+		if _, err := io.ReadFull(rand, optRand); err != nil {
+			return nil, err
+		}
+	}
+
+	s := newState(p, pk[:n], skSeed)
+	sig := make([]byte, p.sigBytes)
+	s.prfMsg(sig, skPrf, optRand, msg)
+	digest := make([]byte, p.forsMsgBytes)
+	tree, idxLeaf := s.hashMsg(digest, sig[:n], pk, msg)
+	rest := sig[n:]
+
+	wotsAddr, treeAddr := s.newAddress(), s.newAddress()
+	wotsAddr.setType(addrWots)
+	treeAddr.setType(addrHashTree)
+	wotsAddr.setTree(tree)
+	wotsAddr.setKeyPair(idxLeaf)
+
+	root := make([]byte, n)
+	s.forsSign(rest, root, digest, &wotsAddr)
+	rest = rest[p.forsBytes:]
+
+	for i := 0; i < p.d; i++ {
+		treeAddr.setLayer(uint32(i))
+		treeAddr.setTree(tree)
+		wotsAddr.copySubtree(&treeAddr)
+		wotsAddr.setKeyPair(idxLeaf)
+		s.merkleSign(rest, root, &wotsAddr, &treeAddr, idxLeaf)
+		rest = rest[p.wotsBytes+p.hPrime*n:]
+
+		idxLeaf = uint32(tree & (1<<uint(p.hPrime) - 1))
+		tree >>= uint(p.hPrime)
+	}
+	return sig, nil
+}
+
+// Verify returns true if sig is a valid signature of msg under pk.
+func Verify(pk *PublicKey, msg, sig []byte) bool {
+	p := pk.id.params()
+	n := p.n
+	if len(sig) != p.sigBytes {
+		return false
+	}
+	s := newState(p, pk.pk[:n], nil)
+	digest := make([]byte, p.forsMsgBytes)
+	tree, idxLeaf := s.hashMsg(digest, sig[:n], pk.pk, msg)
+	sig = sig[n:]
+
+	wotsAddr, treeAddr, wotsPkAddr := s.newAddress(), s.newAddress(), s.newAddress()
+	wotsAddr.setType(addrWots)
+	treeAddr.setType(addrHashTree)
+	wotsPkAddr.setType(addrWotsPk)
+	wotsAddr.setTree(tree)
+	wotsAddr.setKeyPair(idxLeaf)
+
+	root := make([]byte, n)
+	leaf := make([]byte, n)
+	wotsPk := make([]byte, p.wotsBytes)
+	s.forsPkFromSig(root, sig, digest, &wotsAddr)
+	sig = sig[p.forsBytes:]
+
+	for i := 0; i < p.d; i++ {
+		treeAddr.setLayer(uint32(i))
+		treeAddr.setTree(tree)
+		wotsAddr.copySubtree(&treeAddr)
+		wotsAddr.setKeyPair(idxLeaf)
+		wotsPkAddr.copyKeyPair(&wotsAddr)
+		s.wotsPkFromSig(wotsPk, sig, root, &wotsAddr)
+		sig = sig[p.wotsBytes:]
+		s.thash(leaf, wotsPk, &wotsPkAddr)
+		s.computeRoot(root, leaf, idxLeaf, 0, sig, p.hPrime, &treeAddr)
+		sig = sig[p.hPrime*n:]
+
+		idxLeaf = uint32(tree & (1<<uint(p.hPrime) - 1))
+		tree >>= uint(p.hPrime)
+	}
+	return subtle.ConstantTimeCompare(root, pk.pk[n:]) == 1
+}
+
+// ID returns the parameter set of the key.
+func (pk *PublicKey) ID() ID { return pk.id }
+
+// ID returns the parameter set of the key.
+func (sk *PrivateKey) ID() ID { return sk.id }
+
+// Public returns the public key corresponding to the private key.
+func (sk *PrivateKey) Public() *PublicKey {
+	n := sk.id.params().n
+	return &PublicKey{sk.id, append([]byte{}, sk.sk[2*n:]...)}
+}
+
+// Equal returns true if both public keys are equal.
+func (pk *PublicKey) Equal(other *PublicKey) bool {
+	return pk.id == other.id && bytes.Equal(pk.pk, other.pk)
+}
+
+// Equal returns true if both private keys are equal.
+func (sk *PrivateKey) Equal(other *PrivateKey) bool {
+	return sk.id == other.id && subtle.ConstantTimeCompare(sk.sk, other.sk) == 1
+}
+
+// MarshalBinary returns the packed public key.
+func (pk *PublicKey) MarshalBinary() ([]byte, error) {
+	return append([]byte{}, pk.pk...), nil
+}
+
+// MarshalBinary returns the packed private key.
+func (sk *PrivateKey) MarshalBinary() ([]byte, error) {
+	return append([]byte{}, sk.sk...), nil
+}
+
+// UnmarshalPublicKey unpacks a public key of the given parameter set.
+func UnmarshalPublicKey(id ID, data []byte) (*PublicKey, error) {
+	if id >= numIDs {
+		return nil, errID
+	}
+	if len(data) != id.PublicKeySize() {
+		return nil, errSize
+	}
+	return &PublicKey{id, append([]byte{}, data...)}, nil
+}
+
+// UnmarshalPrivateKey unpacks a private key of the given parameter set. The
+// root of the hypertree stored in the key is not recomputed.
+func UnmarshalPrivateKey(id ID, data []byte) (*PrivateKey, error) {
+	if id >= numIDs {
+		return nil, errID
+	}
+	if len(data) != id.PrivateKeySize() {
+		return nil, errSize
+	}
+	return &PrivateKey{id, append([]byte{}, data...)}, nil
+}
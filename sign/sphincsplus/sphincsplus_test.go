@@ -0,0 +1,170 @@
+package sphincsplus
+
+import (
+	"bytes"
+	"crypto/rand"
+	"strings"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+)
+
+// fastIDs are the parameter sets optimized for fast signing.
+var fastIDs = []ID{
+	SHAKE256Simple128f, SHAKE256Robust128f, SHA256Simple128f, SHA256Robust128f,
+	SHAKE256Simple192f, SHA256Simple192f, SHAKE256Simple256f, SHA256Simple256f,
+}
+
+func testIDs() []ID {
+	if !*runLongTest {
+		return fastIDs
+	}
+	ids := make([]ID, numIDs)
+	for i := range ids {
+		ids[i] = ID(i)
+	}
+	return ids
+}
+
+func TestSignVerify(t *testing.T) {
+	msg := []byte("SPHINCS+ signature")
+	for _, id := range testIDs() {
+		id := id
+		t.Run(id.String(), func(t *testing.T) {
+			pk, sk, err := GenerateKey(id, rand.Reader)
+			test.CheckNoErr(t, err, "key generation failed")
+			if !pk.Equal(sk.Public()) {
+				t.Fatal("public key mismatch")
+			}
+
+			sig, err := Sign(sk, msg, rand.Reader)
+			test.CheckNoErr(t, err, "signing failed")
+			if got, want := len(sig), id.SignatureSize(); got != want {
+				test.ReportError(t, got, want, id)
+			}
+			if !Verify(pk, msg, sig) {
+				t.Fatal("valid signature rejected")
+			}
+
+			sig1, err := Sign(sk, msg, nil)
+			test.CheckNoErr(t, err, "signing failed")
+			sig2, err := Sign(sk, msg, nil)
+			test.CheckNoErr(t, err, "signing failed")
+			if !bytes.Equal(sig1, sig2) {
+				t.Fatal("deterministic signatures differ")
+			}
+			if bytes.Equal(sig, sig1) {
+				t.Fatal("randomized signature equals deterministic one")
+			}
+			if !Verify(pk, msg, sig1) {
+				t.Fatal("valid deterministic signature rejected")
+			}
+
+			if Verify(pk, msg[1:], sig) {
+				t.Fatal("signature of another message accepted")
+			}
+			if Verify(pk, msg, sig[1:]) {
+				t.Fatal("truncated signature accepted")
+			}
+			for _, i := range []int{0, id.params().n, len(sig) / 2, len(sig) - 1} {
+				sig[i] ^= 0x01
+				if Verify(pk, msg, sig) {
+					t.Fatalf("tampered signature accepted (byte %d)", i)
+				}
+				sig[i] ^= 0x01
+			}
+
+			other, _, err := GenerateKey(id, rand.Reader)
+			test.CheckNoErr(t, err, "key generation failed")
+			if Verify(other, msg, sig) {
+				t.Fatal("signature accepted under another key")
+			}
+		})
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	id := SHA256Simple128f
+	seed := make([]byte, id.SeedSize())
+	_, _ = rand.Read(seed)
+	pk, sk := NewKeyFromSeed(id, seed)
+
+	ppk, err := pk.MarshalBinary()
+	test.CheckNoErr(t, err, "marshal failed")
+	psk, err := sk.MarshalBinary()
+	test.CheckNoErr(t, err, "marshal failed")
+	if got, want := len(ppk), id.PublicKeySize(); got != want {
+		test.ReportError(t, got, want)
+	}
+	if got, want := len(psk), id.PrivateKeySize(); got != want {
+		test.ReportError(t, got, want)
+	}
+	if !bytes.Equal(psk[:len(seed)], seed) {
+		t.Fatal("private key does not start with the seed")
+	}
+
+	pk2, err := UnmarshalPublicKey(id, ppk)
+	test.CheckNoErr(t, err, "unmarshal failed")
+	sk2, err := UnmarshalPrivateKey(id, psk)
+	test.CheckNoErr(t, err, "unmarshal failed")
+	if !pk.Equal(pk2) || !sk.Equal(sk2) {
+		t.Fatal("keys differ after unmarshaling")
+	}
+
+	_, err = UnmarshalPublicKey(id, ppk[1:])
+	test.CheckIsErr(t, err, "should fail on wrong size")
+	_, err = UnmarshalPrivateKey(SHA256Simple192f, psk)
+	test.CheckIsErr(t, err, "should fail on wrong size")
+	_, err = UnmarshalPublicKey(numIDs, ppk)
+	test.CheckIsErr(t, err, "should fail on invalid parameter set")
+	_, _, err = GenerateKey(numIDs, rand.Reader)
+	test.CheckIsErr(t, err, "should fail on invalid parameter set")
+
+	err = test.CheckPanic(func() { NewKeyFromSeed(id, seed[1:]) })
+	test.CheckNoErr(t, err, "should panic on wrong seed size")
+}
+
+func TestSizes(t *testing.T) {
+	// Signature sizes from Table 3 of the specification.
+	sizes := map[string]int{
+		"128s": 7856, "128f": 17088,
+		"192s": 16224, "192f": 35664,
+		"256s": 29792, "256f": 49856,
+	}
+	for i := ID(0); i < numIDs; i++ {
+		name := i.String()
+		level := strings.Split(name, "-")[2]
+		if got, want := i.SignatureSize(), sizes[level]; got != want {
+			test.ReportError(t, got, want, name)
+		}
+	}
+	if got, want := SHAKE256Robust192s.String(), "SPHINCS+-SHAKE256-192s-robust"; got != want {
+		test.ReportError(t, got, want)
+	}
+}
+
+func benchmarkSign(b *testing.B, id ID) {
+	msg := []byte("SPHINCS+ signature")
+	pk, sk, _ := GenerateKey(id, rand.Reader)
+	sig, _ := Sign(sk, msg, rand.Reader)
+	b.Run("GenerateKey", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _, _ = GenerateKey(id, rand.Reader)
+		}
+	})
+	b.Run("Sign", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = Sign(sk, msg, rand.Reader)
+		}
+	})
+	b.Run("Verify", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Verify(pk, msg, sig)
+		}
+	})
+}
+
+func BenchmarkSHAKE256Simple128f(b *testing.B) { benchmarkSign(b, SHAKE256Simple128f) }
+func BenchmarkSHA256Simple128f(b *testing.B)   { benchmarkSign(b, SHA256Simple128f) }
+func BenchmarkSHAKE256Simple128s(b *testing.B) { benchmarkSign(b, SHAKE256Simple128s) }
+func BenchmarkSHA256Simple128s(b *testing.B)   { benchmarkSign(b, SHA256Simple128s) }
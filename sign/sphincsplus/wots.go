@@ -0,0 +1,80 @@
+package sphincsplus
+
+// chainLengths converts the n-byte message into base-w digits, followed by
+// the digits of its checksum.
+func (s *state) chainLengths(lengths []uint32, msg []byte) {
+	len1 := 2 * s.n
+	for i, b := range msg[:s.n] {
+		lengths[2*i] = uint32(b >> 4)
+		lengths[2*i+1] = uint32(b & (w - 1))
+	}
+	csum := uint32(0)
+	for _, l := range lengths[:len1] {
+		csum += w - 1 - l
+	}
+	for i := wotsLen2 - 1; i >= 0; i-- {
+		lengths[len1+i] = csum & (w - 1)
+		csum >>= logW
+	}
+}
+
+// genChain applies steps iterations of the chaining function to in, which is
+// taken as the start-th value of the chain given by addr.
+func (s *state) genChain(out, in []byte, start, steps uint32, addr *address) {
+	copy(out[:s.n], in)
+	for i := start; i < start+steps && i < w; i++ {
+		addr.setHash(i)
+		s.thash(out, out[:s.n], addr)
+	}
+}
+
+// wotsPkFromSig computes the WOTS+ public key, as the concatenation of the
+// ends of the chains, from a signature of msg.
+func (s *state) wotsPkFromSig(pk, sig, msg []byte, addr *address) {
+	n := s.n
+	lengths := make([]uint32, s.wotsLen)
+	s.chainLengths(lengths, msg)
+	for i, l := range lengths {
+		addr.setChain(uint32(i))
+		s.genChain(pk[i*n:], sig[i*n:(i+1)*n], l, w-1-l, addr)
+	}
+}
+
+// wotsLeaf is a generator of the leaves of a tree of the hypertree. When
+// the leaf being generated is signLeaf, it also writes the WOTS+ signature
+// of the chain lengths in steps.
+type wotsLeaf struct {
+	leafAddr address
+	pkAddr   address
+	sig      []byte
+	steps    []uint32
+	signLeaf uint32
+	buf      []byte
+}
+
+func (s *state) wotsGenLeaf(leaf []byte, idx uint32, info *wotsLeaf) {
+	n := s.n
+	leafAddr, pkAddr := &info.leafAddr, &info.pkAddr
+	leafAddr.setKeyPair(idx)
+	pkAddr.setKeyPair(idx)
+	for i := 0; i < s.wotsLen; i++ {
+		node := info.buf[i*n : (i+1)*n]
+		sign := idx == info.signLeaf
+		leafAddr.setChain(uint32(i))
+		leafAddr.setHash(0)
+		leafAddr.setType(addrWotsPrf)
+		s.prf(node, leafAddr)
+		leafAddr.setType(addrWots)
+		for k := uint32(0); ; k++ {
+			if sign && k == info.steps[i] {
+				copy(info.sig[i*n:], node)
+			}
+			if k == w-1 {
+				break
+			}
+			leafAddr.setHash(k)
+			s.thash(node, node, leafAddr)
+		}
+	}
+	s.thash(leaf, info.buf, pkAddr)
+}
@@ -0,0 +1,46 @@
+package xmss
+
+import "encoding/binary"
+
+// Types of the addresses, which separate the domains of the hash calls.
+const (
+	addrOTS      = 0
+	addrLTree    = 1
+	addrHashTree = 2
+)
+
+// address is the 32-byte structure used to tweak every hash call. It is
+// made of eight big-endian words: the layer, the two words of the tree
+// index, the type, and four words whose meaning depends on the type.
+type address [32]byte
+
+func (a *address) setLayer(layer uint32) { binary.BigEndian.PutUint32(a[0:], layer) }
+
+func (a *address) setTree(tree uint64) { binary.BigEndian.PutUint64(a[4:], tree) }
+
+func (a *address) setType(typ uint32) { binary.BigEndian.PutUint32(a[12:], typ) }
+
+// setOTS sets the index of the WOTS+ key pair of an OTS address.
+func (a *address) setOTS(idx uint32) { binary.BigEndian.PutUint32(a[16:], idx) }
+
+// setLTree sets the index of the leaf compressed by an L-tree address.
+func (a *address) setLTree(idx uint32) { binary.BigEndian.PutUint32(a[16:], idx) }
+
+func (a *address) setChain(chain uint32) { binary.BigEndian.PutUint32(a[20:], chain) }
+
+func (a *address) setHash(hash uint32) { binary.BigEndian.PutUint32(a[24:], hash) }
+
+func (a *address) setTreeHeight(height uint32) { binary.BigEndian.PutUint32(a[20:], height) }
+
+func (a *address) setTreeIndex(index uint32) { binary.BigEndian.PutUint32(a[24:], index) }
+
+func (a *address) setKeyAndMask(km uint32) { binary.BigEndian.PutUint32(a[28:], km) }
+
+// newAddress returns an address of the given type in the tree of a layer.
+func newAddress(layer uint32, tree uint64, typ uint32) address {
+	var a address
+	a.setLayer(layer)
+	a.setTree(tree)
+	a.setType(typ)
+	return a
+}
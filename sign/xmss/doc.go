@@ -0,0 +1,21 @@
+// Package xmss implements the stateful hash-based signature schemes XMSS
+// and XMSS^MT as specified in RFC 8391, with the parameter sets approved by
+// NIST SP 800-208.
+//
+// A private key holds a fixed number of WOTS+ one-time keys, which are the
+// leaves of a Merkle tree (XMSS) or of a hypertree of d layers (XMSS^MT).
+// Each signature consumes the next unused one-time key, and signing twice
+// with the same one-time key allows forgeries. Hence, the state of the
+// private key, that is, the index of its next unused one-time key, is saved
+// with a StateStore provided by the caller before any signature is
+// computed, and signing fails if the state cannot be saved.
+//
+// This package provides the parameter sets instantiated with SHA-256 and
+// with SHAKE256, which is computed with the Keccak permutation of this
+// library, with n = 32 or n = 24 bytes. The secret values of the WOTS+ keys
+// are derived with PRF_keygen as specified in SP 800-208.
+//
+// References:
+//   - RFC 8391 https://www.rfc-editor.org/rfc/rfc8391
+//   - NIST SP 800-208 https://doi.org/10.6028/NIST.SP.800-208
+package xmss
@@ -0,0 +1,142 @@
+package xmss
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+
+	"github.com/cloudflare/circl/sha3"
+)
+
+// Domain separators of the hash functions, which are encoded as toByte(x,
+// padLen) in front of their inputs.
+const (
+	domainF         = 0
+	domainH         = 1
+	domainHMsg      = 2
+	domainPRF       = 3
+	domainPRFKeygen = 4
+)
+
+// state holds the seeds of a key pair together with the buffers used to
+// compute the keyed hash functions. It is not safe for concurrent use.
+type state struct {
+	*params
+	pubSeed []byte
+	skSeed  []byte
+
+	shake *sha3.Shake
+
+	buf  []byte // Input of the hash functions.
+	key  []byte // Key of the keyed hash functions F and H.
+	mask []byte // Bitmasks of F and H.
+}
+
+func newState(p *params, pubSeed, skSeed []byte) *state {
+	s := &state{params: p, pubSeed: pubSeed, skSeed: skSeed}
+	s.buf = make([]byte, 0, p.padLen+3*p.n+len(address{}))
+	s.key = make([]byte, p.n)
+	s.mask = make([]byte, 2*p.n)
+	if !p.sha2 {
+		s.shake = sha3.NewShake256()
+	}
+	return s
+}
+
+// hash computes the hash function with the given domain separator of the
+// concatenation of key and msg. The output may overlap the input.
+func (s *state) hash(out []byte, domain byte, key []byte, msg ...[]byte) {
+	b := s.buf[:s.padLen]
+	for i := range b {
+		b[i] = 0
+	}
+	b[s.padLen-1] = domain
+	b = append(b, key...)
+	for _, m := range msg {
+		b = append(b, m...)
+	}
+	s.buf = b
+	if s.sha2 {
+		sum := sha256.Sum256(b)
+		copy(out[:s.n], sum[:])
+		return
+	}
+	h := s.shake
+	h.Reset()
+	_, _ = h.Write(b)
+	_, _ = h.Read(out[:s.n])
+}
+
+// prf computes PRF(key, addr).
+func (s *state) prf(out, key []byte, addr *address) {
+	s.hash(out, domainPRF, key, addr[:])
+}
+
+// prfKeygen derives the secret value at the given address as in SP 800-208:
+// PRF_keygen(SK_SEED, SEED || ADRS).
+func (s *state) prfKeygen(out []byte, addr *address) {
+	s.hash(out, domainPRFKeygen, s.skSeed, s.pubSeed, addr[:])
+}
+
+// f computes the keyed chaining function F of in, whose key and bitmask are
+// derived from the public seed and addr.
+func (s *state) f(out, in []byte, addr *address) {
+	n := s.n
+	addr.setKeyAndMask(0)
+	s.prf(s.key, s.pubSeed, addr)
+	addr.setKeyAndMask(1)
+	s.prf(s.mask[:n], s.pubSeed, addr)
+	for i := range s.mask[:n] {
+		s.mask[i] ^= in[i]
+	}
+	s.hash(out, domainF, s.key, s.mask[:n])
+}
+
+// randHash computes the node hash RAND_HASH(left, right, SEED, addr). The
+// output may overlap the inputs.
+func (s *state) randHash(out, left, right []byte, addr *address) {
+	n := s.n
+	addr.setKeyAndMask(0)
+	s.prf(s.key, s.pubSeed, addr)
+	addr.setKeyAndMask(1)
+	s.prf(s.mask[:n], s.pubSeed, addr)
+	addr.setKeyAndMask(2)
+	s.prf(s.mask[n:], s.pubSeed, addr)
+	for i := 0; i < n; i++ {
+		s.mask[i] ^= left[i]
+		s.mask[n+i] ^= right[i]
+	}
+	s.hash(out, domainH, s.key, s.mask)
+}
+
+// hashMsg computes the message digest H_msg(r || root || toByte(idx, n),
+// msg).
+func (s *state) hashMsg(out, r, root []byte, idx uint64, msg []byte) {
+	b := s.buf[:s.padLen+3*s.n]
+	for i := range b {
+		b[i] = 0
+	}
+	b[s.padLen-1] = domainHMsg
+	copy(b[s.padLen:], r)
+	copy(b[s.padLen+s.n:], root)
+	binary.BigEndian.PutUint64(b[len(b)-8:], idx)
+	if s.sha2 {
+		h := sha256.New()
+		_, _ = h.Write(b)
+		_, _ = h.Write(msg)
+		copy(out[:s.n], h.Sum(nil))
+		return
+	}
+	h := s.shake
+	h.Reset()
+	_, _ = h.Write(b)
+	_, _ = h.Write(msg)
+	_, _ = h.Read(out[:s.n])
+}
+
+// prfMsg computes the randomizer of the message digest, PRF(SK_PRF,
+// toByte(idx, 32)).
+func (s *state) prfMsg(out, skPrf []byte, idx uint64) {
+	var b [32]byte
+	binary.BigEndian.PutUint64(b[24:], idx)
+	s.hash(out, domainPRF, skPrf, b[:])
+}
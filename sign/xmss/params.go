@@ -0,0 +1,189 @@
+package xmss
+
+import "fmt"
+
+// ID identifies a parameter set of XMSS or XMSS^MT.
+type ID uint8
+
+// Parameter sets of RFC 8391 and NIST SP 800-208 instantiated with SHA-256
+// or SHAKE256. The names follow the hash function, the total height H of
+// the tree, for XMSS^MT the number of layers D, and for the sets of 192-bit
+// security the size N of the hashes.
+const (
+	XMSSSHA2H10 ID = iota
+	XMSSSHA2H16
+	XMSSSHA2H20
+	XMSSSHA2H10N192
+	XMSSSHA2H16N192
+	XMSSSHA2H20N192
+	XMSSSHAKE256H10
+	XMSSSHAKE256H16
+	XMSSSHAKE256H20
+	XMSSSHAKE256H10N192
+	XMSSSHAKE256H16N192
+	XMSSSHAKE256H20N192
+	XMSSMTSHA2H20D2
+	XMSSMTSHA2H20D4
+	XMSSMTSHA2H40D2
+	XMSSMTSHA2H40D4
+	XMSSMTSHA2H40D8
+	XMSSMTSHA2H60D3
+	XMSSMTSHA2H60D6
+	XMSSMTSHA2H60D12
+	XMSSMTSHA2H20D2N192
+	XMSSMTSHA2H20D4N192
+	XMSSMTSHA2H40D2N192
+	XMSSMTSHA2H40D4N192
+	XMSSMTSHA2H40D8N192
+	XMSSMTSHA2H60D3N192
+	XMSSMTSHA2H60D6N192
+	XMSSMTSHA2H60D12N192
+	XMSSMTSHAKE256H20D2
+	XMSSMTSHAKE256H20D4
+	XMSSMTSHAKE256H40D2
+	XMSSMTSHAKE256H40D4
+	XMSSMTSHAKE256H40D8
+	XMSSMTSHAKE256H60D3
+	XMSSMTSHAKE256H60D6
+	XMSSMTSHAKE256H60D12
+	XMSSMTSHAKE256H20D2N192
+	XMSSMTSHAKE256H20D4N192
+	XMSSMTSHAKE256H40D2N192
+	XMSSMTSHAKE256H40D4N192
+	XMSSMTSHAKE256H40D8N192
+	XMSSMTSHAKE256H60D3N192
+	XMSSMTSHAKE256H60D6N192
+	XMSSMTSHAKE256H60D12N192
+
+	numIDs
+)
+
+const (
+	// logW is the base-2 logarithm of the Winternitz parameter.
+	logW = 4
+
+	// w is the Winternitz parameter.
+	w = 1 << logW
+
+	// wotsLen2 is the number of base-w digits of the WOTS+ checksum. It
+	// is the same for every parameter set, as len1*(w-1) < w^3.
+	wotsLen2 = 3
+)
+
+// params holds the values describing a parameter set.
+type params struct {
+	id     ID
+	oid    uint32
+	mt     bool // Whether it is a set of XMSS^MT or of XMSS.
+	sha2   bool // Whether it is instantiated with SHA-256 or with SHAKE256.
+	n      int  // Size in bytes of hashes, seeds and tree nodes.
+	h      int  // Total height of the tree.
+	d      int  // Number of layers.
+	hPrime int  // Height of each tree of a layer, h/d.
+
+	padLen    int // Size of the domain separator of the hash functions.
+	wotsLen   int // Number of chains of a WOTS+ key.
+	wotsBytes int // Size of a WOTS+ signature.
+	idxBytes  int // Size of the index of a signature.
+	pkBytes   int // Size of a public key.
+	skBytes   int // Size of a private key.
+	sigBytes  int // Size of a signature.
+}
+
+func newParams(id ID, oid uint32, mt, sha2 bool, n, h, d int) params {
+	p := params{id: id, oid: oid, mt: mt, sha2: sha2, n: n, h: h, d: d}
+	p.hPrime = h / d
+	// SP 800-208 shortens the domain separator of the 192-bit sets.
+	p.padLen = 32
+	if n == 24 {
+		p.padLen = 4
+	}
+	p.wotsLen = 2*n + wotsLen2
+	p.wotsBytes = p.wotsLen * n
+	p.idxBytes = 4
+	if mt {
+		p.idxBytes = (h + 7) / 8
+	}
+	p.pkBytes = 4 + 2*n
+	p.skBytes = 4 + p.idxBytes + 4*n
+	p.sigBytes = p.idxBytes + n + d*p.wotsBytes + h*n
+	return p
+}
+
+var allParams [numIDs]params
+
+func init() {
+	xmss := []struct {
+		oid  uint32
+		sha2 bool
+		n, h int
+	}{
+		{0x01, true, 32, 10}, {0x02, true, 32, 16}, {0x03, true, 32, 20},
+		{0x0d, true, 24, 10}, {0x0e, true, 24, 16}, {0x0f, true, 24, 20},
+		{0x10, false, 32, 10}, {0x11, false, 32, 16}, {0x12, false, 32, 20},
+		{0x13, false, 24, 10}, {0x14, false, 24, 16}, {0x15, false, 24, 20},
+	}
+	id := ID(0)
+	for _, s := range xmss {
+		allParams[id] = newParams(id, s.oid, false, s.sha2, s.n, s.h, 1)
+		id++
+	}
+
+	heights := []struct{ h, d int }{
+		{20, 2}, {20, 4}, {40, 2}, {40, 4}, {40, 8}, {60, 3}, {60, 6}, {60, 12},
+	}
+	families := []struct {
+		oid  uint32 // OID of the first set of the family.
+		sha2 bool
+		n    int
+	}{
+		{0x01, true, 32}, {0x21, true, 24}, {0x29, false, 32}, {0x31, false, 24},
+	}
+	for _, f := range families {
+		for i, s := range heights {
+			allParams[id] = newParams(id, f.oid+uint32(i), true, f.sha2, f.n, s.h, s.d)
+			id++
+		}
+	}
+}
+
+func (id ID) params() *params {
+	if id >= numIDs {
+		panic("xmss: invalid parameter set")
+	}
+	return &allParams[id]
+}
+
+// String returns the name of the parameter set as given in RFC 8391 and
+// SP 800-208, such as "XMSS-SHA2_10_256" or "XMSSMT-SHAKE256_40/4_192".
+func (id ID) String() string {
+	if id >= numIDs {
+		return fmt.Sprintf("ID(%d)", uint8(id))
+	}
+	p := id.params()
+	hash := "SHAKE256"
+	if p.sha2 {
+		hash = "SHA2"
+	}
+	if p.mt {
+		return fmt.Sprintf("XMSSMT-%s_%d/%d_%d", hash, p.h, p.d, 8*p.n)
+	}
+	return fmt.Sprintf("XMSS-%s_%d_%d", hash, p.h, 8*p.n)
+}
+
+// OID returns the identifier of the parameter set in the IANA registry of
+// XMSS or XMSS^MT, which prefixes the encoding of the keys.
+func (id ID) OID() uint32 { return id.params().oid }
+
+// MaxSignatures returns the number of signatures that a private key of
+// the parameter set can issue.
+func (id ID) MaxSignatures() uint64 { return 1 << uint(id.params().h) }
+
+// PublicKeySize returns the size in bytes of a packed public key.
+func (id ID) PublicKeySize() int { return id.params().pkBytes }
+
+// PrivateKeySize returns the size in bytes of a packed private key.
+func (id ID) PrivateKeySize() int { return id.params().skBytes }
+
+// SignatureSize returns the size in bytes of a signature.
+func (id ID) SignatureSize() int { return id.params().sigBytes }
@@ -0,0 +1,103 @@
+package xmss
+
+// ltree compresses a WOTS+ public key into a leaf with an unbalanced binary
+// tree. The public key is overwritten.
+func (s *state) ltree(leaf, pk []byte, addr *address) {
+	n := s.n
+	height := uint32(0)
+	for l := s.wotsLen; l > 1; l = (l + 1) / 2 {
+		addr.setTreeHeight(height)
+		for i := 0; i < l/2; i++ {
+			addr.setTreeIndex(uint32(i))
+			s.randHash(pk[i*n:], pk[2*i*n:(2*i+1)*n], pk[(2*i+1)*n:(2*i+2)*n], addr)
+		}
+		if l&1 == 1 {
+			copy(pk[(l/2)*n:], pk[(l-1)*n:l*n])
+		}
+		height++
+	}
+	copy(leaf[:n], pk)
+}
+
+// genLeaf computes the leaf idx of the tree of a layer, that is, the
+// compressed public key of the WOTS+ key pair idx.
+func (s *state) genLeaf(leaf, pk []byte, layer uint32, tree uint64, idx uint32) {
+	ots := newAddress(layer, tree, addrOTS)
+	ots.setOTS(idx)
+	s.wotsGenPK(pk, &ots)
+	lt := newAddress(layer, tree, addrLTree)
+	lt.setLTree(idx)
+	s.ltree(leaf, pk, &lt)
+}
+
+// subtree holds all the nodes of one of the trees of a layer, from which
+// the authentication paths of its leaves are read.
+type subtree struct {
+	layer uint32
+	index uint64
+	nodes [][]byte // nodes[k] holds the nodes of height k.
+}
+
+// buildTree computes the nodes of the tree of a layer with the given index.
+func (s *state) buildTree(layer uint32, index uint64) *subtree {
+	n := s.n
+	t := &subtree{layer: layer, index: index}
+	t.nodes = make([][]byte, s.hPrime+1)
+	leaves := make([]byte, n<<uint(s.hPrime))
+	pk := make([]byte, s.wotsBytes)
+	for i := 0; i < 1<<uint(s.hPrime); i++ {
+		s.genLeaf(leaves[i*n:], pk, layer, index, uint32(i))
+	}
+	t.nodes[0] = leaves
+
+	addr := newAddress(layer, index, addrHashTree)
+	for k := 1; k <= s.hPrime; k++ {
+		below := t.nodes[k-1]
+		level := make([]byte, len(below)/2)
+		addr.setTreeHeight(uint32(k - 1))
+		for j := 0; j < len(level)/n; j++ {
+			addr.setTreeIndex(uint32(j))
+			s.randHash(level[j*n:], below[2*j*n:(2*j+1)*n], below[(2*j+1)*n:(2*j+2)*n], &addr)
+		}
+		t.nodes[k] = level
+	}
+	return t
+}
+
+func (t *subtree) root() []byte { return t.nodes[len(t.nodes)-1] }
+
+// authPath writes the siblings of the nodes on the path from the leaf idx
+// to the root.
+func (t *subtree) authPath(path []byte, idx uint32) {
+	n := len(t.root())
+	for k := 0; k < len(t.nodes)-1; k++ {
+		j := int(idx>>uint(k)) ^ 1
+		copy(path[k*n:], t.nodes[k][j*n:(j+1)*n])
+	}
+}
+
+// rootFromSig computes the root of the tree of a layer from the WOTS+
+// signature of msg by the leaf idx followed by its authentication path.
+func (s *state) rootFromSig(root, msg, sig []byte, layer uint32, tree uint64, idx uint32) {
+	n := s.n
+	pk := make([]byte, s.wotsBytes)
+	ots := newAddress(layer, tree, addrOTS)
+	ots.setOTS(idx)
+	s.wotsPkFromSig(pk, sig, msg, &ots)
+	lt := newAddress(layer, tree, addrLTree)
+	lt.setLTree(idx)
+	s.ltree(root, pk, &lt)
+
+	auth := sig[s.wotsBytes:]
+	addr := newAddress(layer, tree, addrHashTree)
+	for k := 0; k < s.hPrime; k++ {
+		addr.setTreeHeight(uint32(k))
+		addr.setTreeIndex(idx >> uint(k+1))
+		sibling := auth[k*n : (k+1)*n]
+		if (idx>>uint(k))&1 == 0 {
+			s.randHash(root, root, sibling, &addr)
+		} else {
+			s.randHash(root, sibling, root, &addr)
+		}
+	}
+}
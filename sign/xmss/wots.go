@@ -0,0 +1,72 @@
+package xmss
+
+// chainLengths converts the n-byte message into base-w digits, followed by
+// the digits of its checksum.
+func (s *state) chainLengths(lengths []uint32, msg []byte) {
+	len1 := 2 * s.n
+	for i, b := range msg[:s.n] {
+		lengths[2*i] = uint32(b >> 4)
+		lengths[2*i+1] = uint32(b & (w - 1))
+	}
+	csum := uint32(0)
+	for _, l := range lengths[:len1] {
+		csum += w - 1 - l
+	}
+	for i := wotsLen2 - 1; i >= 0; i-- {
+		lengths[len1+i] = csum & (w - 1)
+		csum >>= logW
+	}
+}
+
+// chain applies steps iterations of the chaining function to in, which is
+// taken as the start-th value of the chain given by addr.
+func (s *state) chain(out, in []byte, start, steps uint32, addr *address) {
+	copy(out[:s.n], in)
+	for i := start; i < start+steps && i < w; i++ {
+		addr.setHash(i)
+		s.f(out, out[:s.n], addr)
+	}
+}
+
+// wotsSecret derives the start of the i-th chain of the WOTS+ key at addr.
+func (s *state) wotsSecret(out []byte, i int, addr *address) {
+	addr.setChain(uint32(i))
+	addr.setHash(0)
+	addr.setKeyAndMask(0)
+	s.prfKeygen(out, addr)
+}
+
+// wotsGenPK computes the WOTS+ public key at addr, as the concatenation of
+// the ends of the chains.
+func (s *state) wotsGenPK(pk []byte, addr *address) {
+	n := s.n
+	for i := 0; i < s.wotsLen; i++ {
+		node := pk[i*n : (i+1)*n]
+		s.wotsSecret(node, i, addr)
+		s.chain(node, node, 0, w-1, addr)
+	}
+}
+
+// wotsSign computes the WOTS+ signature of the n-byte msg with the key at
+// addr.
+func (s *state) wotsSign(sig, msg []byte, addr *address) {
+	n := s.n
+	lengths := make([]uint32, s.wotsLen)
+	s.chainLengths(lengths, msg)
+	for i, l := range lengths {
+		node := sig[i*n : (i+1)*n]
+		s.wotsSecret(node, i, addr)
+		s.chain(node, node, 0, l, addr)
+	}
+}
+
+// wotsPkFromSig computes the WOTS+ public key from a signature of msg.
+func (s *state) wotsPkFromSig(pk, sig, msg []byte, addr *address) {
+	n := s.n
+	lengths := make([]uint32, s.wotsLen)
+	s.chainLengths(lengths, msg)
+	for i, l := range lengths {
+		addr.setChain(uint32(i))
+		s.chain(pk[i*n:], sig[i*n:(i+1)*n], l, w-1-l, addr)
+	}
+}
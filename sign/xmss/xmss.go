@@ -0,0 +1,301 @@
+package xmss
+
+import (
+	"bytes"
+	cryptoRand "crypto/rand"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"io"
+	"sync"
+)
+
+// StateStore persists the state of a private key, so that none of its
+// one-time keys is ever used twice. It is supplied by the caller and
+// typically writes to a file, a database or a hardware token.
+type StateStore interface {
+	// Store saves the packed private key sk, replacing any previous state
+	// of the key. It must not return nil before the state is durably
+	// written, since a signature may be released right after it returns.
+	Store(sk []byte) error
+}
+
+// PublicKey is an XMSS or XMSS^MT public key, which is encoded as the OID of
+// the parameter set, the root of the tree, and the public seed.
+type PublicKey struct {
+	id ID
+	pk []byte
+}
+
+// PrivateKey is an XMSS or XMSS^MT private key. Its packed form consists of
+// the OID of the parameter set, the index of the next unused one-time key,
+// the secret seed, the PRF key, the root of the tree, and the public seed.
+//
+// A private key caches the nodes of the trees in use on each layer, which
+// takes 2^(h/d+1)*n bytes per layer. It is safe for concurrent use.
+type PrivateKey struct {
+	id    ID
+	sk    []byte
+	store StateStore
+
+	mu    sync.Mutex
+	trees []*subtree
+}
+
+var (
+	errSize      = errors.New("xmss: wrong size")
+	errID        = errors.New("xmss: invalid parameter set")
+	errOID       = errors.New("xmss: OID does not match the parameter set")
+	errIndex     = errors.New("xmss: invalid index")
+	errNoStore   = errors.New("xmss: missing state store")
+	errExhausted = errors.New("xmss: private key exhausted")
+)
+
+// GenerateKey generates a key pair using entropy from rand, and saves the
+// new private key with store. If rand is nil, crypto/rand.Reader is used.
+// The key is returned only after store succeeds.
+//
+// Key generation computes every leaf of the top tree, which for XMSS means
+// every WOTS+ key pair of the private key.
+func GenerateKey(id ID, rand io.Reader, store StateStore) (*PublicKey, *PrivateKey, error) {
+	if id >= numIDs {
+		return nil, nil, errID
+	}
+	if store == nil {
+		return nil, nil, errNoStore
+	}
+	if rand == nil {
+		rand = cryptoRand.Reader
+	}
+	p := id.params()
+	n := p.n
+	seed := make([]byte, 3*n)
+	if _, err := io.ReadFull(rand, seed); err != nil {
+		return nil, nil, err
+	}
+
+	sk := &PrivateKey{id: id, sk: make([]byte, p.skBytes), store: store}
+	binary.BigEndian.PutUint32(sk.sk, p.oid)
+	copy(sk.skSeed(), seed[:n])
+	copy(sk.skPrf(), seed[n:2*n])
+	copy(sk.pubSeed(), seed[2*n:])
+
+	s := newState(p, sk.pubSeed(), sk.skSeed())
+	top := s.buildTree(uint32(p.d-1), 0)
+	copy(sk.root(), top.root())
+	sk.trees = make([]*subtree, p.d)
+	sk.trees[p.d-1] = top
+
+	if err := store.Store(sk.pack()); err != nil {
+		return nil, nil, err
+	}
+	return sk.Public(), sk, nil
+}
+
+// Sign returns a signature of msg using the next unused one-time key of sk.
+//
+// The index of the key is advanced and the new state is saved with the
+// state store before the signature is computed. If the store fails, no
+// signature is produced and the error is returned; the one-time key stays
+// consumed, so that it is not reused should the state have been partially
+// written.
+func Sign(sk *PrivateKey, msg []byte) ([]byte, error) {
+	sk.mu.Lock()
+	defer sk.mu.Unlock()
+	if sk.store == nil {
+		return nil, errNoStore
+	}
+	p := sk.id.params()
+	idx := sk.index()
+	if idx >= sk.id.MaxSignatures() {
+		return nil, errExhausted
+	}
+	sk.setIndex(idx + 1)
+	if err := sk.store.Store(sk.pack()); err != nil {
+		return nil, err
+	}
+
+	n := p.n
+	s := newState(p, sk.pubSeed(), sk.skSeed())
+	sig := make([]byte, p.sigBytes)
+	putIndex(sig[:p.idxBytes], idx)
+	r := sig[p.idxBytes : p.idxBytes+n]
+	s.prfMsg(r, sk.skPrf(), idx)
+	digest := make([]byte, n)
+	s.hashMsg(digest, r, sk.root(), idx, msg)
+	rest := sig[p.idxBytes+n:]
+
+	if sk.trees == nil {
+		sk.trees = make([]*subtree, p.d)
+	}
+	mask := uint64(1)<<uint(p.hPrime) - 1
+	tree, leaf := idx>>uint(p.hPrime), uint32(idx&mask)
+	for j := 0; j < p.d; j++ {
+		t := sk.trees[j]
+		if t == nil || t.index != tree {
+			t = s.buildTree(uint32(j), tree)
+			sk.trees[j] = t
+		}
+		ots := newAddress(uint32(j), tree, addrOTS)
+		ots.setOTS(leaf)
+		s.wotsSign(rest, digest, &ots)
+		t.authPath(rest[p.wotsBytes:], leaf)
+		rest = rest[p.wotsBytes+p.hPrime*n:]
+
+		digest = t.root()
+		leaf = uint32(tree & mask)
+		tree >>= uint(p.hPrime)
+	}
+	return sig, nil
+}
+
+// Verify returns true if sig is a valid signature of msg under pk.
+func Verify(pk *PublicKey, msg, sig []byte) bool {
+	p := pk.id.params()
+	n := p.n
+	if len(sig) != p.sigBytes {
+		return false
+	}
+	idx := getIndex(sig[:p.idxBytes])
+	if idx >= pk.id.MaxSignatures() {
+		return false
+	}
+	root, pubSeed := pk.pk[4:4+n], pk.pk[4+n:]
+	s := newState(p, pubSeed, nil)
+	digest := make([]byte, n)
+	s.hashMsg(digest, sig[p.idxBytes:p.idxBytes+n], root, idx, msg)
+	sig = sig[p.idxBytes+n:]
+
+	node := make([]byte, n)
+	mask := uint64(1)<<uint(p.hPrime) - 1
+	tree, leaf := idx>>uint(p.hPrime), uint32(idx&mask)
+	for j := 0; j < p.d; j++ {
+		s.rootFromSig(node, digest, sig, uint32(j), tree, leaf)
+		sig = sig[p.wotsBytes+p.hPrime*n:]
+		digest, node = node, digest
+
+		leaf = uint32(tree & mask)
+		tree >>= uint(p.hPrime)
+	}
+	return subtle.ConstantTimeCompare(digest, root) == 1
+}
+
+func putIndex(b []byte, idx uint64) {
+	for i := len(b) - 1; i >= 0; i-- {
+		b[i] = byte(idx)
+		idx >>= 8
+	}
+}
+
+func getIndex(b []byte) (idx uint64) {
+	for _, v := range b {
+		idx = idx<<8 | uint64(v)
+	}
+	return idx
+}
+
+func (sk *PrivateKey) index() uint64 { return getIndex(sk.sk[4 : 4+sk.id.params().idxBytes]) }
+
+func (sk *PrivateKey) setIndex(idx uint64) { putIndex(sk.sk[4:4+sk.id.params().idxBytes], idx) }
+
+func (sk *PrivateKey) seedOffset() int { return 4 + sk.id.params().idxBytes }
+
+func (sk *PrivateKey) skSeed() []byte {
+	o, n := sk.seedOffset(), sk.id.params().n
+	return sk.sk[o : o+n]
+}
+
+func (sk *PrivateKey) skPrf() []byte {
+	o, n := sk.seedOffset(), sk.id.params().n
+	return sk.sk[o+n : o+2*n]
+}
+
+func (sk *PrivateKey) root() []byte {
+	o, n := sk.seedOffset(), sk.id.params().n
+	return sk.sk[o+2*n : o+3*n]
+}
+
+func (sk *PrivateKey) pubSeed() []byte {
+	o, n := sk.seedOffset(), sk.id.params().n
+	return sk.sk[o+3*n : o+4*n]
+}
+
+func (sk *PrivateKey) pack() []byte { return append([]byte{}, sk.sk...) }
+
+// ID returns the parameter set of the key.
+func (pk *PublicKey) ID() ID { return pk.id }
+
+// ID returns the parameter set of the key.
+func (sk *PrivateKey) ID() ID { return sk.id }
+
+// Remaining returns the number of signatures that sk can still issue.
+func (sk *PrivateKey) Remaining() uint64 {
+	sk.mu.Lock()
+	defer sk.mu.Unlock()
+	return sk.id.MaxSignatures() - sk.index()
+}
+
+// Public returns the public key corresponding to the private key.
+func (sk *PrivateKey) Public() *PublicKey {
+	pk := make([]byte, sk.id.params().pkBytes)
+	copy(pk, sk.sk[:4])
+	copy(pk[4:], sk.root())
+	copy(pk[4+len(sk.root()):], sk.pubSeed())
+	return &PublicKey{sk.id, pk}
+}
+
+// Equal returns true if both public keys are equal.
+func (pk *PublicKey) Equal(other *PublicKey) bool {
+	return pk.id == other.id && bytes.Equal(pk.pk, other.pk)
+}
+
+// MarshalBinary returns the packed public key.
+func (pk *PublicKey) MarshalBinary() ([]byte, error) {
+	return append([]byte{}, pk.pk...), nil
+}
+
+// MarshalBinary returns the packed private key, including its current
+// index.
+func (sk *PrivateKey) MarshalBinary() ([]byte, error) {
+	sk.mu.Lock()
+	defer sk.mu.Unlock()
+	return sk.pack(), nil
+}
+
+// UnmarshalPublicKey unpacks a public key of the given parameter set.
+func UnmarshalPublicKey(id ID, data []byte) (*PublicKey, error) {
+	if id >= numIDs {
+		return nil, errID
+	}
+	if len(data) != id.PublicKeySize() {
+		return nil, errSize
+	}
+	if binary.BigEndian.Uint32(data) != id.OID() {
+		return nil, errOID
+	}
+	return &PublicKey{id, append([]byte{}, data...)}, nil
+}
+
+// UnmarshalPrivateKey unpacks a private key of the given parameter set,
+// whose subsequent states are saved with store. The caller must ensure that
+// data is the latest state saved for this key: restoring an older state
+// would reuse one-time keys, which breaks the security of the scheme.
+func UnmarshalPrivateKey(id ID, data []byte, store StateStore) (*PrivateKey, error) {
+	if id >= numIDs {
+		return nil, errID
+	}
+	if store == nil {
+		return nil, errNoStore
+	}
+	if len(data) != id.PrivateKeySize() {
+		return nil, errSize
+	}
+	if binary.BigEndian.Uint32(data) != id.OID() {
+		return nil, errOID
+	}
+	sk := &PrivateKey{id: id, sk: append([]byte{}, data...), store: store}
+	if sk.index() > id.MaxSignatures() {
+		return nil, errIndex
+	}
+	return sk, nil
+}
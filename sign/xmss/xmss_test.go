@@ -0,0 +1,235 @@
+package xmss
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"flag"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+)
+
+// Indicates whether long tests should be run
+var runLongTest = flag.Bool("long", false, "runs longer tests")
+
+// memStore keeps the saved states in memory, and fails when err is set.
+type memStore struct {
+	states [][]byte
+	err    error
+}
+
+func (m *memStore) Store(sk []byte) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.states = append(m.states, append([]byte{}, sk...))
+	return nil
+}
+
+func (m *memStore) last() []byte { return m.states[len(m.states)-1] }
+
+func testIDs() []ID {
+	if !*runLongTest {
+		return []ID{XMSSSHA2H10, XMSSMTSHA2H20D4, XMSSMTSHAKE256H20D4N192, XMSSMTSHA2H60D12}
+	}
+	var ids []ID
+	for id := ID(0); id < numIDs; id++ {
+		if p := id.params(); p.hPrime <= 10 {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func TestSignVerify(t *testing.T) {
+	msg := []byte("XMSS signature")
+	for _, id := range testIDs() {
+		id := id
+		t.Run(id.String(), func(t *testing.T) {
+			store := &memStore{}
+			pk, sk, err := GenerateKey(id, rand.Reader, store)
+			test.CheckNoErr(t, err, "key generation failed")
+			if !pk.Equal(sk.Public()) {
+				t.Fatal("public key mismatch")
+			}
+			if got, want := len(store.states), 1; got != want {
+				test.ReportError(t, got, want, id)
+			}
+
+			// The second signature uses a new tree on the bottom layer.
+			p := id.params()
+			if p.mt {
+				putIndex(sk.sk[4:4+p.idxBytes], 1<<uint(p.hPrime)-1)
+			}
+			for i := 0; i < 2; i++ {
+				before := sk.Remaining()
+				sig, err := Sign(sk, msg)
+				test.CheckNoErr(t, err, "signing failed")
+				if got, want := sk.Remaining(), before-1; got != want {
+					test.ReportError(t, got, want, id)
+				}
+				if got, want := len(sig), id.SignatureSize(); got != want {
+					test.ReportError(t, got, want, id)
+				}
+				if !Verify(pk, msg, sig) {
+					t.Fatal("valid signature rejected")
+				}
+				packed, _ := sk.MarshalBinary()
+				if !bytes.Equal(store.last(), packed) {
+					t.Fatal("state not saved before signing")
+				}
+
+				if Verify(pk, msg[1:], sig) {
+					t.Fatal("signature of another message accepted")
+				}
+				if Verify(pk, msg, sig[1:]) {
+					t.Fatal("truncated signature accepted")
+				}
+				for _, j := range []int{0, p.idxBytes, len(sig) / 2, len(sig) - 1} {
+					sig[j] ^= 0x01
+					if Verify(pk, msg, sig) {
+						t.Fatalf("tampered signature accepted (byte %d)", j)
+					}
+					sig[j] ^= 0x01
+				}
+			}
+		})
+	}
+}
+
+func TestState(t *testing.T) {
+	id := XMSSMTSHA2H20D4
+	msg := []byte("XMSS signature")
+	store := &memStore{}
+	pk, sk, err := GenerateKey(id, rand.Reader, store)
+	test.CheckNoErr(t, err, "key generation failed")
+
+	// A failing store prevents signing, and burns the one-time key.
+	store.err = errors.New("disk full")
+	sig, err := Sign(sk, msg)
+	test.CheckIsErr(t, err, "should fail when the state is not stored")
+	if sig != nil {
+		t.Fatal("signature released without storing the state")
+	}
+	store.err = nil
+	sig, err = Sign(sk, msg)
+	test.CheckNoErr(t, err, "signing failed")
+	if got, want := getIndex(sig[:id.params().idxBytes]), uint64(1); got != want {
+		test.ReportError(t, got, want)
+	}
+
+	// A restored key resumes from the stored state.
+	sk2, err := UnmarshalPrivateKey(id, store.last(), store)
+	test.CheckNoErr(t, err, "unmarshal failed")
+	sig2, err := Sign(sk2, msg)
+	test.CheckNoErr(t, err, "signing failed")
+	if got, want := getIndex(sig2[:id.params().idxBytes]), uint64(2); got != want {
+		test.ReportError(t, got, want)
+	}
+	if !Verify(pk, msg, sig2) {
+		t.Fatal("valid signature rejected")
+	}
+
+	// An exhausted key refuses to sign.
+	data, _ := sk2.MarshalBinary()
+	putIndex(data[4:4+id.params().idxBytes], id.MaxSignatures()-1)
+	sk3, err := UnmarshalPrivateKey(id, data, store)
+	test.CheckNoErr(t, err, "unmarshal failed")
+	_, err = Sign(sk3, msg)
+	test.CheckNoErr(t, err, "signing failed")
+	if sk3.Remaining() != 0 {
+		t.Fatal("key should be exhausted")
+	}
+	_, err = Sign(sk3, msg)
+	test.CheckIsErr(t, err, "should fail on exhausted key")
+
+	_, err = Sign(&PrivateKey{id: id, sk: data}, msg)
+	test.CheckIsErr(t, err, "should fail without a state store")
+	_, _, err = GenerateKey(id, rand.Reader, nil)
+	test.CheckIsErr(t, err, "should fail without a state store")
+}
+
+func TestMarshal(t *testing.T) {
+	id := XMSSMTSHAKE256H20D4
+	store := &memStore{}
+	pk, sk, err := GenerateKey(id, rand.Reader, store)
+	test.CheckNoErr(t, err, "key generation failed")
+
+	ppk, err := pk.MarshalBinary()
+	test.CheckNoErr(t, err, "marshal failed")
+	psk, err := sk.MarshalBinary()
+	test.CheckNoErr(t, err, "marshal failed")
+	if got, want := len(ppk), id.PublicKeySize(); got != want {
+		test.ReportError(t, got, want)
+	}
+	if got, want := len(psk), id.PrivateKeySize(); got != want {
+		test.ReportError(t, got, want)
+	}
+
+	pk2, err := UnmarshalPublicKey(id, ppk)
+	test.CheckNoErr(t, err, "unmarshal failed")
+	sk2, err := UnmarshalPrivateKey(id, psk, store)
+	test.CheckNoErr(t, err, "unmarshal failed")
+	if !pk.Equal(pk2) || !pk.Equal(sk2.Public()) {
+		t.Fatal("keys differ after unmarshaling")
+	}
+
+	_, err = UnmarshalPublicKey(id, ppk[1:])
+	test.CheckIsErr(t, err, "should fail on wrong size")
+	_, err = UnmarshalPublicKey(XMSSMTSHAKE256H20D2, ppk)
+	test.CheckIsErr(t, err, "should fail on wrong OID")
+	_, err = UnmarshalPrivateKey(id, psk[1:], store)
+	test.CheckIsErr(t, err, "should fail on wrong size")
+	_, err = UnmarshalPrivateKey(id, psk, nil)
+	test.CheckIsErr(t, err, "should fail without a state store")
+	_, err = UnmarshalPublicKey(numIDs, ppk)
+	test.CheckIsErr(t, err, "should fail on invalid parameter set")
+}
+
+func TestParams(t *testing.T) {
+	for _, v := range []struct {
+		id      ID
+		name    string
+		oid     uint32
+		sigSize int
+	}{
+		{XMSSSHA2H10, "XMSS-SHA2_10_256", 0x01, 2500},
+		{XMSSSHA2H20N192, "XMSS-SHA2_20_192", 0x0f, 1732},
+		{XMSSSHAKE256H16, "XMSS-SHAKE256_16_256", 0x11, 2692},
+		{XMSSMTSHA2H20D2, "XMSSMT-SHA2_20/2_256", 0x01, 4963},
+		{XMSSMTSHA2H60D12, "XMSSMT-SHA2_60/12_256", 0x08, 27688},
+		{XMSSMTSHA2H40D8N192, "XMSSMT-SHA2_40/8_192", 0x25, 10781},
+		{XMSSMTSHAKE256H20D2, "XMSSMT-SHAKE256_20/2_256", 0x29, 4963},
+		{XMSSMTSHAKE256H60D12N192, "XMSSMT-SHAKE256_60/12_192", 0x38, 16160},
+	} {
+		if got, want := v.id.String(), v.name; got != want {
+			test.ReportError(t, got, want)
+		}
+		if got, want := v.id.OID(), v.oid; got != want {
+			test.ReportError(t, got, want, v.name)
+		}
+		if got, want := v.id.SignatureSize(), v.sigSize; got != want {
+			test.ReportError(t, got, want, v.name)
+		}
+	}
+}
+
+func BenchmarkXMSSMTSHA2H20D4(b *testing.B) {
+	id := XMSSMTSHA2H20D4
+	msg := []byte("XMSS signature")
+	store := &memStore{}
+	pk, sk, _ := GenerateKey(id, rand.Reader, store)
+	sig, _ := Sign(sk, msg)
+	b.Run("Sign", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			store.states = store.states[:0]
+			_, _ = Sign(sk, msg)
+		}
+	})
+	b.Run("Verify", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Verify(pk, msg, sig)
+		}
+	})
+}